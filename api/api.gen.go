@@ -119,6 +119,15 @@ type EAT struct {
 // EATEatProfile defines model for EAT.EatProfile.
 type EATEatProfile string
 
+// Format defines model for Format.
+type Format struct {
+	ContentType string `json:"content-type"`
+	NonceSize   uint32 `json:"nonce-size"`
+
+	// Transcoded The format is derived by ratsd from another format supported by the sub-attester.
+	Transcoded *bool `json:"transcoded,omitempty"`
+}
+
 // Option defines model for Option.
 type Option struct {
 	DataType OptionDataType `json:"data-type"`
@@ -128,8 +137,16 @@ type Option struct {
 // OptionDataType defines model for Option.DataType.
 type OptionDataType string
 
+// RejectedPlugin defines model for RejectedPlugin.
+type RejectedPlugin struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
 // SubAttester defines model for SubAttester.
 type SubAttester struct {
+	Formats *[]Format `json:"formats,omitempty"`
 	Name    string    `json:"name"`
 	Options *[]Option `json:"options,omitempty"`
 }
@@ -250,6 +267,9 @@ type ServerInterface interface {
 	// (POST /ratsd/chares)
 	RatsdChares(w http.ResponseWriter, r *http.Request, params RatsdCharesParams)
 
+	// (GET /ratsd/rejected-plugins)
+	RatsdRejectedPlugins(w http.ResponseWriter, r *http.Request)
+
 	// (GET /ratsd/subattesters)
 	RatsdSubattesters(w http.ResponseWriter, r *http.Request)
 }
@@ -309,6 +329,20 @@ func (siw *ServerInterfaceWrapper) RatsdChares(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// RatsdRejectedPlugins operation middleware
+func (siw *ServerInterfaceWrapper) RatsdRejectedPlugins(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RatsdRejectedPlugins(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // RatsdSubattesters operation middleware
 func (siw *ServerInterfaceWrapper) RatsdSubattesters(w http.ResponseWriter, r *http.Request) {
 
@@ -444,6 +478,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("POST "+options.BaseURL+"/ratsd/chares", wrapper.RatsdChares)
+	m.HandleFunc("GET "+options.BaseURL+"/ratsd/rejected-plugins", wrapper.RatsdRejectedPlugins)
 	m.HandleFunc("GET "+options.BaseURL+"/ratsd/subattesters", wrapper.RatsdSubattesters)
 
 	return m
@@ -452,24 +487,27 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RW32/jNgz+VwRtb3OctCvuwYc9pF0x3MNhRdvhHnrFQEtsrJsteRSdXlbkfx8k242d",
-	"Ov1xuOtTY1Hkx4/kRz1I5araWbTsZfYgayCokJHir7MCLtFf4r8Ner54PEpBKaw5WBgrM1kgaCSZSAsV",
-	"ykx2x4n0qsAKgh1v6nDimYxdye122x/GOKeguyDnRI4iEHI1EhuMBhoZTDnhKJHGegarcPLQM3ATPaBt",
-	"KpndnCwWt0lvZ5sqRwp2bLjEgZk0dg2l0YJaWHJ3aee8/bC7w7DKVoaLJk+Vq5LjxfFJtkYC452dE7DX",
-	"GYb0ss75Yd/bRIZDQ6ij43Dag3xManfN5V9QcYB09vHTU/J4Uw9hQl2XRgEbZ+drq9MeYsq+mhHWjviX",
-	"L95ZmbzKVOWOJulZQyzYnaMKWGYyB4/vThoq5Svyle39yRyHTRkigNYmQITyYpT3oe8HCjkIMeYPmNEz",
-	"0sxjiSp4jJ3PWD3rDohgIxP5deaqYFzzRmZMDW4TaV3Xsd/ATnt3ipnz5fXT6iPw3zW5O1O+sVkni2oD",
-	"E3rG7h+MLPxMeCcz+dN8JyPzbrDnoRn3wQ/R7HmbyujPuqd7Tw+AYbY/fx3KpJ/roA2Mq/hf7lyJEHu6",
-	"K0sXZDLJKGMPL9UhWCUDKFMJXDX5smufp1kciJNIF9P2ozZ7jumOpv3em0Q8hfIvCw0Xjsx/qH+8Ah+9",
-	"SoHDEvFeNANo31eFX/D8jRocUkbVkOHNVahOy94pAiEtGy7Cr1i2cCmPn3cTXzDX7XI09s5FPlta5OXy",
-	"+kqcr41Gq1CcubJTIvE7YOWsWF58CJKJ5OO8yEW6SI/aVkILtZGZ/DVdpAuZyBq4iKBaNuaqAGpR1q4V",
-	"VI1ekelGL+htWaJdoSD0tbMeQ7RULOOW9wKEerQAqxPR1M4K38T6JWKFFgkYveACBfYpGCvOl9fifi7O",
-	"Pn4SrQymMuKluHA+6JB2QHjWAkxGj5Ob6YnYmcxfeLxsb9sCo+dTpzchb+Uso213yqHN17LVLsi+lPCi",
-	"Eo5W1nbcW91K6MmNlTheLJ4BpKr7uHbfC1XdK/7t80TTv9tr+vn6+LMcI97tHmOBNpOLZxgWgWeNanN/",
-	"LwZKPglgf+r2oz/HV1hkkaVxJ14X2D/IRAG+7THUqNPQ6SfPclaTy0us3li4/YfpAVAeaY0klGtKLaxj",
-	"0ViNFFRRx67vQesGBTvRPy79xjJ87cAffXfwT1V9Av6ylVkzVtp0JGRx2oYSdnO7vQ0GXWv5Ju/fSLF7",
-	"VzghI38gCxCl8SzcXWTFN/ns8Z6ANZgS8hKFs4IL40UFqjAWD6jC1TDom8ZnNM+PPibIfdXuHW74pwv4",
-	"TT0c//4PAAD//2JvGhaSDQAA",
+	"H4sIAAAAAAAC/7RXT3PbthP9Khj8frdQf+J4cmAmB8V1Ozlk6rHdySH2dJbAWkRKAiywlKNk9N07AEiJ",
+	"lCBHTtObTSx2375dvF1948LUjdGoyfH8G2/AQo2ENvx3UcI1umv8u0VHV9ujKQiBDXkLpXnOSwSJlmdc",
+	"Q408591xxp0osQZvR+vGnziySi/5ZrPpD0OcdyC7IJfWGhuAWNOgJYXBQCKBqhKOMq60I9ACk4eOgNrg",
+	"AXVb8/zT+Xx+n/V2uq0LtN6OFFU4MONKr6BSktkIi99nh87jh90dgmW+VFS2xVSYOjubn53nK7SgnNEz",
+	"C+Rkjj69vHN+3Pcm4/5QWZTBsT/tQW6T2l0zxWcU5CFdfPh4SB6tmyFMaJpKCSBl9Gyl5bSHOCVXTyw2",
+	"xtKLz85onp1kKgpjk/SsIBTswdgaiOe8AIevz1tb8RPy5fF+MsdhU/oIIKXyEKG6GuV97PuRQg5CjPkD",
+	"InSEduKwQuE9+q+KsH7SHVgLa57xLxNTe+OG1jwn2+Im49p0HfsD7MS7KWYuF7eH1UegPxtrHlT1zGZN",
+	"FlWjI5QTMn9hYOH/Fh94zv8328nIrHvYM9+M++CHaPa8pTL6taNnPylhNKGmSf8ED3F6kiZOfR2z3CpN",
+	"r852FCtNuOwkwIJ2wkiUUXCcsKqJxea3JbLogynHJFq1QsmKNQtEsQdragbaUIm2t3Nt459HNKMSmWuL",
+	"Sd9J0x2CwpgKQR8QNcpwlE+Kp9+bvi33dBMIJvs61bGU9fqXbWnItnCybft2QZLNAHWK/f1+9VbZAEoq",
+	"gWv0f6G8qtqlSiRyJFTGG6AyeWARnNGJozS84Gd7KwXxpi0WXf0O8cWqu5EwPPU2usbeV4vjpGbchBqf",
+	"HqLriYMQqfxT+f6hoaXSWPUV5X8/ll+eNJb9ZuEcawfQfu5o/o7nHxzMPmUUrVW0vvHViey9Q7BoF21s",
+	"4FC2oAjh804hSqImbkxKP5jAZ6SFXy9ub9jlSknUAtmFqbrxxH4BrI1mi6v3fo6idVHG5tP59GVsJdTQ",
+	"KJ7zV9P5dN61fwAV2ZiJEmxE2RhHh4p4UUJVoV4is+gaox36aFO2CKufY8DE1gK0zFjbGM1cG+qXsSVq",
+	"tEDogjRin4LS7HJxyx5n7OLDx05JvVb6ngtbyHvp0/YILyLAbLSxfkq/iJ3J7Dsb7eY+FhgdvTNyPRg1",
+	"/s+j61BkK25N+bfB2vvkeBxCiQXe9Va3J/TkhkqczedPABL1Y9jF3jBRPwp6e5do+td7TT9bnd3xMeLd",
+	"QqI02HVyGxmGRaBJK2Lub9hgvL+9O+HV3fHT+fLbTWDpcDZ3JWMluNhjKFFOfaefP8lZY01RYf3Mwu3/",
+	"WjkCyqFdoWXCtJVk2hBrtUTrVVEyGoCWLTIyrP/F4daa4EsH/uVPB3+o6gn4iyizaqy005GQhdc2lLBP",
+	"95t7b9C1lu0m+qQJIz108BITUvIbEgNWKUfMPARmuhusd7FdoXxoZJXxPzcz9lgqUbJHtBjoraDVokR5",
+	"RDDGG4bjz3pchxyfNILHMROj+FndvBmw69qiXyafw+xwCXUMVqAqKCpkRjMqlWM1iFJpPELhzTDos/gb",
+	"qeXWx4sfpHW4if17TjebfwYAMWOjcgURAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
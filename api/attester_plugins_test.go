@@ -0,0 +1,202 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/attesters/dice"
+	"github.com/veraison/ratsd/attesters/nvidiagpu"
+	"github.com/veraison/ratsd/attesters/psa"
+	"github.com/veraison/ratsd/attesters/spdm"
+	"github.com/veraison/ratsd/attesters/static"
+	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/ratsd/verify"
+)
+
+// attesterItem returns the item of the given sub-attester in the response
+// to a successful request
+func attesterItem(t *testing.T, body []byte, name string) *cmw.CMW {
+	t.Helper()
+
+	c, err := decodeCharesClaims(t, body).GetCMW().GetCollectionItem(name)
+	require.NoError(t, err)
+
+	return c
+}
+
+func testdataPath(t *testing.T, pkg, name string) string {
+	t.Helper()
+
+	path, err := filepath.Abs(filepath.Join("..", "attesters", pkg, "testdata", name))
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestRatsdChares_attester_plugins(t *testing.T) {
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+
+	tests := []struct {
+		pkg  string
+		name string
+		// env configures the attester to return evidence, checked by check
+		env   func(t *testing.T) map[string]string
+		check func(t *testing.T, body []byte)
+		// unconfigured is the error of the attester without environment
+		unconfigured string
+	}{
+		{
+			pkg:  "nvidiagpu",
+			name: "nvidia-gpu",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					nvidiagpu.EnvBackend:    nvidiagpu.BackendLocal,
+					nvidiagpu.EnvLocalArchs: "HOPPER,BLACKWELL",
+				}
+			},
+			check: func(t *testing.T, body []byte) {
+				c := attesterItem(t, body, "nvidia-gpu")
+				assert.Equal(t, tokens.NvidiaGPUEvidenceMediaTypeJSON, c.GetMonadType())
+
+				var e tokens.NvidiaGPUEvidence
+				require.NoError(t, e.FromJSON(c.GetMonadValue()))
+				require.Len(t, e, 2)
+				assert.Equal(t, tokens.NvidiaGPUArchHopper, e[0].Arch)
+				assert.Equal(t, tokens.NvidiaGPUArchBlackwell, e[1].Arch)
+				for _, g := range e {
+					assert.Equal(t, tokens.HexString(adjustNonceForTest(t, realNonce, 32)), g.Nonce)
+				}
+			},
+			unconfigured: "no backend configured",
+		},
+		{
+			pkg:  "psa",
+			name: "psa",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					psa.EnvBackend: psa.BackendSoftware,
+					psa.EnvKey:     testdataPath(t, "psa", "iak.pem"),
+				}
+			},
+			check: func(t *testing.T, body []byte) {
+				c := attesterItem(t, body, "psa")
+				assert.Equal(t, tokens.PSATokenMediaType, c.GetMonadType())
+
+				// the largest nonce size allowed by PSA is selected
+				token, err := tokens.ParsePSAToken(c.GetMonadValue())
+				require.NoError(t, err)
+				assert.Equal(t, adjustNonceForTest(t, realNonce, 64), token.Claims.Nonce)
+			},
+			unconfigured: "no backend configured",
+		},
+		{
+			pkg:  "dice",
+			name: "dice",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					dice.EnvChain: testdataPath(t, "dice", "chain.pem"),
+					dice.EnvKey:   testdataPath(t, "dice", "alias.key"),
+				}
+			},
+			check: func(t *testing.T, body []byte) {
+				c := attesterItem(t, body, "dice")
+				assert.Equal(t, tokens.DICEChainMediaTypeJSON, c.GetMonadType())
+
+				var e tokens.DICEChain
+				require.NoError(t, e.FromJSON(c.GetMonadValue()))
+				assert.Equal(t, tokens.BinaryString(adjustNonceForTest(t, realNonce, 32)), e.Nonce)
+				assert.Len(t, e.Certificates, 3)
+				assert.NotEmpty(t, e.Signature)
+			},
+			unconfigured: "no chain configured",
+		},
+		{
+			pkg:  "spdm",
+			name: "spdm",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					spdm.EnvBackend:         spdm.BackendEmulator,
+					spdm.EnvEmulatorDevices: "nic0,gpu0",
+				}
+			},
+			check: func(t *testing.T, body []byte) {
+				c := attesterItem(t, body, "spdm")
+				assert.Equal(t, tokens.SPDMEvidenceMediaTypeJSON, c.GetMonadType())
+
+				var e tokens.SPDMEvidence
+				require.NoError(t, e.FromJSON(c.GetMonadValue()))
+				assert.Equal(t, tokens.BinaryString(adjustNonceForTest(t, realNonce, 32)), e.Nonce)
+				require.Len(t, e.Devices, 2)
+				for _, d := range e.Devices {
+					require.NoError(t, d.Verify([]*x509.Certificate{spdm.TrustAnchor()}, time.Time{}))
+				}
+			},
+			unconfigured: "no backend configured",
+		},
+		{
+			pkg:  "static",
+			name: "static",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					static.EnvConfig: testdataPath(t, "static", "artifacts.json"),
+				}
+			},
+			check: func(t *testing.T, body []byte) {
+				assert.Empty(t, decodeCharesClaims(t, body).GetNonceAdjustMap())
+
+				c := attesterItem(t, body, "static")
+				ct, err := c.GetCollectionType()
+				require.NoError(t, err)
+				assert.Equal(t, static.CollectionType, ct)
+
+				corim, err := os.ReadFile(testdataPath(t, "static", "corim.cbor"))
+				require.NoError(t, err)
+				r, err := c.GetCollectionItem("corim")
+				require.NoError(t, err)
+				assert.Equal(t, "application/rim+cbor", r.GetMonadType())
+				assert.Equal(t, corim, r.GetMonadValue())
+				assert.Equal(t, cmw.Indicator(cmw.ReferenceValues|cmw.Endorsements), r.GetMonadIndicator())
+
+				// the artifacts are reported as unbound rather than failing
+				// the nonce binding check
+				result, err := verify.Legacy(body, realNonce, verify.Config{})
+				require.NoError(t, err)
+				assert.Empty(t, result.Nonces)
+				assert.Equal(t, []string{"static"}, result.Unbound)
+			},
+			unconfigured: "no artifact configured",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := buildPlugin(t, tc.pkg)
+
+			t.Run("configured", func(t *testing.T) {
+				s := newPluginServer(t, dir, tc.name, tc.env(t))
+
+				w := pluginChares(s)
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+				tc.check(t, w.Body.Bytes())
+			})
+
+			t.Run("unconfigured", func(t *testing.T) {
+				s := newPluginServer(t, dir, tc.name, nil)
+
+				w := pluginChares(s)
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+				assert.Contains(t, w.Body.String(), tc.unconfigured)
+			})
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/attesters/faulty"
+)
+
+func faultyChares(s *Server, options string) *httptest.ResponseRecorder {
+	query := fmt.Sprintf(`{"nonce": "%s"}`, validNonce)
+	if options != "" {
+		query = fmt.Sprintf(`{"nonce": "%s", "faulty": %s}`, validNonce, options)
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", strings.NewReader(query))
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, RatsdCharesParams{})
+
+	return w
+}
+
+func TestRatsdChares_faulty_plugin(t *testing.T) {
+	dir := buildPlugin(t, "faulty")
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+
+	t.Run("no fault", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", nil)
+
+		for _, options := range []string{"", `{"latency": "50ms"}`} {
+			w := faultyChares(s, options)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			claims := decodeCharesClaims(t, w.Body.Bytes())
+			c, err := claims.GetCMW().GetCollectionItem("faulty")
+			require.NoError(t, err)
+			assert.Equal(t, faulty.MediaType, c.GetMonadType())
+
+			var e faulty.Evidence
+			require.NoError(t, json.Unmarshal(c.GetMonadValue(), &e))
+			assert.Equal(t, adjustNonceForTest(t, realNonce, 32), e.Nonce)
+		}
+	})
+
+	t.Run("status codes", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", nil)
+
+		tests := []struct {
+			statusCode string
+			expected   int
+		}{
+			{"400", http.StatusBadRequest},
+			{"500", http.StatusInternalServerError},
+			{"0", http.StatusInternalServerError},
+			{"200", http.StatusInternalServerError},
+			{"404", http.StatusInternalServerError},
+			{"418", http.StatusInternalServerError},
+			{"503", http.StatusInternalServerError},
+			{"4294967295", http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			t.Run(tt.statusCode, func(t *testing.T) {
+				w := faultyChares(s, fmt.Sprintf(
+					`{"fault": "status", "status_code": %q}`, tt.statusCode))
+				assert.Equal(t, tt.expected, w.Code)
+				assert.Contains(t, w.Body.String(),
+					"failed to get attestation report from faulty: injected failure")
+			})
+		}
+	})
+
+	t.Run("status code from config", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", map[string]string{
+			faulty.EnvFault:      "status",
+			faulty.EnvStatusCode: "400",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, faultyChares(s, "").Code)
+		assert.Equal(t, http.StatusOK, faultyChares(s, `{"fault": "none"}`).Code)
+	})
+
+	t.Run("malformed evidence", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", nil)
+
+		// ratsd does not look into the evidence, so the malformed evidence is
+		// returned as is
+		w := faultyChares(s, `{"fault": "malformed"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		claims := decodeCharesClaims(t, w.Body.Bytes())
+		c, err := claims.GetCMW().GetCollectionItem("faulty")
+		require.NoError(t, err)
+		assert.False(t, json.Valid(c.GetMonadValue()))
+	})
+
+	t.Run("wrong nonce size", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", nil)
+
+		w := faultyChares(s, `{"fault": "nonce-size"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		claims := decodeCharesClaims(t, w.Body.Bytes())
+		c, err := claims.GetCMW().GetCollectionItem("faulty")
+		require.NoError(t, err)
+
+		var e faulty.Evidence
+		require.NoError(t, json.Unmarshal(c.GetMonadValue(), &e))
+		assert.Len(t, e.Nonce, 16)
+		assert.Equal(t, map[string]uint{"faulty": 32}, claims.GetNonceAdjustMap())
+	})
+
+	t.Run("unsupported nonce size", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", map[string]string{faulty.EnvNonceSize: "0"})
+
+		w := faultyChares(s, "")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "failed to adjust nonce for attester faulty")
+	})
+
+	t.Run("no formats", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", map[string]string{faulty.EnvNoFormats: "true"})
+
+		w := faultyChares(s, "")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "no supported formats from attester faulty")
+	})
+
+	t.Run("nil status", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", nil)
+
+		w := faultyChares(s, `{"fault": "nil-status"}`)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "failed to get attestation report from faulty")
+	})
+
+	t.Run("nil status of supported formats", func(t *testing.T) {
+		s := newPluginServer(t, dir, "faulty", map[string]string{faulty.EnvFault: "nil-status"})
+
+		w := faultyChares(s, "")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "no supported formats from attester faulty")
+	})
+
+	for _, fault := range []string{"panic", "exit"} {
+		t.Run(fault, func(t *testing.T) {
+			s := newPluginServer(t, dir, "faulty", nil)
+
+			w := faultyChares(s, fmt.Sprintf(`{"fault": %q}`, fault))
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Contains(t, w.Body.String(), "failed to get attestation report from faulty")
+
+			// the plugin is gone, so later requests fail too
+			w = faultyChares(s, "")
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+		})
+	}
+}
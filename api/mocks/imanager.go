@@ -62,6 +62,20 @@ func (mr *MockIManagerMockRecorder) GetPluginList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginList", reflect.TypeOf((*MockIManager)(nil).GetPluginList))
 }
 
+// GetRejectedPlugins mocks base method.
+func (m *MockIManager) GetRejectedPlugins() []plugin.RejectedPlugin {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRejectedPlugins")
+	ret0, _ := ret[0].([]plugin.RejectedPlugin)
+	return ret0
+}
+
+// GetRejectedPlugins indicates an expected call of GetRejectedPlugins.
+func (mr *MockIManagerMockRecorder) GetRejectedPlugins() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRejectedPlugins", reflect.TypeOf((*MockIManager)(nil).GetRejectedPlugins))
+}
+
 // Init mocks base method.
 func (m *MockIManager) Init() error {
 	m.ctrl.T.Helper()
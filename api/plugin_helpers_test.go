@@ -0,0 +1,66 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/services/log"
+)
+
+// buildPlugin builds the attester in attesters/<pkg> into a fresh plugin
+// directory
+func buildPlugin(t *testing.T, pkg string) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping plugin integration test in short mode")
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, pkg+".plugin"),
+		"github.com/veraison/ratsd/attesters/"+pkg+"/plugin")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return dir
+}
+
+// newPluginServer launches the plugin in dir with the given environment, and
+// returns a server using it
+func newPluginServer(t *testing.T, dir, name string, env map[string]string) *Server {
+	t.Helper()
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	logger := log.Named("test")
+	manager, err := plugin.CreateGoPluginManager(dir, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Close() })
+
+	require.Equal(t, []string{name}, manager.GetPluginList())
+
+	return NewServer(logger, manager, "all")
+}
+
+// pluginChares requests evidence without options
+func pluginChares(s *Server) *httptest.ResponseRecorder {
+	query := fmt.Sprintf(`{"nonce": "%s"}`, validNonce)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", strings.NewReader(query))
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, RatsdCharesParams{})
+
+	return w
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/measure"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/ratsd/verify"
+	"github.com/veraison/services/log"
+)
+
+func TestRatsdChares_plugin_log(t *testing.T) {
+	dir := buildPlugin(t, "mocktsm")
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+
+	logger := log.Named("test")
+	register := measure.NewMockRegister(tokens.CELIndexCCMR, measure.DefaultRTMR, tpm2.TPMAlgSHA384)
+	pluginLog := measure.New(register)
+
+	loader, err := plugin.CreateGoPluginLoader(dir, logger)
+	require.NoError(t, err)
+	loader.SetMeasurer(pluginLog)
+
+	pluginManager, err := plugin.CreateGoPluginManagerWithLoader(loader, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { pluginManager.Close() })
+
+	manager := plugin.NewBuiltinManager(pluginManager)
+	require.NoError(t, manager.Register(measure.Name, pluginLog))
+
+	w := pluginChares(NewServer(logger, manager, "all"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	claims := decodeCharesClaims(t, w.Body.Bytes())
+	c, err := claims.GetCMW().GetCollectionItem(measure.Name)
+	require.NoError(t, err)
+	assert.Equal(t, tokens.CELMediaTypeJSON, c.GetMonadType())
+
+	cel := &tokens.CEL{}
+	require.NoError(t, cel.FromJSON(c.GetMonadValue()))
+	require.Len(t, cel.Records, 1)
+
+	// the plugin is recorded with the digest it was loaded with
+	info := pluginManager.GetPluginInfo()
+	require.Len(t, info, 1)
+	content := &tokens.CELPluginContent{}
+	require.NoError(t, content.FromTLV(cel.Records[0].Content))
+	assert.Equal(t, &tokens.CELPluginContent{Path: info[0].Path, Digest: info[0].Digest}, content)
+
+	registers, err := cel.Replay(tpm2.TPMAlgSHA384)
+	require.NoError(t, err)
+	assert.Equal(t, register.Value(tpm2.TPMAlgSHA384), registers[measure.DefaultRTMR])
+
+	result, err := verify.Legacy(w.Body.Bytes(), realNonce,
+		verify.Config{NonceExtractors: verify.FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Contains(t, result.Nonces, measure.Name)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/attesters/mocktsm"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/self"
+	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/ratsd/verify"
+	"github.com/veraison/services/log"
+)
+
+// newSelfServer returns a server with the plugin in dir and the ratsd-self
+// attester, anchored if anchor is not empty
+func newSelfServer(t *testing.T, dir string, anchor plugin.IPluggable) *Server {
+	t.Helper()
+
+	logger := log.Named("test")
+	pluginManager, err := plugin.CreateGoPluginManager(dir, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { pluginManager.Close() })
+
+	executable := filepath.Join(t.TempDir(), "ratsd")
+	require.NoError(t, os.WriteFile(executable, []byte("ratsd"), 0o755))
+
+	a, err := self.New(self.Config{
+		Executable: executable,
+		Plugins:    pluginManager.GetPluginInfo(),
+		StartTime:  time.Now(),
+		Anchor:     anchor,
+	})
+	require.NoError(t, err)
+
+	manager := plugin.NewBuiltinManager(pluginManager)
+	require.NoError(t, manager.Register(self.Name, a))
+	assert.ErrorContains(t, manager.Register("mock-tsm", a), `sub-attester "mock-tsm" is already registered`)
+	assert.ErrorContains(t, manager.Register(self.Name, a), `sub-attester "ratsd-self" is already registered`)
+	assert.ElementsMatch(t, []string{"mock-tsm", self.Name}, manager.GetPluginList())
+
+	return NewServer(logger, manager, "all")
+}
+
+func TestRatsdChares_ratsd_self(t *testing.T) {
+	dir := buildPlugin(t, "mocktsm")
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+
+	t.Run("unanchored", func(t *testing.T) {
+		w := pluginChares(newSelfServer(t, dir, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		claims := decodeCharesClaims(t, w.Body.Bytes())
+		c, err := claims.GetCMW().GetCollectionItem(self.Name)
+		require.NoError(t, err)
+		assert.Equal(t, tokens.RatsdSelfMediaTypeJSON, c.GetMonadType())
+
+		e := &tokens.RatsdSelfEvidence{}
+		require.NoError(t, e.FromJSON(c.GetMonadValue()))
+		require.Len(t, e.Plugins, 1)
+		assert.Equal(t, "mock-tsm", e.Plugins[0].Name)
+		assert.Len(t, e.Plugins[0].Digest, 32)
+
+		result, err := verify.Legacy(w.Body.Bytes(), realNonce,
+			verify.Config{NonceExtractors: verify.FakeTSMReportExtractors()})
+		require.NoError(t, err)
+		assert.Contains(t, result.Nonces, self.Name)
+		assert.Contains(t, result.Nonces, "mock-tsm")
+	})
+
+	t.Run("anchored", func(t *testing.T) {
+		w := pluginChares(newSelfServer(t, dir, mocktsm.GetPlugin()))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		claims := decodeCharesClaims(t, w.Body.Bytes())
+		c, err := claims.GetCMW().GetCollectionItem(self.Name)
+		require.NoError(t, err)
+
+		evidence, err := c.GetCollectionItem("evidence")
+		require.NoError(t, err)
+		anchor, err := c.GetCollectionItem("anchor")
+		require.NoError(t, err)
+		assert.Equal(t, tokens.TSMReportMediaTypeJSON, anchor.GetMonadType())
+
+		// the anchor is bound to the evidence rather than to the nonce
+		report := &tokens.TSMReport{}
+		require.NoError(t, report.FromJSON(anchor.GetMonadValue()))
+		assert.Contains(t, string(report.OutBlob),
+			hex.EncodeToString(tokens.RatsdSelfAnchorData(evidence.GetMonadValue())))
+
+		result, err := verify.Legacy(w.Body.Bytes(), realNonce,
+			verify.Config{NonceExtractors: verify.FakeTSMReportExtractors()})
+		require.NoError(t, err)
+		assert.Contains(t, result.Nonces, self.Name)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+// subAttesterCMWCollectionType is used for the CMW collection wrapping the
+// records returned by a sub-attester, unless the sub-attester supplies its own
+// collection type.
+const subAttesterCMWCollectionType = "tag:github.com,2026:veraison/ratsd/cmw/sub-attester"
+
+// maxIndicator is the largest CMW indicator bitmap defined by the CMW
+// specification.
+const maxIndicator = cmw.ReferenceValues | cmw.Endorsements | cmw.Evidence |
+	cmw.AttestationResults | cmw.TrustAnchors
+
+// recordsToCollection converts the records returned by a sub-attester into a
+// CMW collection of the supplied type.
+func recordsToCollection(collectionType string, records []*compositor.Record) (*cmw.CMW, error) {
+	if collectionType == "" {
+		collectionType = subAttesterCMWCollectionType
+	}
+
+	collection := cmw.NewCollection(collectionType)
+	if collection == nil {
+		return nil, fmt.Errorf("invalid CMW collection type %q", collectionType)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("empty CMW collection")
+	}
+
+	for _, r := range records {
+		if r == nil {
+			return nil, errors.New("nil record")
+		}
+
+		if r.Key == "" {
+			return nil, errors.New("record with empty key")
+		}
+
+		if _, err := collection.GetCollectionItem(r.Key); err == nil {
+			return nil, fmt.Errorf("duplicate record key %q", r.Key)
+		}
+
+		item, err := recordToCMW(r)
+		if err != nil {
+			return nil, fmt.Errorf("record %q: %w", r.Key, err)
+		}
+
+		if err := collection.AddCollectionItem(r.Key, item); err != nil {
+			return nil, fmt.Errorf("record %q: %w", r.Key, err)
+		}
+	}
+
+	return collection, nil
+}
+
+func recordToCMW(r *compositor.Record) (*cmw.CMW, error) {
+	if len(r.Records) > 0 {
+		if r.ContentType != "" || len(r.Value) > 0 {
+			return nil, errors.New("record has both a value and nested records")
+		}
+
+		return recordsToCollection(r.CollectionType, r.Records)
+	}
+
+	if r.ContentType == "" {
+		return nil, errors.New("missing content type")
+	}
+
+	if len(r.Value) == 0 {
+		return nil, errors.New("missing value")
+	}
+
+	if r.Indicator > maxIndicator {
+		return nil, fmt.Errorf("invalid CMW indicator %d", r.Indicator)
+	}
+
+	return cmw.NewMonad(r.ContentType, r.Value, cmw.Indicator(r.Indicator)), nil
+}
+
+// passthroughRecord returns the value of the first top-level record with the
+// supplied content type.
+func passthroughRecord(contentType string, records []*compositor.Record) ([]byte, bool) {
+	for _, r := range records {
+		if r != nil && r.ContentType == contentType && len(r.Value) > 0 {
+			return r.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/services/log"
+)
+
+// writePublisherKey generates an Ed25519 key, and writes its public key in
+// dir. It returns the private key and the path of the public key.
+func writePublisherKey(t *testing.T, dir, name string) (ed25519.PrivateKey, string) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name+".pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	return priv, path
+}
+
+// signPlugin writes the detached signature of the plugin at path
+func signPlugin(t *testing.T, path string, key ed25519.PrivateKey, encode bool) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	sig := ed25519.Sign(key, data)
+	if encode {
+		sig = []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+	}
+	require.NoError(t, os.WriteFile(path+plugin.SignatureExt, sig, 0o644))
+}
+
+// newSecureLoader returns a secure loader of the plugins in dir, configured
+// with the given publishers and checksums
+func newSecureLoader(t *testing.T, dir string, publishers, checksums map[string]any) *plugin.GoPluginLoader {
+	t.Helper()
+
+	loader, err := plugin.CreateGoPluginLoader(dir, log.Named("test"))
+	require.NoError(t, err)
+
+	if publishers != nil {
+		v := viper.New()
+		require.NoError(t, v.MergeConfigMap(publishers))
+		require.NoError(t, loader.SetPublisherKeys(v))
+	}
+	if checksums != nil {
+		v := viper.New()
+		require.NoError(t, v.MergeConfigMap(checksums))
+		require.NoError(t, loader.SetChecksum(v))
+	}
+
+	return loader
+}
+
+// newSecureServer launches the plugins in dir that pass the secure loader
+// configured with the given publishers and checksums
+func newSecureServer(t *testing.T, dir string, publishers, checksums map[string]any) (*Server, *plugin.GoPluginManager) {
+	t.Helper()
+
+	return newSecureServerWithLoader(t, newSecureLoader(t, dir, publishers, checksums))
+}
+
+func newSecureServerWithLoader(t *testing.T, loader *plugin.GoPluginLoader) (*Server, *plugin.GoPluginManager) {
+	t.Helper()
+
+	logger := log.Named("test")
+	manager, err := plugin.CreateGoPluginManagerWithLoader(loader, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Close() })
+
+	return NewServer(logger, manager, "all"), manager
+}
+
+func rejectedPlugins(t *testing.T, s *Server) []RejectedPlugin {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/ratsd/rejected-plugins", nil)
+	s.RatsdRejectedPlugins(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var rejected []RejectedPlugin
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rejected))
+
+	return rejected
+}
+
+func TestSecureLoader_signatures(t *testing.T) {
+	dir := buildPlugin(t, "mocktsm")
+	path := filepath.Join(dir, "mocktsm.plugin")
+	keys := t.TempDir()
+
+	vendor, vendorKey := writePublisherKey(t, keys, "vendor")
+	other, otherKey := writePublisherKey(t, keys, "other")
+	publishers := map[string]any{"vendor": vendorKey, "other": otherKey}
+
+	t.Run("signed", func(t *testing.T) {
+		for _, encode := range []bool{false, true} {
+			signPlugin(t, path, vendor, encode)
+
+			s, manager := newSecureServer(t, dir, publishers, nil)
+			assert.Empty(t, rejectedPlugins(t, s))
+
+			info := manager.GetPluginInfo()
+			require.Len(t, info, 1)
+			assert.Equal(t, "mock-tsm", info[0].Name)
+			assert.Equal(t, "vendor", info[0].Publisher)
+
+			w := pluginChares(s)
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("signed by another publisher", func(t *testing.T) {
+		signPlugin(t, path, other, false)
+
+		_, manager := newSecureServer(t, dir, map[string]any{"other": otherKey}, nil)
+		assert.Equal(t, "other", manager.GetPluginInfo()[0].Publisher)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		signPlugin(t, path, other, false)
+
+		s, manager := newSecureServer(t, dir, map[string]any{"vendor": vendorKey}, nil)
+		assert.Empty(t, manager.GetPluginList())
+		assert.Equal(t, []RejectedPlugin{
+			{
+				Name:   "mocktsm",
+				Path:   path,
+				Reason: "the signature of plugin mocktsm is not verified by any publisher key",
+			},
+		}, rejectedPlugins(t, s))
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		require.NoError(t, os.Remove(path+plugin.SignatureExt))
+
+		s, _ := newSecureServer(t, dir, publishers, nil)
+		rejected := rejectedPlugins(t, s)
+		require.Len(t, rejected, 1)
+		assert.Contains(t, rejected[0].Reason, "unable to read the signature of plugin mocktsm: ")
+	})
+
+	t.Run("malformed signature", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path+plugin.SignatureExt, []byte("signature"), 0o644))
+
+		s, _ := newSecureServer(t, dir, publishers, nil)
+		assert.Equal(t,
+			"unable to read the signature of plugin mocktsm: expected a 64-byte signature, raw or base64 encoded",
+			rejectedPlugins(t, s)[0].Reason)
+	})
+}
+
+func TestSecureLoader_checksums(t *testing.T) {
+	dir := buildPlugin(t, "mocktsm")
+	path := filepath.Join(dir, "mocktsm.plugin")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	digest := sha256.Sum256(data)
+
+	t.Run("pinned", func(t *testing.T) {
+		s, manager := newSecureServer(t, dir, nil, map[string]any{"mocktsm": hex.EncodeToString(digest[:])})
+		assert.Empty(t, rejectedPlugins(t, s))
+		assert.Equal(t, []string{"mock-tsm"}, manager.GetPluginList())
+		assert.Empty(t, manager.GetPluginInfo()[0].Publisher)
+	})
+
+	badChecksums := []struct {
+		name      string
+		checksums map[string]any
+		expected  string
+	}{
+		{
+			"mismatch",
+			map[string]any{"mocktsm": hex.EncodeToString(make([]byte, 32))},
+			"the checksum of plugin mocktsm does not match",
+		},
+		{
+			"missing",
+			map[string]any{"tsm": hex.EncodeToString(digest[:])},
+			"the checksum for plugin mocktsm is missing",
+		},
+	}
+
+	for _, tc := range badChecksums {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := newSecureLoader(t, dir, nil, tc.checksums)
+
+			_, err := plugin.CreateGoPluginManagerWithLoader(loader, log.Named("test"))
+			assert.ErrorContains(t, err, tc.expected)
+		})
+
+		t.Run(tc.name+" skipped", func(t *testing.T) {
+			loader := newSecureLoader(t, dir, nil, tc.checksums)
+			loader.SetSkipBadChecksums(true)
+
+			s, manager := newSecureServerWithLoader(t, loader)
+			assert.Empty(t, manager.GetPluginList())
+			assert.Equal(t, []RejectedPlugin{
+				{Name: "mocktsm", Path: path, Reason: tc.expected},
+			}, rejectedPlugins(t, s))
+		})
+	}
+
+	t.Run("pinned and signed", func(t *testing.T) {
+		key, pub := writePublisherKey(t, t.TempDir(), "vendor")
+		signPlugin(t, path, key, false)
+
+		s, manager := newSecureServer(t, dir, map[string]any{"vendor": pub},
+			map[string]any{"mocktsm": hex.EncodeToString(digest[:])})
+		assert.Empty(t, rejectedPlugins(t, s))
+		assert.Equal(t, "vendor", manager.GetPluginInfo()[0].Publisher)
+	})
+}
+
+func TestSecureLoader_publisher_keys_Fail(t *testing.T) {
+	loader, err := plugin.CreateGoPluginLoader(t.TempDir(), log.Named("test"))
+	require.NoError(t, err)
+
+	keys := t.TempDir()
+	notPEM := filepath.Join(keys, "key.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("key"), 0o644))
+
+	for _, tc := range []struct {
+		publishers map[string]any
+		expected   string
+	}{
+		{map[string]any{"vendor": 1}, `invalid key for publisher "vendor": expected string, got int`},
+		{map[string]any{"vendor": filepath.Join(keys, "none.pem")}, "failed to load the key of publisher vendor: open "},
+		{map[string]any{"vendor": notPEM}, "failed to load the key of publisher vendor: no PEM encoded public key in "},
+	} {
+		v := viper.New()
+		require.NoError(t, v.MergeConfigMap(tc.publishers))
+		assert.ErrorContains(t, loader.SetPublisherKeys(v), tc.expected)
+	}
+}
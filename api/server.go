@@ -3,13 +3,14 @@
 package api
 
 import (
-	"crypto/sha3"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/moogar0880/problems"
@@ -18,6 +19,7 @@ import (
 	"github.com/veraison/ratsd/proto/compositor"
 	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
 	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
+	"github.com/veraison/ratsd/tokens"
 	"go.uber.org/zap"
 )
 
@@ -42,6 +44,7 @@ type charesResponseFormat int
 const (
 	charesResponseLegacy charesResponseFormat = iota
 	charesResponseV2
+	charesResponsePassthrough
 )
 
 type charesResponse struct {
@@ -49,11 +52,12 @@ type charesResponse struct {
 	contentType string
 }
 
+// responseCodeToHTTP maps the status code of a failed GetEvidence call to the
+// HTTP status of the problem reported by ratsd
 func responseCodeToHTTP(responseCode uint32) int {
 	// Plugin should return 200 on success, 400 for caller input errors, and 500 for everything else.
+	// A failure reported with 200, or any other status code, is an error of the plugin.
 	switch responseCode {
-	case 200:
-		return http.StatusOK
 	case 400:
 		return http.StatusBadRequest
 	default:
@@ -62,36 +66,16 @@ func responseCodeToHTTP(responseCode uint32) int {
 }
 
 func adjustNonce(nonce []byte, size uint32) ([]byte, error) {
-	return adjustNonceWithFunction(nonce, size, nonceAdjustFunction)
+	return ratsdtoken.AdjustNonce(nonce, size, nonceAdjustFunction)
 }
 
-func adjustNonceWithFunction(nonce []byte, size uint32, function string) ([]byte, error) {
-	if size == 0 {
-		return nil, fmt.Errorf("nonce size must be greater than zero")
-	}
-
-	adjusted := make([]byte, int(size))
-	var h io.ReadWriter
-	switch function {
-	case ratsdtoken.NonceAdjustFunctionShake128:
-		h = sha3.NewSHAKE128()
-	case ratsdtoken.NonceAdjustFunctionShake256:
-		h = sha3.NewSHAKE256()
-	default:
-		return nil, fmt.Errorf("unsupported nonce adjustment function %q", function)
-	}
-
-	if _, err := h.Write(nonce); err != nil {
-		return nil, err
-	}
-	if _, err := h.Read(adjusted); err != nil {
-		return nil, err
-	}
-
-	return adjusted, nil
-}
-
-func negotiateCharesResponse(accept *string) (charesResponse, error) {
+// negotiateCharesResponse selects the response to a chares request from the
+// Accept header. The media ranges are tried in order of preference, see
+// acceptedMediaTypes. Besides the RATSD tokens, a range may select the native
+// evidence of the attester, if the request selects exactly one attester that
+// supports it: that evidence is then returned as is, without the RATSD token
+// wrapper.
+func (s *Server) negotiateCharesResponse(accept *string, payload []byte) (charesResponse, error) {
 	defaultResponse := charesResponse{
 		format:      charesResponseLegacy,
 		contentType: legacyCharesResponseMediaType,
@@ -100,27 +84,31 @@ func negotiateCharesResponse(accept *string) (charesResponse, error) {
 		return defaultResponse, nil
 	}
 
-	for _, offered := range splitAcceptHeader(*accept) {
-		offered = strings.TrimSpace(offered)
+	var (
+		native       []Format
+		nativeLoaded bool
+	)
+
+	for _, offered := range acceptedMediaTypes(*accept) {
 		if offered == "*/*" {
 			return defaultResponse, nil
 		}
 
-		mediaType, params, err := mime.ParseMediaType(offered)
-		if err != nil {
-			continue
+		if resp, ok := tokenResponse(offered); ok {
+			return resp, nil
 		}
 
-		switch mediaType {
-		case "application/eat-ucs+json":
-			if params["eat_profile"] == ratsdtoken.LegacyProfile {
-				return defaultResponse, nil
-			}
-		case "application/cmw+cbor":
-			if params["cmwct"] == ratsdtokenv2.Profile {
+		// the attester is only queried if no RATSD token is preferred
+		if !nativeLoaded {
+			native = s.passthroughFormats(payload)
+			nativeLoaded = true
+		}
+
+		for _, f := range native {
+			if mediaTypeMatches(offered, f.ContentType) {
 				return charesResponse{
-					format:      charesResponseV2,
-					contentType: v2CharesResponseMediaType,
+					format:      charesResponsePassthrough,
+					contentType: f.ContentType,
 				}, nil
 			}
 		}
@@ -134,6 +122,238 @@ func negotiateCharesResponse(accept *string) (charesResponse, error) {
 	)
 }
 
+// tokenResponse returns the RATSD token response matching the offered media
+// type, if any
+func tokenResponse(offered string) (charesResponse, bool) {
+	mediaType, params, err := mime.ParseMediaType(offered)
+	if err != nil {
+		return charesResponse{}, false
+	}
+
+	switch mediaType {
+	case "application/eat-ucs+json":
+		if params["eat_profile"] == ratsdtoken.LegacyProfile {
+			return charesResponse{
+				format:      charesResponseLegacy,
+				contentType: legacyCharesResponseMediaType,
+			}, true
+		}
+	case "application/cmw+cbor":
+		if params["cmwct"] == ratsdtokenv2.Profile {
+			return charesResponse{
+				format:      charesResponseV2,
+				contentType: v2CharesResponseMediaType,
+			}, true
+		}
+	}
+
+	return charesResponse{}, false
+}
+
+// passthroughFormats returns the formats of the attester selected by the
+// request, if it selects exactly one, and nil otherwise.
+func (s *Server) passthroughFormats(payload []byte) []Format {
+	requestFields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &requestFields); err != nil {
+		return nil
+	}
+
+	rawSelection, ok := requestFields["attester-selection"]
+	if !ok {
+		return nil
+	}
+
+	selectedAttesters := []string{}
+	if err := json.Unmarshal(rawSelection, &selectedAttesters); err != nil {
+		return nil
+	}
+
+	selectedAttesters = uniqueAttesters(selectedAttesters)
+	if len(selectedAttesters) != 1 {
+		return nil
+	}
+
+	attester, err := s.manager.LookupByName(selectedAttesters[0])
+	if err != nil {
+		return nil
+	}
+
+	formatOut := attester.GetSupportedFormats()
+	if formatOut.Status == nil || !formatOut.Status.Result {
+		return nil
+	}
+
+	return availableFormats(formatOut.Formats)
+}
+
+// selectFormat returns the format an attester is queried with in order to
+// produce evidence of content type ct. A format supported natively by the
+// attester is preferred over one that ratsd has to transcode.
+func selectFormat(formats []*compositor.Format, ct string) (*compositor.Format, bool) {
+	for _, f := range formats {
+		if f.ContentType == ct {
+			return f, true
+		}
+	}
+
+	for _, f := range formats {
+		if tokens.CanTranscode(f.ContentType, ct) {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+// availableFormats returns the formats supported natively by an attester,
+// followed by the formats ratsd can derive from them by transcoding.
+func availableFormats(formats []*compositor.Format) []Format {
+	available := make([]Format, 0, len(formats))
+	seen := make(map[string]struct{}, len(formats))
+	for _, f := range formats {
+		available = append(available, Format{
+			ContentType: f.ContentType,
+			NonceSize:   f.NonceSize,
+		})
+		seen[f.ContentType] = struct{}{}
+	}
+
+	transcoded := true
+	for _, f := range formats {
+		for _, ct := range tokens.EquivalentMediaTypes(f.ContentType) {
+			if _, ok := seen[ct]; ok {
+				continue
+			}
+			seen[ct] = struct{}{}
+			available = append(available, Format{
+				ContentType: ct,
+				NonceSize:   f.NonceSize,
+				Transcoded:  &transcoded,
+			})
+		}
+	}
+
+	return available
+}
+
+// transcodeEvidence converts the evidence returned by an attester from
+// content type from to content type to. If the attester returned typed
+// records, the top-level records of content type from are converted.
+func transcodeEvidence(out *compositor.EvidenceOut, from, to string) error {
+	if len(out.Records) == 0 {
+		evidence, err := tokens.Transcode(out.Evidence, from, to)
+		if err != nil {
+			return err
+		}
+		out.Evidence = evidence
+		return nil
+	}
+
+	for _, r := range out.Records {
+		if r == nil || r.ContentType != from {
+			continue
+		}
+
+		value, err := tokens.Transcode(r.Value, from, to)
+		if err != nil {
+			return fmt.Errorf("record %q: %w", r.Key, err)
+		}
+		r.ContentType = to
+		r.Value = value
+	}
+
+	return nil
+}
+
+// mediaTypeMatches reports whether the offered media type from an Accept
+// header is the same as the supplied content type. The "q" parameter of the
+// offered media type is not taken into account.
+func mediaTypeMatches(offered, contentType string) bool {
+	offeredType, offeredParams, err := mime.ParseMediaType(strings.TrimSpace(offered))
+	if err != nil {
+		return false
+	}
+	delete(offeredParams, "q")
+
+	ctType, ctParams, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if offeredType != ctType || len(offeredParams) != len(ctParams) {
+		return false
+	}
+
+	for k, v := range ctParams {
+		if offeredParams[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// uniqueAttesters returns the attester names in their original order, with
+// duplicates removed.
+func uniqueAttesters(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	unique := make([]string, 0, len(names))
+	for _, pn := range names {
+		if _, ok := seen[pn]; ok {
+			continue
+		}
+		seen[pn] = struct{}{}
+		unique = append(unique, pn)
+	}
+
+	return unique
+}
+
+// acceptedMediaTypes returns the media ranges of an Accept header in order of
+// preference: by decreasing "q" parameter, and in the order of the header for
+// equal ones. Ranges with q=0, which are not acceptable, and malformed ranges
+// are left out.
+func acceptedMediaTypes(accept string) []string {
+	type mediaRange struct {
+		value string
+		q     float64
+	}
+
+	var ranges []mediaRange
+	for _, offered := range splitAcceptHeader(accept) {
+		offered = strings.TrimSpace(offered)
+
+		_, params, err := mime.ParseMediaType(offered)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		if q == 0 {
+			continue
+		}
+
+		ranges = append(ranges, mediaRange{offered, q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	values := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		values = append(values, r.value)
+	}
+
+	return values
+}
+
 func splitAcceptHeader(accept string) []string {
 	values := []string{}
 	start := 0
@@ -187,7 +407,8 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 		return
 	}
 
-	resp, err := negotiateCharesResponse(param.Accept)
+	payload, _ := io.ReadAll(r.Body)
+	resp, err := s.negotiateCharesResponse(param.Accept, payload)
 	if param.Accept != nil {
 		s.logger.Info("request media type: ", *(param.Accept))
 	}
@@ -197,7 +418,6 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 		return
 	}
 
-	payload, _ := io.ReadAll(r.Body)
 	requestFields := make(map[string]json.RawMessage)
 	err = json.Unmarshal(payload, &requestFields)
 	if err != nil {
@@ -308,6 +528,7 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 	if resp.format == charesResponseLegacy {
 		collection = cmw.NewCollection(legacyCMWCollectionType)
 	}
+	var passthroughEvidence []byte
 	pl := s.manager.GetPluginList()
 	if len(pl) == 0 {
 		errMsg := "no sub-attester available"
@@ -318,6 +539,44 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 
 	options := requestFields
 
+	// addRecords embeds the typed records returned by a sub-attester as a
+	// nested CMW collection.
+	addRecords := func(pn string, ct string, out *compositor.EvidenceOut) bool {
+		if resp.format == charesResponsePassthrough {
+			value, ok := passthroughRecord(ct, out.Records)
+			if !ok {
+				errMsg := fmt.Sprintf("no %s record from attester %s", ct, pn)
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
+			passthroughEvidence = value
+			return true
+		}
+
+		c, err := recordsToCollection(out.CollectionType, out.Records)
+		if err != nil {
+			errMsg := fmt.Sprintf("invalid records from %s: %s", pn, err.Error())
+			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+			s.reportProblem(w, p)
+			return false
+		}
+
+		if resp.format == charesResponseV2 {
+			err = v2Evidence.SetCollectionItem(pn, *c)
+		} else {
+			err = collection.AddCollectionItem(pn, c)
+		}
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to add evidence from %s: %s", pn, err.Error())
+			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+			s.reportProblem(w, p)
+			return false
+		}
+
+		return true
+	}
+
 	getCMW := func(pn string) bool {
 		attester, err := s.manager.LookupByName(pn)
 		if err != nil {
@@ -329,9 +588,9 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 		}
 
 		formatOut := attester.GetSupportedFormats()
-		if !formatOut.Status.Result || len(formatOut.Formats) == 0 {
+		if formatOut.Status == nil || !formatOut.Status.Result || len(formatOut.Formats) == 0 {
 			errMsg := fmt.Sprintf("no supported formats from attester %s: %s ",
-				pn, formatOut.Status.Error)
+				pn, formatOut.Status.GetError())
 			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
 			s.reportProblem(w, p)
 			return false
@@ -341,6 +600,18 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 		var outputCt string
 		selectedFormat = formatOut.Formats[0]
 		outputCt = selectedFormat.ContentType
+		if resp.format == charesResponsePassthrough {
+			f, ok := selectFormat(formatOut.Formats, resp.contentType)
+			if !ok {
+				errMsg := fmt.Sprintf(
+					"%s does not support content type %s", pn, resp.contentType)
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
+			selectedFormat = f
+			outputCt = resp.contentType
+		}
 		params, hasOption := options[pn]
 		if !hasOption || string(params) == "null" {
 			params = json.RawMessage{}
@@ -361,13 +632,24 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 
 			validCt := false
 			if desiredCt, ok := attesterOptions["content-type"]; ok {
-				for _, f := range formatOut.Formats {
-					if f.ContentType == desiredCt {
-						selectedFormat = f
-						outputCt = selectedFormat.ContentType
-						validCt = true
-						break
+				if resp.format == charesResponsePassthrough && desiredCt != resp.contentType {
+					errMsg := fmt.Sprintf(
+						"content type %s for %s conflicts with accept type %s",
+						desiredCt, pn, resp.contentType)
+					p := &problems.DefaultProblem{
+						Type:   string(TagGithubCom2024VeraisonratsdErrorInvalidrequest),
+						Title:  string(InvalidRequest),
+						Detail: errMsg,
+						Status: http.StatusBadRequest,
 					}
+					s.reportProblem(w, p)
+					return false
+				}
+
+				if f, ok := selectFormat(formatOut.Formats, desiredCt); ok {
+					selectedFormat = f
+					outputCt = desiredCt
+					validCt = true
 				}
 
 				if !validCt {
@@ -386,63 +668,89 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 		}
 
 		s.logger.Info(pn, " output content type: ", outputCt)
-		attesterNonce, err := adjustNonce(nonce, selectedFormat.NonceSize)
-		if err != nil {
-			errMsg := fmt.Sprintf(
-				"failed to adjust nonce for attester %s: %s", pn, err.Error())
-			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
-			s.reportProblem(w, p)
-			return false
-		}
+		// The output of an unbound format, e.g., endorsements, is not bound
+		// to a nonce: no nonce is sent to the attester, and it is left out of
+		// the nonce adjustment map.
+		var attesterNonce []byte
+		if !selectedFormat.Unbound {
+			attesterNonce, err = adjustNonce(nonce, selectedFormat.NonceSize)
+			if err != nil {
+				errMsg := fmt.Sprintf(
+					"failed to adjust nonce for attester %s: %s", pn, err.Error())
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
 
-		if resp.format == charesResponseV2 {
-			err = v2Evidence.Claims.SetNonceAdjustFn(nonceAdjustFunction)
-		} else {
-			err = legacyEvidence.Claims.SetNonceAdjustFn(nonceAdjustFunction)
-		}
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to set nonce adjustment function: %s", err.Error())
-			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
-			s.reportProblem(w, p)
-			return false
-		}
+			switch resp.format {
+			case charesResponseV2:
+				err = v2Evidence.Claims.SetNonceAdjustFn(nonceAdjustFunction)
+			case charesResponseLegacy:
+				err = legacyEvidence.Claims.SetNonceAdjustFn(nonceAdjustFunction)
+			}
+			if err != nil {
+				errMsg := fmt.Sprintf("failed to set nonce adjustment function: %s", err.Error())
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
 
-		if resp.format == charesResponseV2 {
-			err = v2Evidence.Claims.SetKeyandNonceSz(pn, uint(selectedFormat.NonceSize))
-		} else {
-			err = legacyEvidence.Claims.SetKeyandNonceSz(pn, uint(selectedFormat.NonceSize))
-		}
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to set nonce adjustment map: %s", err.Error())
-			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
-			s.reportProblem(w, p)
-			return false
+			switch resp.format {
+			case charesResponseV2:
+				err = v2Evidence.Claims.SetKeyandNonceSz(pn, uint(selectedFormat.NonceSize))
+			case charesResponseLegacy:
+				err = legacyEvidence.Claims.SetKeyandNonceSz(pn, uint(selectedFormat.NonceSize))
+			}
+			if err != nil {
+				errMsg := fmt.Sprintf("failed to set nonce adjustment map: %s", err.Error())
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
 		}
 
 		in := &compositor.EvidenceIn{
-			ContentType: outputCt,
+			ContentType: selectedFormat.ContentType,
 			Nonce:       attesterNonce,
 			Options:     params,
 		}
 
 		out := attester.GetEvidence(in)
-		if !out.Status.Result {
+		if out.Status == nil || !out.Status.Result {
 			errMsg := fmt.Sprintf(
-				"failed to get attestation report from %s: %s ", pn, out.Status.Error)
+				"failed to get attestation report from %s: %s ", pn, out.Status.GetError())
 			p := problems.NewDetailedProblem(responseCodeToHTTP(out.StatusCode), errMsg)
 			s.reportProblem(w, p)
 			return false
 		}
 
-		if resp.format == charesResponseV2 {
-			if err := v2Evidence.SetToken(pn, in.ContentType, out.Evidence, cmw.Evidence); err != nil {
+		if outputCt != in.ContentType {
+			s.logger.Info(pn, " transcoding evidence from ", in.ContentType)
+			if err := transcodeEvidence(out, in.ContentType, outputCt); err != nil {
+				errMsg := fmt.Sprintf(
+					"failed to transcode evidence from %s: %s", pn, err.Error())
+				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
+				s.reportProblem(w, p)
+				return false
+			}
+		}
+
+		if len(out.Records) > 0 {
+			return addRecords(pn, outputCt, out)
+		}
+
+		switch resp.format {
+		case charesResponseV2:
+			if err := v2Evidence.SetToken(pn, outputCt, out.Evidence, cmw.Evidence); err != nil {
 				errMsg := fmt.Sprintf("failed to add evidence from %s: %s", pn, err.Error())
 				p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
 				s.reportProblem(w, p)
 				return false
 			}
-		} else {
-			c := cmw.NewMonad(in.ContentType, out.Evidence)
+		case charesResponsePassthrough:
+			passthroughEvidence = out.Evidence
+		default:
+			c := cmw.NewMonad(outputCt, out.Evidence)
 			collection.AddCollectionItem(pn, c)
 		}
 		return true
@@ -450,15 +758,7 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 
 	attestersToQuery := pl
 	if hasSelection {
-		seen := make(map[string]struct{}, len(selectedAttesters))
-		attestersToQuery = make([]string, 0, len(selectedAttesters))
-		for _, pn := range selectedAttesters {
-			if _, ok := seen[pn]; ok {
-				continue
-			}
-			seen[pn] = struct{}{}
-			attestersToQuery = append(attestersToQuery, pn)
-		}
+		attestersToQuery = uniqueAttesters(selectedAttesters)
 	}
 
 	for _, pn := range attestersToQuery {
@@ -468,7 +768,10 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 	}
 
 	var response []byte
-	if resp.format == charesResponseV2 {
+	switch resp.format {
+	case charesResponsePassthrough:
+		response = passthroughEvidence
+	case charesResponseV2:
 		// Token signing is not configured by the API yet, but COSE_Sign1
 		// serialization requires a non-empty signature field.
 		if err := v2Evidence.SetSignature([]byte{0}); err != nil {
@@ -478,7 +781,7 @@ func (s *Server) RatsdChares(w http.ResponseWriter, r *http.Request, param Ratsd
 			return
 		}
 		response, err = v2Evidence.MarshalCBOR()
-	} else {
+	default:
 		if err := legacyEvidence.Claims.SetCMW(collection); err != nil {
 			errMsg := fmt.Sprintf("failed to serialize CMW collection: %s", err.Error())
 			p := problems.NewDetailedProblem(http.StatusInternalServerError, errMsg)
@@ -520,6 +823,14 @@ func (s *Server) RatsdSubattesters(w http.ResponseWriter, r *http.Request) {
 			*options = append(*options, option)
 		}
 		entry := SubAttester{Name: pn, Options: options}
+
+		// An attester that cannot report its formats, e.g., because the
+		// underlying hardware is missing, is listed without them.
+		formatOut := attester.GetSupportedFormats()
+		if formatOut.Status != nil && formatOut.Status.Result && len(formatOut.Formats) > 0 {
+			formats := availableFormats(formatOut.Formats)
+			entry.Formats = &formats
+		}
 		resp = append(resp, entry)
 	}
 
@@ -527,3 +838,15 @@ func (s *Server) RatsdSubattesters(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
+
+func (s *Server) RatsdRejectedPlugins(w http.ResponseWriter, r *http.Request) {
+	resp := []RejectedPlugin{}
+
+	for _, p := range s.manager.GetRejectedPlugins() {
+		resp = append(resp, RejectedPlugin{Name: p.Name, Path: p.Path, Reason: p.Reason})
+	}
+
+	w.Header().Set("Content-Type", JsonType)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
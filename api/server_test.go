@@ -21,10 +21,12 @@ import (
 	mock_deps "github.com/veraison/ratsd/api/mocks"
 	"github.com/veraison/ratsd/attesters/mocktsm"
 	"github.com/veraison/ratsd/attesters/tsm"
+	"github.com/veraison/ratsd/plugin"
 	"github.com/veraison/ratsd/proto/compositor"
 	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
 	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
 	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/ratsd/verify"
 	"github.com/veraison/services/log"
 )
 
@@ -80,6 +82,8 @@ type testAttester struct {
 	expectedContentType string
 	expectedNonce       []byte
 	evidence            []byte
+	collectionType      string
+	records             []*compositor.Record
 }
 
 func (t *testAttester) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
@@ -89,9 +93,11 @@ func (t *testAttester) GetEvidence(in *compositor.EvidenceIn) *compositor.Eviden
 	assert.Equal(t.t, t.expectedNonce, in.Nonce)
 
 	return &compositor.EvidenceOut{
-		Status:     &compositor.Status{Result: true},
-		StatusCode: http.StatusOK,
-		Evidence:   t.evidence,
+		Status:         &compositor.Status{Result: true},
+		StatusCode:     http.StatusOK,
+		Evidence:       t.evidence,
+		CollectionType: t.collectionType,
+		Records:        t.records,
 	}
 }
 
@@ -134,11 +140,11 @@ func TestRatsdSubattesters_valid_requests(t *testing.T) {
 		},
 		{
 			"with only mocktsm attester",
-			"[{\"name\":\"mock-tsm\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"}]}]\n",
+			"[{\"formats\":[{\"content-type\":\"application/vnd.veraison.tsm-report+json\",\"nonce-size\":64},{\"content-type\":\"application/vnd.veraison.tsm-report+cbor\",\"nonce-size\":64}],\"name\":\"mock-tsm\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"},{\"data-type\":\"string\",\"name\":\"provider\"},{\"data-type\":\"string\",\"name\":\"service_provider\"},{\"data-type\":\"string\",\"name\":\"service_guid\"},{\"data-type\":\"string\",\"name\":\"service_manifest_version\"}]}]\n",
 		},
 		{
 			"with tsm and mocktsm attester",
-			"[{\"name\":\"mock-tsm\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"}]},{\"name\":\"tsm-report\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"}]}]\n",
+			"[{\"formats\":[{\"content-type\":\"application/vnd.veraison.tsm-report+json\",\"nonce-size\":64},{\"content-type\":\"application/vnd.veraison.tsm-report+cbor\",\"nonce-size\":64}],\"name\":\"mock-tsm\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"},{\"data-type\":\"string\",\"name\":\"provider\"},{\"data-type\":\"string\",\"name\":\"service_provider\"},{\"data-type\":\"string\",\"name\":\"service_guid\"},{\"data-type\":\"string\",\"name\":\"service_manifest_version\"}]},{\"name\":\"tsm-report\",\"options\":[{\"data-type\":\"string\",\"name\":\"privilege_level\"},{\"data-type\":\"string\",\"name\":\"service_provider\"},{\"data-type\":\"string\",\"name\":\"service_guid\"},{\"data-type\":\"string\",\"name\":\"service_manifest_version\"}]}]\n",
 		},
 	}
 
@@ -161,6 +167,47 @@ func TestRatsdSubattesters_valid_requests(t *testing.T) {
 
 }
 
+func TestRatsdRejectedPlugins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetRejectedPlugins().Return(nil).Times(1)
+	dm.EXPECT().GetRejectedPlugins().Return([]plugin.RejectedPlugin{
+		{
+			Name:   "tsm",
+			Path:   "/usr/lib/ratsd/tsm.plugin",
+			Reason: "the signature of plugin tsm is not verified by any publisher key",
+		},
+	}).Times(1)
+	logger := log.Named("test")
+	s := NewServer(logger, dm, "all")
+	tests := []struct {
+		name, response string
+	}{
+		{
+			"no rejected plugin",
+			"[]\n",
+		},
+		{
+			"with a rejected plugin",
+			"[{\"name\":\"tsm\",\"path\":\"/usr/lib/ratsd/tsm.plugin\",\"reason\":\"the signature of plugin tsm is not verified by any publisher key\"}]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, "/ratsd/rejected-plugins", nil)
+			s.RatsdRejectedPlugins(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, jsonType, w.Result().Header.Get("Content-Type"))
+			assert.Equal(t, tt.response, w.Body.String())
+		})
+	}
+}
+
 func TestRatsdChares_wrong_content_type(t *testing.T) {
 	expectedCode := http.StatusBadRequest
 	expectedType := problems.ProblemMediaType
@@ -646,3 +693,561 @@ func TestRatsdChares_valid_request_selected_attesters_in_all_mode(t *testing.T)
 	_, err = collection.GetCollectionItem("other-tsm")
 	assert.Error(t, err)
 }
+
+func TestRatsdChares_passthrough_single_attester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params RatsdCharesParams
+	param := fmt.Sprintf("%s;q=0.9", tokens.TSMReportMediaTypeJSON)
+	params.Accept = &param
+	logger := log.Named("test")
+
+	pluginList := []string{"mock-tsm", "other-tsm"}
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return(pluginList).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+
+	s := NewServer(logger, dm, "all")
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	adjustedNonce := adjustNonceForTest(t, realNonce, 64)
+
+	w := httptest.NewRecorder()
+	rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+		"attester-selection": ["mock-tsm", "mock-tsm"],
+		"mock-tsm":{"privilege_level":"1"}}`, validNonce))
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, params)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, tokens.TSMReportMediaTypeJSON, w.Result().Header.Get("Content-Type"))
+
+	tsmout := &tokens.TSMReport{}
+	require.NoError(t, tsmout.FromJSON(w.Body.Bytes()))
+	assert.Equal(t, "fake\n", tsmout.Provider)
+
+	expectedOutblob := fmt.Sprintf("privlevel: %d\ninblob: %s", 1,
+		hex.EncodeToString(adjustedNonce))
+	assert.Equal(t, tokens.BinaryString(expectedOutblob), tsmout.OutBlob)
+}
+
+func TestRatsdChares_accept_preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{"mock-tsm"}).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+
+	s := NewServer(log.Named("test"), dm, "all")
+	native := tokens.TSMReportMediaTypeJSON
+	selected := fmt.Sprintf(`{"nonce": "%s", "attester-selection": ["mock-tsm"]}`, validNonce)
+	unselected := fmt.Sprintf(`{"nonce": "%s"}`, validNonce)
+
+	tests := []struct {
+		name, accept, body string
+		code               int
+		contentType        string
+	}{
+		{"native before any", native + ", */*", selected, http.StatusOK, native},
+		{"native preferred to any", "*/*;q=0.1, " + native, selected, http.StatusOK, native},
+		{"any preferred to native", native + ";q=0.5, */*", selected,
+			http.StatusOK, legacyCharesResponseMediaType},
+		{"v2 preferred to native", native + ";q=0.5, " + v2CharesResponseMediaType, selected,
+			http.StatusOK, v2CharesResponseMediaType},
+		{"v2 not acceptable", v2CharesResponseMediaType + ";q=0, " + native, selected,
+			http.StatusOK, native},
+		{"equal preferences in order", v2CharesResponseMediaType + ";q=0.8, " + native + ";q=0.8",
+			selected, http.StatusOK, v2CharesResponseMediaType},
+		{"native without selection", native + ", */*", unselected,
+			http.StatusOK, legacyCharesResponseMediaType},
+		{"nothing acceptable", native + ";q=0", selected,
+			http.StatusNotAcceptable, problems.ProblemMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+			accept := tt.accept
+			s.RatsdChares(w, r, RatsdCharesParams{Accept: &accept})
+
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+			assert.Equal(t, tt.contentType, w.Result().Header.Get("Content-Type"))
+		})
+	}
+}
+
+func TestRatsdChares_passthrough_adjusts_nonce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params RatsdCharesParams
+	selectedCt := "application/vnd.veraison.test-selected"
+	param := fmt.Sprintf("application/json, %s", selectedCt)
+	params.Accept = &param
+	logger := log.Named("test")
+
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "format-attester"
+	attester := &testAttester{
+		t: t,
+		formats: []*compositor.Format{
+			{ContentType: "application/vnd.veraison.test-default", NonceSize: 16},
+			{ContentType: selectedCt, NonceSize: 32},
+		},
+		expectedContentType: selectedCt,
+		expectedNonce:       adjustNonceForTest(t, realNonce, 32),
+		evidence:            []byte("evidence"),
+	}
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{attesterName}).AnyTimes()
+	dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+	s := NewServer(logger, dm, "selected")
+	w := httptest.NewRecorder()
+	rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+		"attester-selection": ["%s"]}`, validNonce, attesterName))
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, params)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, selectedCt, w.Result().Header.Get("Content-Type"))
+	assert.Equal(t, []byte("evidence"), w.Body.Bytes())
+}
+
+func TestRatsdChares_passthrough_invalid_requests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	param := tokens.TSMReportMediaTypeJSON
+	params := RatsdCharesParams{Accept: &param}
+	logger := log.Named("test")
+
+	pluginList := []string{"mock-tsm", "other-tsm"}
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return(pluginList).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+	dm.EXPECT().LookupByName("other-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+
+	s := NewServer(logger, dm, "all")
+	tests := []struct {
+		name, body string
+		code       int
+		detail     string
+	}{
+		{
+			"no attester selection",
+			fmt.Sprintf(`{"nonce": "%s"}`, validNonce),
+			http.StatusNotAcceptable,
+			fmt.Sprintf("wrong accept type, expect %s or %s (got %s)",
+				legacyCharesResponseMediaType, v2CharesResponseMediaType, param),
+		},
+		{
+			"more than one attester selected",
+			fmt.Sprintf(`{"nonce": "%s",
+				"attester-selection": ["mock-tsm", "other-tsm"]}`, validNonce),
+			http.StatusNotAcceptable,
+			fmt.Sprintf("wrong accept type, expect %s or %s (got %s)",
+				legacyCharesResponseMediaType, v2CharesResponseMediaType, param),
+		},
+		{
+			"conflicting content type option",
+			fmt.Sprintf(`{"nonce": "%s",
+				"attester-selection": ["mock-tsm"],
+				"mock-tsm":{"content-type":"%s"}}`, validNonce, tokens.TSMReportMediaTypeCBOR),
+			http.StatusBadRequest,
+			fmt.Sprintf("content type %s for mock-tsm conflicts with accept type %s",
+				tokens.TSMReportMediaTypeCBOR, param),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rb := strings.NewReader(tt.body)
+			r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+			r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+			s.RatsdChares(w, r, params)
+
+			var body problems.DefaultProblem
+			_ = json.Unmarshal(w.Body.Bytes(), &body)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, problems.ProblemMediaType, w.Result().Header.Get("Content-Type"))
+			assert.Equal(t, tt.detail, body.Detail)
+		})
+	}
+}
+
+func TestRatsdChares_transcodes_evidence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := log.Named("test")
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{"mock-tsm"}).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+
+	s := NewServer(logger, dm, "all")
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	expectedOutblob := fmt.Sprintf("privlevel: %d\ninblob: %s", 0,
+		hex.EncodeToString(adjustNonceForTest(t, realNonce, 64)))
+
+	t.Run("content type option", func(t *testing.T) {
+		var params RatsdCharesParams
+		param := fmt.Sprintf(`application/eat-ucs+json; eat_profile=%q`, TagGithubCom2024Veraisonratsd)
+		params.Accept = &param
+
+		w := httptest.NewRecorder()
+		rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+			"mock-tsm":{"content-type":"%s"}}`, validNonce, tokens.TSMReportMediaTypeCBOR))
+		r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+		r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+		s.RatsdChares(w, r, params)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		claims := decodeCharesClaims(t, w.Body.Bytes())
+		assert.Equal(t, map[string]uint{"mock-tsm": 64}, claims.GetNonceAdjustMap())
+
+		c, err := claims.GetCMW().GetCollectionItem("mock-tsm")
+		require.NoError(t, err)
+		assert.Equal(t, tokens.TSMReportMediaTypeCBOR, c.GetMonadType())
+
+		tsmout := &tokens.TSMReport{}
+		require.NoError(t, tsmout.FromCBOR(c.GetMonadValue()))
+		assert.Equal(t, "fake\n", tsmout.Provider)
+		assert.Equal(t, tokens.BinaryString(expectedOutblob), tsmout.OutBlob)
+	})
+
+	t.Run("passthrough", func(t *testing.T) {
+		var params RatsdCharesParams
+		param := tokens.TSMReportMediaTypeCBOR
+		params.Accept = &param
+
+		w := httptest.NewRecorder()
+		rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+			"attester-selection": ["mock-tsm"]}`, validNonce))
+		r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+		r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+		s.RatsdChares(w, r, params)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, tokens.TSMReportMediaTypeCBOR, w.Result().Header.Get("Content-Type"))
+
+		tsmout := &tokens.TSMReport{}
+		require.NoError(t, tsmout.FromCBOR(w.Body.Bytes()))
+		assert.Equal(t, tokens.BinaryString(expectedOutblob), tsmout.OutBlob)
+	})
+}
+
+func TestRatsdChares_transcode_failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params RatsdCharesParams
+	param := fmt.Sprintf(`application/eat-ucs+json; eat_profile=%q`, TagGithubCom2024Veraisonratsd)
+	params.Accept = &param
+	logger := log.Named("test")
+
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "broken-tsm"
+	attester := &testAttester{
+		t: t,
+		formats: []*compositor.Format{
+			{ContentType: tokens.TSMReportMediaTypeJSON, NonceSize: 64},
+		},
+		expectedContentType: tokens.TSMReportMediaTypeJSON,
+		expectedNonce:       adjustNonceForTest(t, realNonce, 64),
+		evidence:            []byte("not a TSM report"),
+	}
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{attesterName}).AnyTimes()
+	dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+	s := NewServer(logger, dm, "all")
+	w := httptest.NewRecorder()
+	rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+		"%s":{"content-type":"%s"}}`, validNonce, attesterName, tokens.TSMReportMediaTypeCBOR))
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, params)
+
+	var body problems.DefaultProblem
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, body.Detail, "failed to transcode evidence from broken-tsm: decoding "+
+		tokens.TSMReportMediaTypeJSON)
+}
+
+func testRecords() []*compositor.Record {
+	return []*compositor.Record{
+		{
+			Key:         "report",
+			ContentType: "application/vnd.veraison.test-report",
+			Value:       []byte("report"),
+			Indicator:   cmw.Evidence,
+		},
+		{
+			Key:            "certs",
+			CollectionType: "tag:example.com,2026:certs",
+			Records: []*compositor.Record{
+				{
+					Key:         "vcek",
+					ContentType: "application/pkix-cert",
+					Value:       []byte("vcek"),
+					Indicator:   cmw.Endorsements,
+				},
+			},
+		},
+	}
+}
+
+func assertTestRecords(t *testing.T, c *cmw.CMW) {
+	t.Helper()
+
+	require.Equal(t, cmw.KindCollection, c.GetKind())
+	collectionType, err := c.GetCollectionType()
+	require.NoError(t, err)
+	assert.Equal(t, subAttesterCMWCollectionType, collectionType)
+
+	report, err := c.GetCollectionItem("report")
+	require.NoError(t, err)
+	assert.Equal(t, "application/vnd.veraison.test-report", report.GetMonadType())
+	assert.Equal(t, []byte("report"), report.GetMonadValue())
+	assert.Equal(t, cmw.Indicator(cmw.Evidence), report.GetMonadIndicator())
+
+	certs, err := c.GetCollectionItem("certs")
+	require.NoError(t, err)
+	require.Equal(t, cmw.KindCollection, certs.GetKind())
+	collectionType, err = certs.GetCollectionType()
+	require.NoError(t, err)
+	assert.Equal(t, "tag:example.com,2026:certs", collectionType)
+
+	vcek, err := certs.GetCollectionItem("vcek")
+	require.NoError(t, err)
+	assert.Equal(t, "application/pkix-cert", vcek.GetMonadType())
+	assert.Equal(t, []byte("vcek"), vcek.GetMonadValue())
+	assert.Equal(t, cmw.Indicator(cmw.Endorsements), vcek.GetMonadIndicator())
+}
+
+func TestRatsdChares_embeds_records(t *testing.T) {
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "records-attester"
+	ct := "application/vnd.veraison.test-report"
+
+	tests := []struct {
+		name, accept string
+		collection   func(t *testing.T, body []byte) cmw.CMW
+	}{
+		{
+			"legacy token",
+			legacyCharesResponseMediaType,
+			func(t *testing.T, body []byte) cmw.CMW {
+				c := decodeCharesClaims(t, body).GetCMW()
+				require.NotNil(t, c)
+				return *c
+			},
+		},
+		{
+			"v2 token",
+			v2CharesResponseMediaType,
+			func(t *testing.T, body []byte) cmw.CMW {
+				_, c, _ := decodeCharesV2(t, body)
+				return c
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attester := &testAttester{
+				t:                   t,
+				formats:             []*compositor.Format{{ContentType: ct, NonceSize: 32}},
+				expectedContentType: ct,
+				expectedNonce:       adjustNonceForTest(t, realNonce, 32),
+				records:             testRecords(),
+			}
+
+			dm := mock_deps.NewMockIManager(ctrl)
+			dm.EXPECT().GetPluginList().Return([]string{attesterName}).AnyTimes()
+			dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+			s := NewServer(log.Named("test"), dm, "all")
+			w := httptest.NewRecorder()
+			rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s"}`, validNonce))
+			r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+			r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+			accept := tt.accept
+			s.RatsdChares(w, r, RatsdCharesParams{Accept: &accept})
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.accept, w.Result().Header.Get("Content-Type"))
+
+			collection := tt.collection(t, w.Body.Bytes())
+			c, err := collection.GetCollectionItem(attesterName)
+			require.NoError(t, err)
+			assertTestRecords(t, c)
+		})
+	}
+}
+
+func TestRatsdChares_passthrough_records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "records-attester"
+	ct := "application/vnd.veraison.test-report"
+	attester := &testAttester{
+		t:                   t,
+		formats:             []*compositor.Format{{ContentType: ct, NonceSize: 32}},
+		expectedContentType: ct,
+		expectedNonce:       adjustNonceForTest(t, realNonce, 32),
+		records:             testRecords(),
+	}
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{attesterName}).AnyTimes()
+	dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+	s := NewServer(log.Named("test"), dm, "all")
+	w := httptest.NewRecorder()
+	rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s",
+		"attester-selection": ["%s"]}`, validNonce, attesterName))
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	s.RatsdChares(w, r, RatsdCharesParams{Accept: &ct})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ct, w.Result().Header.Get("Content-Type"))
+	assert.Equal(t, []byte("report"), w.Body.Bytes())
+}
+
+func TestRatsdChares_unbound_records(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "unbound-attester"
+	ct := "application/rim+cbor"
+	attester := &testAttester{
+		t:                   t,
+		formats:             []*compositor.Format{{ContentType: ct, Unbound: true}},
+		expectedContentType: ct,
+		records: []*compositor.Record{
+			{Key: "corim", ContentType: ct, Value: []byte("corim"), Indicator: uint32(cmw.Endorsements)},
+		},
+	}
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{"mock-tsm", attesterName}).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+	dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+	s := NewServer(log.Named("test"), dm, "all")
+	w := httptest.NewRecorder()
+	rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s"}`, validNonce))
+	r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+	r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+	accept := v2CharesResponseMediaType
+	s.RatsdChares(w, r, RatsdCharesParams{Accept: &accept})
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// the attester is sent no nonce, and left out of the nonce adjustment map
+	claims, _, _ := decodeCharesV2(t, w.Body.Bytes())
+	assert.Equal(t, map[string]uint{"mock-tsm": 64}, claims.GetNonceAdjustMap())
+
+	result, err := verify.V2(w.Body.Bytes(), realNonce,
+		verify.Config{AllowUnsigned: true, NonceExtractors: verify.FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustNonceForTest(t, realNonce, 64)}, result.Nonces)
+	assert.Equal(t, []string{attesterName}, result.Unbound)
+}
+
+func TestRatsdChares_invalid_records(t *testing.T) {
+	realNonce, _ := base64.RawURLEncoding.DecodeString(validNonce)
+	attesterName := "records-attester"
+	ct := "application/vnd.veraison.test-report"
+
+	tests := []struct {
+		name    string
+		records []*compositor.Record
+		detail  string
+	}{
+		{
+			"empty key",
+			[]*compositor.Record{{ContentType: ct, Value: []byte("report")}},
+			"invalid records from records-attester: record with empty key",
+		},
+		{
+			"duplicate key",
+			[]*compositor.Record{
+				{Key: "report", ContentType: ct, Value: []byte("report")},
+				{Key: "report", ContentType: ct, Value: []byte("report")},
+			},
+			`invalid records from records-attester: duplicate record key "report"`,
+		},
+		{
+			"missing value",
+			[]*compositor.Record{{Key: "report", ContentType: ct}},
+			`invalid records from records-attester: record "report": missing value`,
+		},
+		{
+			"invalid indicator",
+			[]*compositor.Record{{Key: "report", ContentType: ct, Value: []byte("report"), Indicator: 32}},
+			`invalid records from records-attester: record "report": invalid CMW indicator 32`,
+		},
+		{
+			"value and nested records",
+			[]*compositor.Record{{
+				Key: "report", ContentType: ct, Value: []byte("report"),
+				Records: []*compositor.Record{{Key: "x", ContentType: ct, Value: []byte("x")}},
+			}},
+			`invalid records from records-attester: record "report": record has both a value and nested records`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attester := &testAttester{
+				t:                   t,
+				formats:             []*compositor.Format{{ContentType: ct, NonceSize: 32}},
+				expectedContentType: ct,
+				expectedNonce:       adjustNonceForTest(t, realNonce, 32),
+				records:             tt.records,
+			}
+
+			dm := mock_deps.NewMockIManager(ctrl)
+			dm.EXPECT().GetPluginList().Return([]string{attesterName}).AnyTimes()
+			dm.EXPECT().LookupByName(attesterName).Return(attester, nil).AnyTimes()
+
+			s := NewServer(log.Named("test"), dm, "all")
+			w := httptest.NewRecorder()
+			rb := strings.NewReader(fmt.Sprintf(`{"nonce": "%s"}`, validNonce))
+			r, _ := http.NewRequest(http.MethodPost, "/ratsd/chares", rb)
+			r.Header.Add("Content-Type", ApplicationvndVeraisonCharesJson)
+			s.RatsdChares(w, r, RatsdCharesParams{})
+
+			var body problems.DefaultProblem
+			_ = json.Unmarshal(w.Body.Bytes(), &body)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Equal(t, tt.detail, body.Detail)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dice implements a sub-attester returning the certificate chain of a
+// DICE layered device, optionally with a signature over the nonce made with
+// the key of the last layer.
+package dice
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const nonceSize = 32
+
+// Environment variables holding the configuration of the DICE attester
+const (
+	// EnvChain is the path of the certificate chain, e.g., a file or a
+	// sysfs attribute
+	EnvChain = "RATSD_DICE_CHAIN"
+	// EnvKey is the path of the PEM encoded PKCS #8 key of the leaf
+	// certificate. The nonce is not signed if it is unset.
+	EnvKey = "RATSD_DICE_KEY"
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "dice",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.DICEChainMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.DICEChainMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	errNoChain = errors.New("no chain configured")
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type DICEPlugin struct {
+	chain  string
+	signer crypto.Signer
+}
+
+// NewPlugin returns a DICE attester reading the certificate chain at path.
+// If signer is not nil, it signs the nonce with the key of the leaf
+// certificate, e.g., through a secure element or a TPM.
+func NewPlugin(path string, signer crypto.Signer) *DICEPlugin {
+	return &DICEPlugin{chain: path, signer: signer}
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *DICEPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (p *DICEPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *DICEPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if _, err := readChain(p.chain); err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("DICE chain is not available: %s", err.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (p *DICEPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the DICE attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in dice plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	chain, err := readChain(p.chain)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to read DICE chain: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	out := &tokens.DICEChain{Nonce: in.Nonce}
+	for _, c := range chain {
+		out.Certificates = append(out.Certificates, c.Raw)
+	}
+
+	if p.signer != nil {
+		alg, sig, err := signNonce(p.signer, chain[0], in.Nonce)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to sign nonce: %v", err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+		out.SignatureAlg = alg.String()
+		out.Signature = sig
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.DICEChainMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode DICE chain as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// readChain reads the PEM or concatenated DER certificates at path, and
+// returns them ordered from the leaf to the root
+func readChain(path string) ([]*x509.Certificate, error) {
+	if path == "" {
+		return nil, errNoChain
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN")) {
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, c)
+		}
+	} else if certs, err = x509.ParseCertificates(data); err != nil {
+		return nil, err
+	}
+
+	return orderChain(certs)
+}
+
+// orderChain orders certs from the leaf to the root, whatever their order in
+// the source. The certificates must form a single chain.
+func orderChain(certs []*x509.Certificate) ([]*x509.Certificate, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate")
+	}
+
+	issues := func(issuer, c *x509.Certificate) bool {
+		return issuer != c && c.CheckSignatureFrom(issuer) == nil
+	}
+
+	// the leaf issues no other certificate of the chain
+	var leaves []*x509.Certificate
+	for _, c := range certs {
+		if !slices.ContainsFunc(certs, func(o *x509.Certificate) bool { return issues(c, o) }) {
+			leaves = append(leaves, c)
+		}
+	}
+
+	if len(leaves) != 1 {
+		return nil, fmt.Errorf("%d certificates do not form a single chain", len(certs))
+	}
+
+	chain := leaves
+	for len(chain) < len(certs) {
+		last := chain[len(chain)-1]
+		i := slices.IndexFunc(certs, func(o *x509.Certificate) bool { return issues(o, last) })
+		if i < 0 || slices.Contains(chain, certs[i]) {
+			return nil, fmt.Errorf("%d certificates do not form a single chain", len(certs))
+		}
+		chain = append(chain, certs[i])
+	}
+
+	return chain, nil
+}
+
+// signNonce signs nonce with signer, whose key must be that of leaf
+func signNonce(signer crypto.Signer, leaf *x509.Certificate, nonce []byte) (x509.SignatureAlgorithm, []byte, error) {
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return 0, nil, errors.New("the signer key is not the key of the leaf certificate")
+	}
+
+	var alg x509.SignatureAlgorithm
+	var opts crypto.SignerOpts
+
+	switch k := signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			alg, opts = x509.ECDSAWithSHA256, crypto.SHA256
+		case elliptic.P384():
+			alg, opts = x509.ECDSAWithSHA384, crypto.SHA384
+		case elliptic.P521():
+			alg, opts = x509.ECDSAWithSHA512, crypto.SHA512
+		default:
+			return 0, nil, fmt.Errorf("curve %s is not supported", k.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		alg, opts = x509.PureEd25519, crypto.Hash(0)
+	case *rsa.PublicKey:
+		alg = x509.SHA256WithRSAPSS
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	default:
+		return 0, nil, fmt.Errorf("key type %T is not supported", k)
+	}
+
+	digest := nonce
+	if h := opts.HashFunc(); h != 0 {
+		d := h.New()
+		d.Write(nonce)
+		digest = d.Sum(nil)
+	}
+
+	sig, err := signer.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return alg, sig, nil
+}
+
+// GetPlugin returns the DICE attester configured from the environment
+func GetPlugin() (*DICEPlugin, error) {
+	path := os.Getenv(EnvKey)
+	if path == "" {
+		return NewPlugin(os.Getenv(EnvChain), nil), nil
+	}
+
+	signer, err := loadKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EnvKey, err)
+	}
+
+	return NewPlugin(os.Getenv(EnvChain), signer), nil
+}
+
+func loadKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a signing key", path)
+	}
+
+	return signer, nil
+}
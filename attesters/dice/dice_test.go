@@ -0,0 +1,250 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package dice
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var (
+	testChain = filepath.Join("testdata", "chain.pem")
+	testKey   = filepath.Join("testdata", "alias.key")
+)
+
+func testNonce() []byte {
+	return bytes.Repeat([]byte{0x5a}, nonceSize)
+}
+
+func testRoots(t *testing.T) []*x509.Certificate {
+	chain, err := readChain(testChain)
+	require.NoError(t, err)
+	return chain[len(chain)-1:]
+}
+
+func testSigner(t *testing.T) crypto.Signer {
+	signer, err := loadKey(testKey)
+	require.NoError(t, err)
+	return signer
+}
+
+func getEvidence(t *testing.T, p *DICEPlugin, contentType string) *tokens.DICEChain {
+	out := p.GetEvidence(&compositor.EvidenceIn{ContentType: contentType, Nonce: testNonce()})
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	c := &tokens.DICEChain{}
+	if contentType == tokens.DICEChainMediaTypeCBOR {
+		require.NoError(t, c.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, c.FromJSON(out.Evidence))
+	}
+
+	return c
+}
+
+// writeChain writes certs to a file as concatenated DER, and returns its path
+func writeChain(t *testing.T, certs ...*x509.Certificate) string {
+	var data []byte
+	for _, c := range certs {
+		data = append(data, c.Raw...)
+	}
+
+	path := filepath.Join(t.TempDir(), "chain.der")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+// newSelfSigned returns a self-signed certificate of key
+func newSelfSigned(t *testing.T, key crypto.Signer) *x509.Certificate {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "alias"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+
+	c, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return c
+}
+
+func Test_GetPlugin(t *testing.T) {
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin("", nil), p)
+
+	t.Setenv(EnvChain, testChain)
+	t.Setenv(EnvKey, testKey)
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(testChain, testSigner(t)), p)
+
+	t.Setenv(EnvKey, testChain)
+	_, err = GetPlugin()
+	assert.ErrorContains(t, err, "RATSD_DICE_KEY: ")
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	out := NewPlugin(testChain, nil).GetSubAttesterID()
+	require.True(t, out.Status.Result)
+	assert.Equal(t, "dice", out.SubAttesterID.Name)
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	out := NewPlugin(testChain, nil).GetSupportedFormats()
+	require.True(t, out.Status.Result)
+	assert.Equal(t, supportedFormats, out.Formats)
+}
+
+func Test_GetSupportedFormats_Unavailable(t *testing.T) {
+	out := NewPlugin("", nil).GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, "DICE chain is not available: no chain configured", out.Status.Error)
+}
+
+func Test_GetEvidence_Signed(t *testing.T) {
+	p := NewPlugin(testChain, testSigner(t))
+
+	for _, f := range supportedFormats {
+		c := getEvidence(t, p, f.ContentType)
+		assert.Equal(t, tokens.BinaryString(testNonce()), c.Nonce)
+		assert.Len(t, c.Certificates, 3)
+		assert.Equal(t, x509.ECDSAWithSHA256.String(), c.SignatureAlg)
+		require.NoError(t, c.Verify(testRoots(t), time.Time{}))
+	}
+}
+
+func Test_GetEvidence_Unsigned(t *testing.T) {
+	c := getEvidence(t, NewPlugin(testChain, nil), tokens.DICEChainMediaTypeJSON)
+	assert.Empty(t, c.Signature)
+	assert.ErrorIs(t, c.Verify(testRoots(t), time.Time{}), tokens.ErrDICEUnsigned)
+}
+
+func Test_GetEvidence_DER(t *testing.T) {
+	chain, err := readChain(testChain)
+	require.NoError(t, err)
+
+	// the order of the certificates in the source does not matter
+	p := NewPlugin(writeChain(t, chain[1], chain[2], chain[0]), testSigner(t))
+	c := getEvidence(t, p, tokens.DICEChainMediaTypeCBOR)
+	for i := range chain {
+		assert.Equal(t, tokens.BinaryString(chain[i].Raw), c.Certificates[i])
+	}
+	require.NoError(t, c.Verify(testRoots(t), time.Time{}))
+}
+
+func Test_GetEvidence_KeyTypes(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		key crypto.Signer
+		alg x509.SignatureAlgorithm
+	}{
+		{ecKey, x509.ECDSAWithSHA384},
+		{edKey, x509.PureEd25519},
+		{rsaKey, x509.SHA256WithRSAPSS},
+	}
+
+	for _, tv := range tvs {
+		cert := newSelfSigned(t, tv.key)
+		c := getEvidence(t, NewPlugin(writeChain(t, cert), tv.key), tokens.DICEChainMediaTypeJSON)
+		assert.Equal(t, tv.alg.String(), c.SignatureAlg)
+		require.NoError(t, c.Verify([]*x509.Certificate{cert}, time.Time{}))
+	}
+}
+
+func Test_GetEvidence_Fail(t *testing.T) {
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		plugin      *DICEPlugin
+		in          *compositor.EvidenceIn
+		statusCode  uint32
+		expectedErr string
+	}{
+		{
+			NewPlugin(testChain, nil),
+			&compositor.EvidenceIn{ContentType: tokens.DICEChainMediaTypeJSON, Nonce: []byte("nonce")},
+			http.StatusBadRequest,
+			"nonce size of the DICE attester should be 32, got 5",
+		},
+		{
+			NewPlugin(testChain, nil),
+			&compositor.EvidenceIn{ContentType: "application/eat+cwt", Nonce: testNonce()},
+			http.StatusBadRequest,
+			"no supported format in dice plugin matches the requested format",
+		},
+		{
+			NewPlugin("", nil),
+			&compositor.EvidenceIn{ContentType: tokens.DICEChainMediaTypeJSON, Nonce: testNonce()},
+			http.StatusInternalServerError,
+			"failed to read DICE chain: no chain configured",
+		},
+		{
+			NewPlugin(testChain, otherKey),
+			&compositor.EvidenceIn{ContentType: tokens.DICEChainMediaTypeJSON, Nonce: testNonce()},
+			http.StatusInternalServerError,
+			"failed to sign nonce: the signer key is not the key of the leaf certificate",
+		},
+	}
+
+	for _, tv := range tvs {
+		out := tv.plugin.GetEvidence(tv.in)
+		assert.False(t, out.Status.Result)
+		assert.Equal(t, tv.statusCode, out.StatusCode)
+		assert.Equal(t, tv.expectedErr, out.Status.Error)
+	}
+}
+
+func Test_readChain_Fail(t *testing.T) {
+	chain, err := readChain(testChain)
+	require.NoError(t, err)
+
+	// two unrelated certificates
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = readChain(writeChain(t, chain[0], newSelfSigned(t, key)))
+	assert.EqualError(t, err, "2 certificates do not form a single chain")
+
+	// a PEM file without certificates
+	_, err = readChain(testKey)
+	assert.EqualError(t, err, "no certificate")
+
+	path := filepath.Join(t.TempDir(), "chain.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")}
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	_, err = readChain(path)
+	assert.Error(t, err)
+}
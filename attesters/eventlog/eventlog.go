@@ -0,0 +1,241 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package eventlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	nonceSize = 32
+
+	defaultTCG2 = "/sys/kernel/security/tpm0/binary_bios_measurements"
+	defaultCCEL = "/sys/firmware/acpi/tables/data/CCEL"
+)
+
+// Environment variables holding the paths of the event logs
+const (
+	EnvTCG2 = "RATSD_EVENTLOG_TCG2"
+	EnvCCEL = "RATSD_EVENTLOG_CCEL"
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "eventlog",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.EventLogMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.EventLogMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	// sources are the event log sources, in order of preference
+	sources = []string{tokens.EventLogSourceTCG2, tokens.EventLogSourceCCEL}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Config holds the paths of the event logs
+type Config struct {
+	// TCG2 is the path of the TCG2 event log of the TPM
+	TCG2 string
+	// CCEL is the path of the event log of the CC Event Log ACPI table
+	CCEL string
+}
+
+// DefaultConfig returns the paths of the event logs on Linux
+func DefaultConfig() Config {
+	return Config{
+		TCG2: defaultTCG2,
+		CCEL: defaultCCEL,
+	}
+}
+
+// ConfigFromEnv returns the default configuration updated with the
+// environment variables that are set
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+
+	if v, ok := os.LookupEnv(EnvTCG2); ok {
+		cfg.TCG2 = v
+	}
+
+	if v, ok := os.LookupEnv(EnvCCEL); ok {
+		cfg.CCEL = v
+	}
+
+	return cfg
+}
+
+type EventLogPlugin struct {
+	cfg Config
+}
+
+// NewPlugin returns an event log attester using the given configuration
+func NewPlugin(cfg Config) *EventLogPlugin {
+	return &EventLogPlugin{cfg: cfg}
+}
+
+func (p *EventLogPlugin) path(source string) string {
+	if source == tokens.EventLogSourceCCEL {
+		return p.cfg.CCEL
+	}
+
+	return p.cfg.TCG2
+}
+
+// defaultSource returns the first available event log source
+func (p *EventLogPlugin) defaultSource() (string, error) {
+	for _, source := range sources {
+		if _, err := os.Stat(p.path(source)); err == nil {
+			return source, nil
+		}
+	}
+
+	return "", errors.New("no TCG2 or CCEL event log found")
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *EventLogPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "source", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+}
+
+func (p *EventLogPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *EventLogPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if _, err := p.defaultSource(); err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("event log is not available: %s", err.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (p *EventLogPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the event log attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in eventlog plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	options := make(map[string]string)
+	if len(in.Options) > 0 {
+		if err := json.Unmarshal(in.Options, &options); err != nil {
+			errMsg := fmt.Errorf(
+				"failed to parse %s: %v", in.Options, err)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+	}
+
+	source, ok := options["source"]
+	if ok && !slices.Contains(sources, source) {
+		errMsg := fmt.Errorf("source %s is not supported", source)
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !ok {
+		var err error
+		if source, err = p.defaultSource(); err != nil {
+			return getEvidenceError(err, http.StatusInternalServerError)
+		}
+	}
+
+	data, err := os.ReadFile(p.path(source))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			errMsg := fmt.Errorf("no %s event log found", source)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+		errMsg := fmt.Errorf("failed to read %s event log: %v", source, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	events, length, err := tokens.ParseEventLog(data)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to parse %s event log: %v", source, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	out := &tokens.EventLog{
+		Nonce:  in.Nonce,
+		Source: source,
+		Raw:    data[:length],
+		Events: events,
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.EventLogMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode event log as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// GetPlugin returns the event log attester configured from the environment
+func GetPlugin() *EventLogPlugin {
+	return NewPlugin(ConfigFromEnv())
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package eventlog
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+
+	// size of the CCEL fixture up to its last event, before the padding
+	ccelLength = 461
+)
+
+var testConfig = Config{
+	TCG2: filepath.Join("testdata", "binary_bios_measurements"),
+	CCEL: filepath.Join("testdata", "CCEL"),
+}
+
+func getEventLog(t *testing.T, p *EventLogPlugin, contentType, options string) *tokens.EventLog {
+	in := &compositor.EvidenceIn{
+		ContentType: contentType,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(options),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	l := &tokens.EventLog{}
+	if contentType == tokens.EventLogMediaTypeCBOR {
+		require.NoError(t, l.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, l.FromJSON(out.Evidence))
+	}
+	assert.Equal(t, tokens.BinaryString(validNonceStr), l.Nonce)
+
+	return l
+}
+
+func getEvidenceFailure(t *testing.T, p *EventLogPlugin, options string) *compositor.EvidenceOut {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.EventLogMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(options),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+
+	return out
+}
+
+func Test_ConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvTCG2, testConfig.TCG2)
+	t.Setenv(EnvCCEL, testConfig.CCEL)
+
+	assert.Equal(t, testConfig, ConfigFromEnv())
+	assert.Equal(t, NewPlugin(testConfig), GetPlugin())
+}
+
+func Test_ConfigFromEnv_Default(t *testing.T) {
+	assert.Equal(t, DefaultConfig(), ConfigFromEnv())
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "source", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(testConfig).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(testConfig).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, NewPlugin(testConfig).GetSupportedFormats())
+
+	// CCEL only
+	cfg := testConfig
+	cfg.TCG2 = filepath.Join(t.TempDir(), "binary_bios_measurements")
+	assert.Equal(t, expected, NewPlugin(cfg).GetSupportedFormats())
+}
+
+func Test_GetSupportedFormats_no_event_log(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPlugin(Config{TCG2: filepath.Join(dir, "tcg2"), CCEL: filepath.Join(dir, "CCEL")})
+
+	out := p.GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, "event log is not available: no TCG2 or CCEL event log found", out.Status.Error)
+	assert.Empty(t, out.Formats)
+}
+
+func Test_GetEvidence_TCG2(t *testing.T) {
+	l := getEventLog(t, NewPlugin(testConfig), tokens.EventLogMediaTypeJSON, "")
+	assert.Equal(t, tokens.EventLogSourceTCG2, l.Source)
+
+	raw, err := os.ReadFile(testConfig.TCG2)
+	require.NoError(t, err)
+	assert.Equal(t, tokens.BinaryString(raw), l.Raw)
+
+	// Spec ID event, then 13 events with SHA-1 and SHA-256 digests
+	require.Len(t, l.Events, 14)
+	assert.Equal(t, uint32(tokens.EventTypeNoAction), l.Events[0].EventType)
+	for _, e := range l.Events[1:] {
+		require.Len(t, e.Digests, 2)
+		assert.Equal(t, uint16(tpm2.TPMAlgSHA1), e.Digests[0].AlgID)
+		assert.Equal(t, uint16(tpm2.TPMAlgSHA256), e.Digests[1].AlgID)
+	}
+
+	pcrs, err := l.Replay(tpm2.TPMAlgSHA256)
+	require.NoError(t, err)
+	assert.Len(t, pcrs, 9)
+}
+
+func Test_GetEvidence_CCEL(t *testing.T) {
+	l := getEventLog(t, NewPlugin(testConfig), tokens.EventLogMediaTypeCBOR, `{"source": "ccel"}`)
+	assert.Equal(t, tokens.EventLogSourceCCEL, l.Source)
+
+	// the padding of the log area is trimmed
+	assert.Len(t, l.Raw, ccelLength)
+	require.Len(t, l.Events, 6)
+
+	rtmrs, err := l.Replay(tpm2.TPMAlgSHA384)
+	require.NoError(t, err)
+	assert.Len(t, rtmrs, 3)
+	for _, rtmr := range []uint32{1, 2, 3} {
+		assert.Len(t, rtmrs[rtmr], 48)
+	}
+}
+
+func Test_GetEvidence_CCEL_default(t *testing.T) {
+	cfg := testConfig
+	cfg.TCG2 = filepath.Join(t.TempDir(), "binary_bios_measurements")
+
+	l := getEventLog(t, NewPlugin(cfg), tokens.EventLogMediaTypeJSON, "")
+	assert.Equal(t, tokens.EventLogSourceCCEL, l.Source)
+}
+
+func Test_GetEvidence_missing_source(t *testing.T) {
+	cfg := testConfig
+	cfg.CCEL = filepath.Join(t.TempDir(), "CCEL")
+
+	out := getEvidenceFailure(t, NewPlugin(cfg), `{"source": "ccel"}`)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no ccel event log found", out.Status.Error)
+
+	dir := t.TempDir()
+	p := NewPlugin(Config{TCG2: filepath.Join(dir, "tcg2"), CCEL: filepath.Join(dir, "CCEL")})
+
+	out = getEvidenceFailure(t, p, "")
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "no TCG2 or CCEL event log found", out.Status.Error)
+}
+
+func Test_GetEvidence_corrupted(t *testing.T) {
+	raw, err := os.ReadFile(testConfig.TCG2)
+	require.NoError(t, err)
+
+	cfg := testConfig
+	cfg.TCG2 = filepath.Join(t.TempDir(), "binary_bios_measurements")
+	require.NoError(t, os.WriteFile(cfg.TCG2, raw[:len(raw)-1], 0o600))
+
+	out := getEvidenceFailure(t, NewPlugin(cfg), "")
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to parse tcg2 event log: event 13: event log truncated", out.Status.Error)
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.EventLogMediaTypeJSON,
+		Nonce:       []byte("abcdefghijklmnop"),
+	}
+
+	out := NewPlugin(testConfig).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the event log attester should be 32, got 16", out.Status.Error)
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: "invalid-format",
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := NewPlugin(testConfig).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in eventlog plugin matches the requested format", out.Status.Error)
+}
+
+func Test_GetEvidence_Invalid_Options(t *testing.T) {
+	tvs := []struct {
+		options  string
+		expected string
+	}{
+		{`{"source": "uefi"}`, "source uefi is not supported"},
+		{`{"source"`, `failed to parse {"source": unexpected end of JSON input`},
+	}
+
+	for _, tv := range tvs {
+		out := getEvidenceFailure(t, NewPlugin(testConfig), tv.options)
+		assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode, tv.options)
+		assert.Equal(t, tv.expected, out.Status.Error, tv.options)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faulty implements a sub-attester that injects faults, to test how
+// ratsd copes with misbehaving plugins. The faults are selected per request
+// with options, or for the lifetime of the plugin with environment variables,
+// since ratsd passes no configuration to its plugins.
+package faulty
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+const (
+	// MediaType is the media type of the evidence of the faulty attester,
+	// a JSON object holding the nonce
+	MediaType = "application/vnd.veraison.faulty+json"
+
+	defaultNonceSize  = 32
+	defaultStatusCode = http.StatusTeapot
+	exitCode          = 3
+)
+
+// Fault is a misbehaviour of the faulty attester in GetEvidence
+type Fault string
+
+const (
+	// FaultNone returns well-formed evidence
+	FaultNone Fault = "none"
+	// FaultPanic panics, which terminates the plugin in the middle of the
+	// RPC
+	FaultPanic Fault = "panic"
+	// FaultExit exits the plugin in the middle of the RPC
+	FaultExit Fault = "exit"
+	// FaultMalformed returns evidence that is not valid JSON
+	FaultMalformed Fault = "malformed"
+	// FaultNonceSize returns evidence bound to a truncated nonce
+	FaultNonceSize Fault = "nonce-size"
+	// FaultStatus fails with the configured status code
+	FaultStatus Fault = "status"
+	// FaultNilStatus returns a response without status. Set as the default
+	// fault, it also affects GetSupportedFormats.
+	FaultNilStatus Fault = "nil-status"
+)
+
+var faults = map[Fault]bool{
+	FaultNone:      true,
+	FaultPanic:     true,
+	FaultExit:      true,
+	FaultMalformed: true,
+	FaultNonceSize: true,
+	FaultStatus:    true,
+	FaultNilStatus: true,
+}
+
+// Environment variables holding the configuration of the faulty attester
+const (
+	EnvFault      = "RATSD_FAULTY_FAULT"
+	EnvLatency    = "RATSD_FAULTY_LATENCY"
+	EnvStatusCode = "RATSD_FAULTY_STATUS_CODE"
+	EnvNonceSize  = "RATSD_FAULTY_NONCE_SIZE"
+	EnvNoFormats  = "RATSD_FAULTY_NO_FORMATS"
+)
+
+// Config is the configuration of the faulty attester. Fault, Latency and
+// StatusCode are the defaults for the options of the same name.
+type Config struct {
+	Fault      Fault
+	Latency    time.Duration
+	StatusCode uint32
+	// NonceSize is the nonce size advertised in the supported format
+	NonceSize uint32
+	// NoFormats makes GetSupportedFormats return an empty list
+	NoFormats bool
+}
+
+// DefaultConfig returns a configuration injecting no fault
+func DefaultConfig() Config {
+	return Config{
+		Fault:      FaultNone,
+		StatusCode: defaultStatusCode,
+		NonceSize:  defaultNonceSize,
+	}
+}
+
+// ConfigFromEnv returns the default configuration updated with the
+// environment variables that are set
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	if v, ok := os.LookupEnv(EnvFault); ok {
+		fault, err := parseFault(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %w", EnvFault, err)
+		}
+		cfg.Fault = fault
+	}
+
+	if v, ok := os.LookupEnv(EnvLatency); ok {
+		latency, err := parseLatency(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %w", EnvLatency, err)
+		}
+		cfg.Latency = latency
+	}
+
+	if v, ok := os.LookupEnv(EnvStatusCode); ok {
+		code, err := parseStatusCode(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %w", EnvStatusCode, err)
+		}
+		cfg.StatusCode = code
+	}
+
+	if v, ok := os.LookupEnv(EnvNonceSize); ok {
+		size, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: nonce size %s is invalid", EnvNonceSize, v)
+		}
+		cfg.NonceSize = uint32(size)
+	}
+
+	if v, ok := os.LookupEnv(EnvNoFormats); ok {
+		noFormats, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %s is not a boolean", EnvNoFormats, v)
+		}
+		cfg.NoFormats = noFormats
+	}
+
+	return cfg, nil
+}
+
+func parseFault(v string) (Fault, error) {
+	if !faults[Fault(v)] {
+		return "", fmt.Errorf("unknown fault %s", v)
+	}
+	return Fault(v), nil
+}
+
+func parseLatency(v string) (time.Duration, error) {
+	latency, err := time.ParseDuration(v)
+	if err != nil || latency < 0 {
+		return 0, fmt.Errorf("latency %s is invalid", v)
+	}
+	return latency, nil
+}
+
+func parseStatusCode(v string) (uint32, error) {
+	code, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("status_code %s is invalid", v)
+	}
+	return uint32(code), nil
+}
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "faulty",
+		Version: "1.0.0",
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type Plugin struct {
+	cfg Config
+	// exit terminates the plugin, for testing
+	exit func(code int)
+}
+
+// Evidence is the evidence of the faulty attester
+type Evidence struct {
+	Nonce []byte `json:"nonce"`
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *Plugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "fault", Type: "string"},
+			&compositor.Option{Name: "latency", Type: "string"},
+			&compositor.Option{Name: "status_code", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	formats := []*compositor.Format{}
+	if !p.cfg.NoFormats {
+		formats = append(formats, &compositor.Format{
+			ContentType: MediaType,
+			NonceSize:   p.cfg.NonceSize,
+		})
+	}
+
+	status := statusSucceeded
+	if p.cfg.Fault == FaultNilStatus {
+		status = nil
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  status,
+		Formats: formats,
+	}
+}
+
+func (p *Plugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if in.ContentType != MediaType {
+		errMsg := fmt.Errorf(
+			"no supported format in faulty plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	options := make(map[string]string)
+	if len(in.Options) > 0 {
+		if err := json.Unmarshal(in.Options, &options); err != nil {
+			errMsg := fmt.Errorf(
+				"failed to parse %s: %v", in.Options, err)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+	}
+
+	fault, latency, statusCode := p.cfg.Fault, p.cfg.Latency, p.cfg.StatusCode
+	var err error
+
+	if v, ok := options["fault"]; ok {
+		if fault, err = parseFault(v); err != nil {
+			return getEvidenceError(err, http.StatusBadRequest)
+		}
+	}
+
+	if v, ok := options["latency"]; ok {
+		if latency, err = parseLatency(v); err != nil {
+			return getEvidenceError(err, http.StatusBadRequest)
+		}
+	}
+
+	if v, ok := options["status_code"]; ok {
+		if statusCode, err = parseStatusCode(v); err != nil {
+			return getEvidenceError(err, http.StatusBadRequest)
+		}
+	}
+
+	time.Sleep(latency)
+
+	nonce := in.Nonce
+
+	switch fault {
+	case FaultPanic:
+		panic("faulty plugin: injected panic")
+	case FaultExit:
+		p.exit(exitCode)
+	case FaultMalformed:
+		return &compositor.EvidenceOut{
+			Status:     statusSucceeded,
+			Evidence:   []byte(`{"nonce":`),
+			StatusCode: http.StatusOK,
+		}
+	case FaultNonceSize:
+		nonce = nonce[:len(nonce)/2]
+	case FaultStatus:
+		errMsg := fmt.Errorf("injected failure with status code %d", statusCode)
+		return getEvidenceError(errMsg, statusCode)
+	case FaultNilStatus:
+		return &compositor.EvidenceOut{StatusCode: http.StatusOK}
+	}
+
+	evidence, err := json.Marshal(Evidence{Nonce: nonce})
+	if err != nil {
+		errMsg := fmt.Errorf("failed to JSON encode faulty evidence: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   evidence,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// NewPlugin returns a faulty attester with the given configuration
+func NewPlugin(cfg Config) *Plugin {
+	return &Plugin{
+		cfg:  cfg,
+		exit: os.Exit,
+	}
+}
+
+// GetPlugin returns a faulty attester configured from the environment
+func GetPlugin() (*Plugin, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlugin(cfg), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package faulty
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+const (
+	validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+)
+
+func evidenceIn(options string) *compositor.EvidenceIn {
+	return &compositor.EvidenceIn{
+		ContentType: MediaType,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(options),
+	}
+}
+
+func Test_ConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvFault, "status")
+	t.Setenv(EnvLatency, "10ms")
+	t.Setenv(EnvStatusCode, "503")
+	t.Setenv(EnvNonceSize, "7")
+	t.Setenv(EnvNoFormats, "true")
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Fault:      FaultStatus,
+		Latency:    10 * time.Millisecond,
+		StatusCode: 503,
+		NonceSize:  7,
+		NoFormats:  true,
+	}, cfg)
+}
+
+func Test_ConfigFromEnv_Fail(t *testing.T) {
+	tests := []struct{ env, value, msg string }{
+		{EnvFault, "other", "RATSD_FAULTY_FAULT: unknown fault other"},
+		{EnvLatency, "-1s", "RATSD_FAULTY_LATENCY: latency -1s is invalid"},
+		{EnvStatusCode, "x", "RATSD_FAULTY_STATUS_CODE: status_code x is invalid"},
+		{EnvNonceSize, "-1", "RATSD_FAULTY_NONCE_SIZE: nonce size -1 is invalid"},
+		{EnvNoFormats, "maybe", "RATSD_FAULTY_NO_FORMATS: maybe is not a boolean"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+
+			_, err := ConfigFromEnv()
+			assert.EqualError(t, err, tt.msg)
+		})
+	}
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	p := NewPlugin(DefaultConfig())
+	assert.Equal(t, []*compositor.Format{
+		&compositor.Format{ContentType: MediaType, NonceSize: defaultNonceSize},
+	}, p.GetSupportedFormats().Formats)
+
+	cfg := DefaultConfig()
+	cfg.NoFormats = true
+	out := NewPlugin(cfg).GetSupportedFormats()
+	assert.True(t, out.Status.Result)
+	assert.Empty(t, out.Formats)
+	cfg = DefaultConfig()
+	cfg.Fault = FaultNilStatus
+	assert.Nil(t, NewPlugin(cfg).GetSupportedFormats().Status)
+}
+
+func Test_GetEvidence(t *testing.T) {
+	out := NewPlugin(DefaultConfig()).GetEvidence(evidenceIn(""))
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	var e Evidence
+	require.NoError(t, json.Unmarshal(out.Evidence, &e))
+	assert.Equal(t, []byte(validNonceStr), e.Nonce)
+}
+
+func Test_GetEvidence_Faults(t *testing.T) {
+	p := NewPlugin(DefaultConfig())
+
+	out := p.GetEvidence(evidenceIn(`{"fault": "malformed"}`))
+	assert.True(t, out.Status.Result)
+	assert.False(t, json.Valid(out.Evidence))
+
+	out = p.GetEvidence(evidenceIn(`{"fault": "nonce-size"}`))
+	require.True(t, out.Status.Result)
+	var e Evidence
+	require.NoError(t, json.Unmarshal(out.Evidence, &e))
+	assert.Len(t, e.Nonce, len(validNonceStr)/2)
+
+	out = p.GetEvidence(evidenceIn(`{"fault": "status"}`))
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusTeapot), out.StatusCode)
+
+	out = p.GetEvidence(evidenceIn(`{"fault": "status", "status_code": "0"}`))
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(0), out.StatusCode)
+	assert.Equal(t, "injected failure with status code 0", out.Status.Error)
+
+	out = p.GetEvidence(evidenceIn(`{"fault": "nil-status"}`))
+	assert.Nil(t, out.Status)
+
+	assert.PanicsWithValue(t, "faulty plugin: injected panic", func() {
+		p.GetEvidence(evidenceIn(`{"fault": "panic"}`))
+	})
+
+	exited := -1
+	p.exit = func(code int) { exited = code }
+	p.GetEvidence(evidenceIn(`{"fault": "exit"}`))
+	assert.Equal(t, exitCode, exited)
+}
+
+func Test_GetEvidence_Latency(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Latency = 20 * time.Millisecond
+
+	start := time.Now()
+	out := NewPlugin(cfg).GetEvidence(evidenceIn(""))
+	assert.True(t, out.Status.Result)
+	assert.GreaterOrEqual(t, time.Since(start), cfg.Latency)
+
+	start = time.Now()
+	out = NewPlugin(cfg).GetEvidence(evidenceIn(`{"latency": "0s"}`))
+	assert.True(t, out.Status.Result)
+	assert.Less(t, time.Since(start), cfg.Latency)
+}
+
+func Test_GetEvidence_Invalid_Options(t *testing.T) {
+	tests := []struct{ name, params, msg string }{
+		{"unknown fault", `{"fault": "other"}`, "unknown fault other"},
+		{"invalid latency", `{"latency": "soon"}`, "latency soon is invalid"},
+		{"invalid status code", `{"status_code": "-1"}`, "status_code -1 is invalid"},
+		{"invalid json", `{"fault"}`,
+			`failed to parse {"fault"}: invalid character '}' after object key`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := &compositor.EvidenceOut{
+				Status: &compositor.Status{
+					Result: false,
+					Error:  tt.msg,
+				},
+				StatusCode: http.StatusBadRequest,
+			}
+
+			assert.Equal(t, expected, NewPlugin(DefaultConfig()).GetEvidence(evidenceIn(tt.params)))
+		})
+	}
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := evidenceIn("")
+	in.ContentType = "application/json"
+
+	out := NewPlugin(DefaultConfig()).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"github.com/veraison/ratsd/attesters/faulty"
+	"github.com/veraison/ratsd/plugin"
+)
+
+func main() {
+	plugin.ServeConfigured("faulty", faulty.GetPlugin)
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ima
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	nonceSize = 32
+
+	// maxPCR is the highest PCR of the PC Client platform TPM profile
+	maxPCR = 23
+
+	defaultDir     = "/sys/kernel/security/ima"
+	defaultHashAlg = "sha1"
+
+	// measurementsFile is the binary measurement list with the template
+	// hashes of the SHA-1 bank. The lists of the other banks have the name
+	// of the bank as suffix.
+	measurementsFile = "binary_runtime_measurements"
+)
+
+// EnvDir is the environment variable holding the directory of the IMA
+// measurement lists
+const EnvDir = "RATSD_IMA_DIR"
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "ima",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.IMALogMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.IMALogMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type IMAPlugin struct {
+	// dir is the directory of the IMA measurement lists
+	dir string
+}
+
+// NewPlugin returns an IMA attester reading the measurement lists of dir
+func NewPlugin(dir string) *IMAPlugin {
+	return &IMAPlugin{dir: dir}
+}
+
+// measurementsPath returns the path of the measurement list of the given bank
+func (p *IMAPlugin) measurementsPath(hashAlg string) string {
+	name := measurementsFile
+	if hashAlg != defaultHashAlg {
+		name += "_" + hashAlg
+	}
+
+	return filepath.Join(p.dir, name)
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *IMAPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "pcrs", Type: "string"},
+			&compositor.Option{Name: "offset", Type: "string"},
+			&compositor.Option{Name: "hash_alg", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+}
+
+func (p *IMAPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *IMAPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if _, err := os.Stat(p.measurementsPath(defaultHashAlg)); err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("IMA is not available: %s", err.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+// logRequest holds the options of an evidence request
+type logRequest struct {
+	pcrs    []uint32
+	offset  uint64
+	hashAlg string
+}
+
+func parseOptions(in []byte) (*logRequest, error) {
+	req := &logRequest{hashAlg: defaultHashAlg}
+
+	options := make(map[string]string)
+	if len(in) > 0 {
+		if err := json.Unmarshal(in, &options); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", in, err)
+		}
+	}
+
+	if v, ok := options["pcrs"]; ok {
+		for _, f := range strings.Split(v, ",") {
+			pcr, err := strconv.ParseUint(strings.TrimSpace(f), 10, 8)
+			if err != nil || pcr > maxPCR {
+				return nil, fmt.Errorf("pcrs %s is invalid", v)
+			}
+			if !slices.Contains(req.pcrs, uint32(pcr)) {
+				req.pcrs = append(req.pcrs, uint32(pcr))
+			}
+		}
+	}
+
+	if v, ok := options["offset"]; ok {
+		offset, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("offset %s is invalid", v)
+		}
+		req.offset = offset
+	}
+
+	if v, ok := options["hash_alg"]; ok {
+		if _, ok := tokens.TPMHashAlgs[v]; !ok {
+			return nil, fmt.Errorf("hash_alg %s is not supported", v)
+		}
+		req.hashAlg = v
+	}
+
+	return req, nil
+}
+
+func (p *IMAPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the IMA attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in ima plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	req, err := parseOptions(in.Options)
+	if err != nil {
+		return getEvidenceError(err, http.StatusBadRequest)
+	}
+
+	f, err := os.Open(p.measurementsPath(req.hashAlg))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && req.hashAlg != defaultHashAlg {
+			errMsg := fmt.Errorf("no IMA measurement list for the %s bank", req.hashAlg)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+		errMsg := fmt.Errorf("failed to read IMA measurement list: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+	defer f.Close() //nolint:errcheck
+
+	h, _ := tokens.TPMHashAlgs[req.hashAlg].Hash()
+
+	entries, count, err := readMeasurements(f, h.Size(), req.offset, req.pcrs)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to read IMA measurement list: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	if req.offset > count {
+		errMsg := fmt.Errorf("offset %d is beyond the end of the IMA measurement list (%d entries)",
+			req.offset, count)
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	out := &tokens.IMALog{
+		Nonce:      in.Nonce,
+		HashAlg:    req.hashAlg,
+		Offset:     req.offset,
+		NextOffset: count,
+		Entries:    entries,
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.IMALogMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode IMA log as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// GetPlugin returns the IMA attester reading the measurement lists of the
+// directory set in the environment, or of securityfs
+func GetPlugin() *IMAPlugin {
+	dir := defaultDir
+	if v, ok := os.LookupEnv(EnvDir); ok {
+		dir = v
+	}
+
+	return NewPlugin(dir)
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ima
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+
+	// number of entries in the fixtures
+	fixtureEntries = 7
+)
+
+var p = NewPlugin("testdata")
+
+func getLog(t *testing.T, contentType, options string) *tokens.IMALog {
+	in := &compositor.EvidenceIn{
+		ContentType: contentType,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(options),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	l := &tokens.IMALog{}
+	if contentType == tokens.IMALogMediaTypeCBOR {
+		require.NoError(t, l.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, l.FromJSON(out.Evidence))
+	}
+	assert.Equal(t, tokens.BinaryString(validNonceStr), l.Nonce)
+
+	return l
+}
+
+func indices(l *tokens.IMALog) []uint64 {
+	var idx []uint64
+	for _, e := range l.Entries {
+		idx = append(idx, e.Index)
+	}
+	return idx
+}
+
+func Test_GetPlugin(t *testing.T) {
+	t.Setenv(EnvDir, "testdata")
+	assert.Equal(t, p, GetPlugin())
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "pcrs", Type: "string"},
+			&compositor.Option{Name: "offset", Type: "string"},
+			&compositor.Option{Name: "hash_alg", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+
+	assert.Equal(t, expected, p.GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, p.GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, p.GetSupportedFormats())
+}
+
+func Test_GetSupportedFormats_no_IMA(t *testing.T) {
+	out := NewPlugin(t.TempDir()).GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Contains(t, out.Status.Error, "IMA is not available: ")
+	assert.Empty(t, out.Formats)
+}
+
+func Test_GetEvidence_JSON(t *testing.T) {
+	l := getLog(t, tokens.IMALogMediaTypeJSON, "")
+	assert.Equal(t, "sha1", l.HashAlg)
+	assert.Equal(t, uint64(0), l.Offset)
+	assert.Equal(t, uint64(fixtureEntries), l.NextOffset)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, indices(l))
+
+	boot := l.Entries[0]
+	assert.Equal(t, uint32(10), boot.PCR)
+	assert.Equal(t, "ima-ng", boot.TemplateName)
+	assert.Contains(t, string(boot.TemplateData), "boot_aggregate")
+	digest := sha1.Sum(boot.TemplateData)
+	assert.Equal(t, tokens.BinaryString(digest[:]), boot.TemplateHash)
+
+	violation := l.Entries[4]
+	assert.Equal(t, tokens.BinaryString(make([]byte, sha1.Size)), violation.TemplateHash)
+
+	legacy := l.Entries[5]
+	assert.Equal(t, "ima", legacy.TemplateName)
+	assert.True(t, bytes.HasSuffix(legacy.TemplateData, []byte("/usr/bin/ls")))
+}
+
+func Test_GetEvidence_CBOR_SHA256(t *testing.T) {
+	l := getLog(t, tokens.IMALogMediaTypeCBOR, `{"hash_alg": "sha256"}`)
+	assert.Equal(t, "sha256", l.HashAlg)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, indices(l))
+
+	sha1Log := getLog(t, tokens.IMALogMediaTypeJSON, "")
+	for i, e := range l.Entries {
+		assert.Len(t, e.TemplateHash, 32)
+		assert.Equal(t, sha1Log.Entries[i].TemplateData, e.TemplateData)
+	}
+}
+
+func Test_GetEvidence_PCR_filter(t *testing.T) {
+	l := getLog(t, tokens.IMALogMediaTypeJSON, `{"pcrs": "11"}`)
+	assert.Equal(t, []uint64{3}, indices(l))
+	assert.Equal(t, uint64(fixtureEntries), l.NextOffset)
+
+	l = getLog(t, tokens.IMALogMediaTypeJSON, `{"pcrs": "11, 10"}`)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, indices(l))
+
+	l = getLog(t, tokens.IMALogMediaTypeJSON, `{"pcrs": "12"}`)
+	assert.Empty(t, l.Entries)
+}
+
+func Test_GetEvidence_offset(t *testing.T) {
+	l := getLog(t, tokens.IMALogMediaTypeJSON, `{"offset": "5"}`)
+	assert.Equal(t, uint64(5), l.Offset)
+	assert.Equal(t, []uint64{5, 6}, indices(l))
+
+	l = getLog(t, tokens.IMALogMediaTypeJSON, `{"offset": "2", "pcrs": "10"}`)
+	assert.Equal(t, []uint64{2, 4, 5, 6}, indices(l))
+
+	// nothing new since the previous retrieval
+	l = getLog(t, tokens.IMALogMediaTypeJSON, `{"offset": "7"}`)
+	assert.Empty(t, l.Entries)
+	assert.Equal(t, uint64(fixtureEntries), l.NextOffset)
+}
+
+func Test_GetEvidence_Replay(t *testing.T) {
+	l := getLog(t, tokens.IMALogMediaTypeJSON, "")
+
+	pcrs, err := l.ReplayPCRs()
+	require.NoError(t, err)
+	assert.Len(t, pcrs, 2)
+
+	// PCR 11 is extended by a single entry
+	expected := sha1.Sum(append(make([]byte, sha1.Size), l.Entries[3].TemplateHash...))
+	assert.Equal(t, expected[:], pcrs[11])
+}
+
+func Test_GetEvidence_offset_beyond_end(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.IMALogMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"offset": "8"}`),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "offset 8 is beyond the end of the IMA measurement list (7 entries)", out.Status.Error)
+}
+
+func Test_GetEvidence_missing_bank(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.IMALogMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"hash_alg": "sha384"}`),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no IMA measurement list for the sha384 bank", out.Status.Error)
+}
+
+func Test_GetEvidence_missing_list(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.IMALogMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := NewPlugin(t.TempDir()).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Contains(t, out.Status.Error, "failed to read IMA measurement list: ")
+}
+
+func Test_GetEvidence_truncated_list(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", measurementsFile))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, measurementsFile), data[:len(data)-1], 0o600))
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.IMALogMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := NewPlugin(dir).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to read IMA measurement list: entry 6: template data: unexpected EOF", out.Status.Error)
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.IMALogMediaTypeJSON,
+		Nonce:       []byte("abcdefghijklmnop"),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the IMA attester should be 32, got 16", out.Status.Error)
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: "invalid-format",
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in ima plugin matches the requested format", out.Status.Error)
+}
+
+func Test_GetEvidence_Invalid_Options(t *testing.T) {
+	tvs := []struct {
+		options  string
+		expected string
+	}{
+		{`{"pcrs": "10,24"}`, "pcrs 10,24 is invalid"},
+		{`{"pcrs": ""}`, "pcrs  is invalid"},
+		{`{"offset": "-1"}`, "offset -1 is invalid"},
+		{`{"hash_alg": "md5"}`, "hash_alg md5 is not supported"},
+		{`{"offset"`, `failed to parse {"offset": unexpected end of JSON input`},
+	}
+
+	for _, tv := range tvs {
+		in := &compositor.EvidenceIn{
+			ContentType: tokens.IMALogMediaTypeJSON,
+			Nonce:       []byte(validNonceStr),
+			Options:     []byte(tv.options),
+		}
+
+		out := p.GetEvidence(in)
+		assert.False(t, out.Status.Result, tv.options)
+		assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode, tv.options)
+		assert.Equal(t, tv.expected, out.Status.Error, tv.options)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ima
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	// legacyTemplate is the name of the original IMA template, whose
+	// template data is not length-prefixed in the binary list
+	legacyTemplate = "ima"
+	// legacyDigestSize is the size of the SHA-1 file digest of the legacy
+	// template
+	legacyDigestSize = 20
+
+	// maxTemplateNameLen is TCG_EVENT_NAME_LEN_MAX
+	maxTemplateNameLen = 255
+	// maxTemplateDataLen bounds the template data of an entry, so that a
+	// corrupted list does not exhaust memory
+	maxTemplateDataLen = 1 << 20
+)
+
+// readMeasurements parses a binary IMA measurement list, as found in
+// binary_runtime_measurements, whose template hashes are hashSize bytes long.
+// The list is expected in little-endian byte order, i.e., from a
+// little-endian kernel or in the canonical format. The entries before offset,
+// and those not extending one of pcrs if pcrs is not empty, are skipped. It
+// returns the other entries, and the number of entries in the list.
+func readMeasurements(r io.Reader, hashSize int, offset uint64, pcrs []uint32) ([]tokens.IMAEntry, uint64, error) {
+	br := bufio.NewReader(r)
+	entries := []tokens.IMAEntry{}
+
+	var index uint64
+	for ; ; index++ {
+		if _, err := br.Peek(1); err == io.EOF {
+			break
+		}
+
+		e, err := readEntry(br, hashSize)
+		if err != nil {
+			return nil, 0, fmt.Errorf("entry %d: %w", index, err)
+		}
+
+		if index < offset || (len(pcrs) > 0 && !slices.Contains(pcrs, e.PCR)) {
+			continue
+		}
+
+		e.Index = index
+		entries = append(entries, *e)
+	}
+
+	return entries, index, nil
+}
+
+func readEntry(r io.Reader, hashSize int) (*tokens.IMAEntry, error) {
+	e := &tokens.IMAEntry{}
+
+	if err := binary.Read(r, binary.LittleEndian, &e.PCR); err != nil {
+		return nil, truncated(err)
+	}
+
+	e.TemplateHash = make([]byte, hashSize)
+	if _, err := io.ReadFull(r, e.TemplateHash); err != nil {
+		return nil, truncated(err)
+	}
+
+	name, err := readField(r, maxTemplateNameLen)
+	if err != nil {
+		return nil, fmt.Errorf("template name: %w", err)
+	}
+	e.TemplateName = string(name)
+
+	if e.TemplateName == legacyTemplate {
+		e.TemplateData, err = readLegacyData(r)
+	} else {
+		e.TemplateData, err = readField(r, maxTemplateDataLen)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template data: %w", err)
+	}
+
+	return e, nil
+}
+
+// readLegacyData reads the template data of the legacy template, made of
+// the file digest followed by the length-prefixed file name
+func readLegacyData(r io.Reader) ([]byte, error) {
+	digest := make([]byte, legacyDigestSize)
+	if _, err := io.ReadFull(r, digest); err != nil {
+		return nil, truncated(err)
+	}
+
+	name, err := readField(r, maxTemplateNameLen+1)
+	if err != nil {
+		return nil, err
+	}
+
+	data := binary.LittleEndian.AppendUint32(digest, uint32(len(name)))
+	return append(data, name...), nil
+}
+
+// readField reads a field prefixed with its 32-bit length
+func readField(r io.Reader, maxLen uint32) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, truncated(err)
+	}
+
+	if n > maxLen {
+		return nil, fmt.Errorf("length %d exceeds %d", n, maxLen)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, truncated(err)
+	}
+
+	return b, nil
+}
+
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
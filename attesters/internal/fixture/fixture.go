@@ -0,0 +1,44 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fixture reads the fixtures embedded by the mock and emulated
+// attesters. The fixtures are bundled at build time, so that failing to read
+// them is a programming error, reported with a panic.
+package fixture
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// MustRead returns the contents of fixtures/name in fsys
+func MustRead(fsys fs.FS, name string) []byte {
+	data, err := fs.ReadFile(fsys, path.Join("fixtures", name))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// MustReadPEM returns the contents of the first PEM block of fixtures/name in
+// fsys
+func MustReadPEM(fsys fs.FS, name string) []byte {
+	block, _ := pem.Decode(MustRead(fsys, name))
+	if block == nil {
+		panic(fmt.Sprintf("no PEM data in %s", name))
+	}
+	return block.Bytes
+}
+
+// TrustAnchor returns the root certificate of a test CA, in fixtures/root.crt
+// in fsys
+func TrustAnchor(fsys fs.FS) *x509.Certificate {
+	cert, err := x509.ParseCertificate(MustReadPEM(fsys, "root.crt"))
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package fixture
+
+import (
+	"encoding/pem"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MustRead(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fixtures/data.bin": {Data: []byte("data")},
+		"fixtures/cert.pem": {Data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("der")})},
+		"fixtures/root.crt": {Data: []byte("not PEM")},
+	}
+
+	assert.Equal(t, []byte("data"), MustRead(fsys, "data.bin"))
+	assert.Equal(t, []byte("der"), MustReadPEM(fsys, "cert.pem"))
+
+	assert.Panics(t, func() { MustRead(fsys, "missing.bin") })
+	assert.PanicsWithValue(t, "no PEM data in data.bin", func() { MustReadPEM(fsys, "data.bin") })
+	assert.PanicsWithValue(t, "no PEM data in root.crt", func() { TrustAnchor(fsys) })
+}
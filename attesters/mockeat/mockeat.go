@@ -0,0 +1,173 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package mockeat
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"embed"
+	"fmt"
+	"net/http"
+
+	"github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+	"github.com/veraison/ratsd/attesters/internal/fixture"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	nonceSize = 32
+
+	// oemID is the IANA Private Enterprise Number of Veraison
+	oemID = 48482
+	// ueidTypeRAND is the UEID type byte of a random UEID
+	ueidTypeRAND = 0x01
+)
+
+// The fixtures are a test CA made of a root and an intermediate certificate,
+// and the attester key and certificate issued by the intermediate CA. They
+// must never be trusted outside of tests.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "mock-eat",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.MockEATMediaType,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type MockPlugin struct {
+	signer  cose.Signer
+	x5chain [][]byte
+	ueid    []byte
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (m *MockPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (m *MockPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (m *MockPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (m *MockPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the mock EAT attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if in.ContentType != tokens.MockEATMediaType {
+		errMsg := fmt.Errorf(
+			"no supported format in mock EAT plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	evidence, err := m.sign(in.Nonce)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to sign mock EAT: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   evidence,
+		StatusCode: http.StatusOK,
+	}
+}
+
+func (m *MockPlugin) sign(nonce []byte) ([]byte, error) {
+	claims := tokens.EATClaims{
+		Profile:   tokens.MockEATProfile,
+		Nonce:     nonce,
+		UEID:      m.ueid,
+		OEMID:     oemID,
+		SWName:    sid.Name,
+		SWVersion: []string{sid.Version},
+	}
+
+	payload, err := cbor.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := cose.NewSign1Message()
+	msg.Headers.Protected.SetAlgorithm(m.signer.Algorithm())
+	msg.Headers.Protected[cose.HeaderLabelX5Chain] = m.x5chain
+	msg.Payload = payload
+
+	if err := msg.Sign(rand.Reader, nil, m.signer); err != nil {
+		return nil, err
+	}
+
+	return msg.MarshalCBOR()
+}
+
+// TrustAnchor returns the root certificate of the test CA that issued the
+// certificate of the mock EAT attester
+func TrustAnchor() *x509.Certificate {
+	return fixture.TrustAnchor(fixtures)
+}
+
+// TrustAnchorPEM returns the PEM encoded root certificate of the test CA
+func TrustAnchorPEM() []byte {
+	return fixture.MustRead(fixtures, "root.crt")
+}
+
+func GetPlugin() *MockPlugin {
+	key, err := x509.ParsePKCS8PrivateKey(fixture.MustReadPEM(fixtures, "attester.key"))
+	if err != nil {
+		panic(err)
+	}
+
+	signer, err := cose.NewSigner(cose.AlgorithmES256, key.(crypto.Signer))
+	if err != nil {
+		panic(err)
+	}
+
+	cert := fixture.MustReadPEM(fixtures, "attester.crt")
+	digest := sha256.Sum256(cert)
+
+	return &MockPlugin{
+		signer:  signer,
+		x5chain: [][]byte{cert, fixture.MustReadPEM(fixtures, "intermediate.crt")},
+		ueid:    append([]byte{ueidTypeRAND}, digest[:]...),
+	}
+}
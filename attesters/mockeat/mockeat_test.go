@@ -0,0 +1,121 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package mockeat
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+)
+
+var (
+	p = GetPlugin()
+)
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, p.GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, p.GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, p.GetSupportedFormats())
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	inblob := []byte("abcdefghijklmnop")
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.MockEATMediaType,
+		Nonce:       inblob,
+	}
+
+	errMsg := fmt.Sprintf(
+		"nonce size of the mock EAT attester should be %d, got %d", nonceSize, len(inblob))
+	expected := &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false,
+			Error:  errMsg,
+		},
+		StatusCode: http.StatusBadRequest,
+	}
+
+	assert.Equal(t, expected, p.GetEvidence(in))
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.EATMediaType,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	expected := &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false,
+			Error:  "no supported format in mock EAT plugin matches the requested format",
+		},
+		StatusCode: http.StatusBadRequest,
+	}
+
+	assert.Equal(t, expected, p.GetEvidence(in))
+}
+
+func Test_GetEvidence(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.MockEATMediaType,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	eat, err := tokens.ParseEAT(out.Evidence)
+	require.NoError(t, err)
+	assert.Equal(t, tokens.MockEATProfile, eat.Claims.Profile)
+	assert.Equal(t, []byte(validNonceStr), eat.Claims.Nonce)
+	assert.Len(t, eat.Claims.UEID, 33)
+	assert.Equal(t, "mock-eat", eat.Claims.SWName)
+	assert.Equal(t, []string{"1.0.0"}, eat.Claims.SWVersion)
+	require.Len(t, eat.X5Chain, 2)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(TrustAnchor())
+	assert.NoError(t, eat.Verify(roots, time.Time{}))
+}
+
+func Test_TrustAnchorPEM(t *testing.T) {
+	block, _ := pem.Decode(TrustAnchorPEM())
+	require.NotNil(t, block)
+	assert.Equal(t, "CERTIFICATE", block.Type)
+	assert.Equal(t, TrustAnchor().Raw, block.Bytes)
+	assert.True(t, TrustAnchor().IsCA)
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"github.com/veraison/ratsd/attesters/mockeat"
+	"github.com/veraison/ratsd/plugin"
+)
+
+func main() {
+	m := mockeat.GetPlugin()
+	plugin.RegisterImplementation(m)
+	plugin.Serve()
+}
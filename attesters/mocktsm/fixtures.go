@@ -0,0 +1,177 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package mocktsm
+
+import (
+	"embed"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-configfs-tsm/configfs/faketsm"
+	cose "github.com/veraison/go-cose"
+	"github.com/veraison/ratsd/attesters/internal/fixture"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const fakeProvider = "fake"
+
+// The fixtures are structurally valid evidence of each TSM provider, with the
+// report data left blank. The SEV-SNP certificates chain up to a mock ARK.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// Locations of the inblob and privlevel in the fixtures
+const (
+	sevSNPVMPLOffset       = 0x30
+	sevSNPReportDataOffset = 0x50
+	sevSNPMaxVMPL          = 3
+	tdxReportDataOffset    = 48 + 520
+	ccaRealmTokenKey       = 44241
+	ccaChallengeKey        = 10
+)
+
+// emulatedProvider renders the outblob and auxblob of an emulated TSM
+// provider
+type emulatedProvider struct {
+	outblob func(inblob []byte, privlevel uint) ([]byte, error)
+	// auxblob is nil if the provider has no auxblob
+	auxblob []byte
+	// maxPrivlevel is the highest privlevel accepted by the provider
+	maxPrivlevel uint
+}
+
+var emulatedProviders = map[string]*emulatedProvider{
+	tokens.TSMProviderSEVSNP: {
+		outblob:      renderSEVSNPReport,
+		auxblob:      fixture.MustRead(fixtures, "sev-snp-certs.bin"),
+		maxPrivlevel: sevSNPMaxVMPL,
+	},
+	tokens.TSMProviderTDX: {
+		outblob: renderTDXQuote,
+	},
+	tokens.TSMProviderCCA: {
+		outblob: renderCCAToken,
+	},
+}
+
+// hasAuxBlob tells whether the provider returns an auxblob
+func hasAuxBlob(provider string) bool {
+	if provider == fakeProvider {
+		return true
+	}
+	return emulatedProviders[provider].auxblob != nil
+}
+
+// emulate replaces the attributes of the fake report subsystem with those of
+// the given TSM provider
+func emulate(sub *faketsm.ReportSubsystem, provider string) {
+	ep := emulatedProviders[provider]
+	checkInAttr, readAttr := sub.CheckInAttr, sub.ReadAttr
+
+	sub.CheckInAttr = func(e *faketsm.ReportEntry, attr string, contents []byte) error {
+		if err := checkInAttr(e, attr, contents); err != nil {
+			return err
+		}
+		if attr == "privlevel" {
+			level, err := parsePrivlevel(contents)
+			if err != nil || level > ep.maxPrivlevel {
+				return syscall.EINVAL
+			}
+		}
+		return nil
+	}
+
+	sub.ReadAttr = func(e *faketsm.ReportEntry, attr string) ([]byte, error) {
+		switch attr {
+		case "provider":
+			return []byte(provider + "\n"), nil
+		case "auxblob":
+			if ep.auxblob == nil {
+				return nil, os.ErrNotExist
+			}
+			return clone(ep.auxblob), nil
+		case "outblob":
+			inblob := e.InAttrs["inblob"].Value
+			if len(inblob) == 0 {
+				return nil, syscall.EINVAL
+			}
+			level, err := parsePrivlevel(e.InAttrs["privlevel"].Value)
+			if err != nil {
+				return nil, syscall.EINVAL
+			}
+			return ep.outblob(inblob, level)
+		}
+		return readAttr(e, attr)
+	}
+}
+
+func parsePrivlevel(b []byte) (uint, error) {
+	level, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 32)
+	return uint(level), err
+}
+
+func renderSEVSNPReport(inblob []byte, privlevel uint) ([]byte, error) {
+	r := fixture.MustRead(fixtures, "sev-snp-report.bin")
+	r[sevSNPVMPLOffset] = byte(privlevel)
+	copy(r[sevSNPReportDataOffset:sevSNPReportDataOffset+nonceSize], inblob)
+	return r, nil
+}
+
+func renderTDXQuote(inblob []byte, _ uint) ([]byte, error) {
+	q := fixture.MustRead(fixtures, "tdx-quote.bin")
+	copy(q[tdxReportDataOffset:tdxReportDataOffset+nonceSize], inblob)
+	return q, nil
+}
+
+// renderCCAToken sets the challenge of the realm token to the inblob. The
+// realm token signature is kept, so it no longer verifies.
+func renderCCAToken(inblob []byte, _ uint) ([]byte, error) {
+	var tag cbor.RawTag
+	if err := cbor.Unmarshal(fixture.MustRead(fixtures, "arm-cca-token.cbor"), &tag); err != nil {
+		return nil, err
+	}
+
+	var collection map[uint64][]byte
+	if err := cbor.Unmarshal(tag.Content, &collection); err != nil {
+		return nil, err
+	}
+
+	var realm cose.Sign1Message
+	if err := realm.UnmarshalCBOR(collection[ccaRealmTokenKey]); err != nil {
+		return nil, err
+	}
+
+	var claims map[int64]cbor.RawMessage
+	if err := cbor.Unmarshal(realm.Payload, &claims); err != nil {
+		return nil, err
+	}
+
+	challenge, err := cbor.Marshal(inblob)
+	if err != nil {
+		return nil, err
+	}
+	claims[ccaChallengeKey] = challenge
+
+	if realm.Payload, err = cbor.Marshal(claims); err != nil {
+		return nil, err
+	}
+
+	if collection[ccaRealmTokenKey], err = realm.MarshalCBOR(); err != nil {
+		return nil, err
+	}
+
+	content, err := cbor.Marshal(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return cbor.Marshal(cbor.RawTag{Number: tag.Number, Content: content})
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
@@ -9,16 +9,13 @@ import (
 	"strconv"
 
 	"github.com/google/go-configfs-tsm/configfs/configfsi"
-	"github.com/google/go-configfs-tsm/configfs/faketsm"
 	"github.com/google/go-configfs-tsm/report"
+	"github.com/veraison/ratsd/attesters/tsm"
 	"github.com/veraison/ratsd/proto/compositor"
 	"github.com/veraison/ratsd/tokens"
 )
 
-const (
-	mediaType = tokens.TSMReportMediaTypeJSON
-	nonceSize = 64
-)
+const nonceSize = 64
 
 var (
 	sid = &compositor.SubAttesterID{
@@ -28,7 +25,11 @@ var (
 
 	supportedFormats = []*compositor.Format{
 		&compositor.Format{
-			ContentType: mediaType,
+			ContentType: tokens.TSMReportMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.TSMReportMediaTypeCBOR,
 			NonceSize:   nonceSize,
 		},
 	}
@@ -37,7 +38,8 @@ var (
 )
 
 type MockPlugin struct {
-	client *faketsm.Client
+	// clients maps the emulated TSM providers to their fake configfs-TSM
+	clients map[string]configfsi.Client
 }
 
 func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
@@ -52,7 +54,9 @@ func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
 func (m *MockPlugin) GetOptions() *compositor.OptionsOut {
 	options := []*compositor.Option{
 		&compositor.Option{Name: "privilege_level", Type: "string"},
+		&compositor.Option{Name: "provider", Type: "string"},
 	}
+	options = append(options, tsm.ServiceOptions...)
 
 	return &compositor.OptionsOut{
 		Options: options,
@@ -83,16 +87,12 @@ func (m *MockPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.Evidence
 		return getEvidenceError(errMsg, http.StatusBadRequest)
 	}
 
-	if in.ContentType != mediaType {
+	if in.ContentType != tokens.TSMReportMediaTypeJSON &&
+		in.ContentType != tokens.TSMReportMediaTypeCBOR {
 		errMsg := fmt.Errorf(
 			"no supported format in mock TSM plugin matches the requested format")
 		return getEvidenceError(errMsg, http.StatusBadRequest)
 	}
-	req := &report.Request{
-		InBlob:     in.Nonce,
-		GetAuxBlob: true,
-	}
-
 	options := make(map[string]string)
 	if len(in.Options) > 0 {
 		if err := json.Unmarshal(in.Options, &options); err != nil {
@@ -102,6 +102,23 @@ func (m *MockPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.Evidence
 		}
 	}
 
+	provider := fakeProvider
+	if p, ok := options["provider"]; ok {
+		provider = p
+	}
+
+	client, ok := m.clients[provider]
+	if !ok {
+		errMsg := fmt.Errorf(
+			"provider %s is not emulated by the mock TSM plugin", provider)
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	req := &report.Request{
+		InBlob:     in.Nonce,
+		GetAuxBlob: hasAuxBlob(provider),
+	}
+
 	if privlevel, ok := options["privilege_level"]; ok {
 		level, err := strconv.Atoi(privlevel)
 		if err != nil || level < 0 {
@@ -112,21 +129,30 @@ func (m *MockPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.Evidence
 		req.Privilege = &report.Privilege{Level: uint(level)}
 	}
 
-	resp, err := report.Get(m.client, req)
+	sr, err := tsm.ParseServiceRequest(options)
+	if err != nil {
+		return getEvidenceError(err, http.StatusBadRequest)
+	}
+
+	out, err := tsm.GetReport(client, req, sr)
 	if err != nil {
 		errMsg := fmt.Errorf("failed to get mock TSM report: %v", err)
 		return getEvidenceError(errMsg, http.StatusInternalServerError)
 	}
 
-	out := &tokens.TSMReport{
-		Provider: resp.Provider,
-		OutBlob:  resp.OutBlob,
-		AuxBlob:  resp.AuxBlob,
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.TSMReportMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
 	}
 
-	outEncoded, err := out.ToJSON()
+	outEncoded, err := encodeOp()
 	if err != nil {
-		errMsg := fmt.Errorf("failed to JSON encode mock TSM report: %v", err)
+		errMsg := fmt.Errorf("failed to %s encode mock TSM report: %v", encodeAs, err)
 		return getEvidenceError(errMsg, http.StatusInternalServerError)
 	}
 
@@ -138,11 +164,14 @@ func (m *MockPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.Evidence
 }
 
 func GetPlugin() *MockPlugin {
+	clients := map[string]configfsi.Client{
+		fakeProvider: newClient(fakeProvider),
+	}
+	for provider := range emulatedProviders {
+		clients[provider] = newClient(provider)
+	}
+
 	return &MockPlugin{
-		client: &faketsm.Client{
-			Subsystems: map[string]configfsi.Client{
-				"report": faketsm.ReportV7(0),
-			},
-		},
+		clients: clients,
 	}
 }
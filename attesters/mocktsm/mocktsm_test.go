@@ -3,12 +3,14 @@
 package mocktsm
 
 import (
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/veraison/ratsd/proto/compositor"
 	"github.com/veraison/ratsd/tokens"
 )
@@ -24,6 +26,10 @@ var (
 func Test_GetOptions(t *testing.T) {
 	options := []*compositor.Option{
 		&compositor.Option{Name: "privilege_level", Type: "string"},
+		&compositor.Option{Name: "provider", Type: "string"},
+		&compositor.Option{Name: "service_provider", Type: "string"},
+		&compositor.Option{Name: "service_guid", Type: "string"},
+		&compositor.Option{Name: "service_manifest_version", Type: "string"},
 	}
 
 	expected := &compositor.OptionsOut{
@@ -93,7 +99,7 @@ func Test_GetEvidence_invalid_format(t *testing.T) {
 func Test_GetEvidence_No_Options(t *testing.T) {
 	inblob := []byte(validNonceStr)
 	in := &compositor.EvidenceIn{
-		ContentType: string(mediaType),
+		ContentType: tokens.TSMReportMediaTypeJSON,
 		Nonce:       inblob,
 	}
 
@@ -123,12 +129,16 @@ func TestGetEvidence_With_Invalid_Options(t *testing.T) {
 		"privilege_level -20 is invalid"},
 		{"invalid json", `{"privilege_level"}`,
 		`failed to parse {"privilege_level"}: invalid character '}' after object key`},
+		{"service guid without provider", `{"service_guid": "c476f1eb-0123-45a5-9641-b4e7dde5bfe3"}`,
+		"service_guid requires service_provider"},
+		{"invalid manifest version", `{"service_provider": "svsm", "service_manifest_version": "x"}`,
+		"service_manifest_version x is invalid"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inblob := []byte(validNonceStr)
 			in := &compositor.EvidenceIn{
-				ContentType: string(mediaType),
+				ContentType: tokens.TSMReportMediaTypeJSON,
 				Nonce:       inblob,
 				Options:     []byte(tt.params),
 			}
@@ -149,7 +159,7 @@ func TestGetEvidence_With_Invalid_Options(t *testing.T) {
 func Test_GetEvidence_With_Valid_Privilege_level(t *testing.T) {
 	inblob := []byte(validNonceStr)
 	in := &compositor.EvidenceIn{
-		ContentType: string(mediaType),
+		ContentType: tokens.TSMReportMediaTypeJSON,
 		Nonce:       inblob,
 		Options:     []byte(`{"privilege_level": "1"}`),
 	}
@@ -171,3 +181,198 @@ func Test_GetEvidence_With_Valid_Privilege_level(t *testing.T) {
 
 	assert.Equal(t, expected, p.GetEvidence(in))
 }
+
+func Test_GetEvidence_With_Service_Provider(t *testing.T) {
+	inblob := []byte(validNonceStr)
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeJSON,
+		Nonce:       inblob,
+		Options: []byte(`{"service_provider": "svsm", ` +
+			`"service_guid": "c476f1eb-0123-45a5-9641-b4e7dde5bfe3", "service_manifest_version": "1"}`),
+	}
+
+	serviceProvider := "svsm"
+	out := &tokens.TSMReport{
+		Provider:        "fake\n",
+		OutBlob:         []byte(fmt.Sprintf("privlevel: 0\ninblob: %s", hex.EncodeToString(inblob))),
+		AuxBlob:         []byte("auxblob"),
+		ServiceProvider: &serviceProvider,
+		ManifestBlob: []byte("service_provider: svsm\n" +
+			"service_guid: c476f1eb-0123-45a5-9641-b4e7dde5bfe3\nservice_manifest_version: 1"),
+	}
+
+	outEncoded, err := out.ToJSON()
+	assert.NoError(t, err)
+
+	expected := &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+
+	assert.Equal(t, expected, p.GetEvidence(in))
+}
+
+func Test_GetEvidence_With_Unknown_Service_Provider(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"service_provider": "other"}`),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Contains(t, out.Status.Error, "failed to get mock TSM report: could not write report service_provider")
+}
+
+func Test_GetEvidence_Emulated_Providers(t *testing.T) {
+	inblob := []byte(validNonceStr)
+
+	for _, provider := range []string{
+		tokens.TSMProviderSEVSNP, tokens.TSMProviderTDX, tokens.TSMProviderCCA,
+	} {
+		for _, mt := range []string{tokens.TSMReportMediaTypeJSON, tokens.TSMReportMediaTypeCBOR} {
+			t.Run(provider+" "+mt, func(t *testing.T) {
+				in := &compositor.EvidenceIn{
+					ContentType: mt,
+					Nonce:       inblob,
+					Options:     []byte(fmt.Sprintf(`{"provider": %q}`, provider)),
+				}
+
+				out := p.GetEvidence(in)
+				require.True(t, out.Status.Result, out.Status.Error)
+				assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+				var report tokens.TSMReport
+				if mt == tokens.TSMReportMediaTypeCBOR {
+					require.NoError(t, report.FromCBOR(out.Evidence))
+				} else {
+					require.NoError(t, report.FromJSON(out.Evidence))
+				}
+				assert.Equal(t, provider, report.ProviderName())
+
+				reportData, err := report.ReportData()
+				require.NoError(t, err)
+				assert.Equal(t, inblob, reportData)
+			})
+		}
+	}
+}
+
+func Test_GetEvidence_Emulated_SEVSNP(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"provider": "sev_guest", "privilege_level": "2"}`),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	var report tokens.TSMReport
+	require.NoError(t, report.FromJSON(out.Evidence))
+
+	r, err := tokens.ParseSEVSNPReport(report.OutBlob)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), r.VMPL)
+	assert.Equal(t, r.CommittedTCB, r.LaunchTCB)
+
+	certs, err := tokens.ParseSEVSNPCertTable(report.AuxBlob)
+	require.NoError(t, err)
+	require.Len(t, certs, 3)
+
+	pool := x509.NewCertPool()
+	var vcek *x509.Certificate
+	for _, c := range certs {
+		cert, err := c.X509()
+		require.NoError(t, err)
+		if c.Kind == "VCEK" {
+			vcek = cert
+		} else {
+			pool.AddCert(cert)
+		}
+	}
+	require.NotNil(t, vcek)
+
+	_, err = vcek.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: vcek.NotBefore,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_GetEvidence_Emulated_No_AuxBlob(t *testing.T) {
+	for _, provider := range []string{tokens.TSMProviderTDX, tokens.TSMProviderCCA} {
+		in := &compositor.EvidenceIn{
+			ContentType: tokens.TSMReportMediaTypeJSON,
+			Nonce:       []byte(validNonceStr),
+			Options:     []byte(fmt.Sprintf(`{"provider": %q}`, provider)),
+		}
+
+		out := p.GetEvidence(in)
+		require.True(t, out.Status.Result, out.Status.Error)
+
+		var report tokens.TSMReport
+		require.NoError(t, report.FromJSON(out.Evidence))
+		assert.Empty(t, report.AuxBlob, provider)
+	}
+}
+
+func Test_GetEvidence_Emulated_Invalid_Privilege_level(t *testing.T) {
+	tests := []struct{ provider, level string }{
+		{tokens.TSMProviderSEVSNP, "4"},
+		{tokens.TSMProviderTDX, "1"},
+		{tokens.TSMProviderCCA, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			in := &compositor.EvidenceIn{
+				ContentType: tokens.TSMReportMediaTypeJSON,
+				Nonce:       []byte(validNonceStr),
+				Options: []byte(fmt.Sprintf(`{"provider": %q, "privilege_level": %q}`,
+					tt.provider, tt.level)),
+			}
+
+			out := p.GetEvidence(in)
+			assert.False(t, out.Status.Result)
+			assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+			assert.Contains(t, out.Status.Error, "could not write report privlevel")
+		})
+	}
+}
+
+func Test_GetEvidence_Emulated_Service_Provider(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeCBOR,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"provider": "sev_guest", "service_provider": "svsm"}`),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	var report tokens.TSMReport
+	require.NoError(t, report.FromCBOR(out.Evidence))
+	assert.Equal(t, tokens.TSMProviderSEVSNP, report.ProviderName())
+	require.NotNil(t, report.ServiceProvider)
+	assert.Equal(t, "svsm", *report.ServiceProvider)
+}
+
+func Test_GetEvidence_Unknown_Provider(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"provider": "other"}`),
+	}
+
+	expected := &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false,
+			Error:  "provider other is not emulated by the mock TSM plugin",
+		},
+		StatusCode: http.StatusBadRequest,
+	}
+
+	assert.Equal(t, expected, p.GetEvidence(in))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package mocktsm
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/configfs/faketsm"
+)
+
+const fakeServiceProvider = "svsm"
+
+var serviceAttrs = []string{"service_provider", "service_guid", "service_manifest_version"}
+
+// newReportSubsystem returns the fake configfs-TSM report subsystem of the
+// given TSM provider, with support for the fake "svsm" service provider. When a
+// service provider is selected, the manifestblob records the service
+// attributes.
+func newReportSubsystem(provider string) *faketsm.ReportSubsystem {
+	sub := faketsm.ReportV7(0)
+	if provider != fakeProvider {
+		emulate(sub, provider)
+	}
+	makeEntry, checkInAttr, readAttr := sub.MakeEntry, sub.CheckInAttr, sub.ReadAttr
+
+	sub.MakeEntry = func() *faketsm.ReportEntry {
+		e := makeEntry()
+		for _, attr := range serviceAttrs {
+			e.InAttrs[attr] = &faketsm.ReportAttributeState{}
+		}
+		return e
+	}
+
+	sub.CheckInAttr = func(e *faketsm.ReportEntry, attr string, contents []byte) error {
+		switch attr {
+		case "service_provider":
+			if strings.TrimSpace(string(contents)) != fakeServiceProvider {
+				return syscall.EINVAL
+			}
+		case "service_guid", "service_manifest_version":
+			if len(e.InAttrs["service_provider"].Value) == 0 {
+				return syscall.EINVAL
+			}
+		default:
+			return checkInAttr(e, attr, contents)
+		}
+		return nil
+	}
+
+	sub.ReadAttr = func(e *faketsm.ReportEntry, attr string) ([]byte, error) {
+		if attr != "manifestblob" {
+			return readAttr(e, attr)
+		}
+
+		provider := e.InAttrs["service_provider"].Value
+		if len(provider) == 0 {
+			return nil, syscall.EINVAL
+		}
+
+		return fmt.Appendf(nil, "service_provider: %s\nservice_guid: %s\nservice_manifest_version: %s",
+			provider, e.InAttrs["service_guid"].Value, e.InAttrs["service_manifest_version"].Value), nil
+	}
+
+	return sub
+}
+
+func newClient(provider string) configfsi.Client {
+	return &faketsm.Client{
+		Subsystems: map[string]configfsi.Client{
+			"report": newReportSubsystem(provider),
+		},
+	}
+}
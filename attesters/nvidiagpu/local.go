@@ -0,0 +1,106 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package nvidiagpu
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/ratsd/attesters/internal/fixture"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// localSignatureSize is the size of the raw r || s ECDSA P-384 signature of
+// the evidence of the local backend
+const localSignatureSize = 96
+
+// The fixtures are a test root CA, and the device key and certificate issued
+// by it. They must never be trusted outside of tests.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// LocalBackend is a stand-in for the GPUs of the machine, for running the
+// attester and ratsd without NVIDIA hardware. The evidence of its devices is
+// the nonce, followed by the index of the device as a big-endian uint32, and
+// by the raw ECDSA P-384 signature over the SHA-384 digest of both, made with
+// the key of the certificate returned by the devices.
+type LocalBackend struct {
+	devices []Device
+}
+
+type localDevice struct {
+	index uint32
+	arch  string
+	key   *ecdsa.PrivateKey
+	cert  []byte
+}
+
+// NewLocalBackend returns a backend emulating one GPU per architecture in
+// archs
+func NewLocalBackend(archs []string) (*LocalBackend, error) {
+	if len(archs) == 0 {
+		return nil, errors.New("no GPU to emulate")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(fixture.MustReadPEM(fixtures, "device.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	b := &LocalBackend{}
+	for i, arch := range archs {
+		if arch != tokens.NvidiaGPUArchHopper && arch != tokens.NvidiaGPUArchBlackwell {
+			return nil, fmt.Errorf("arch %s is not supported", arch)
+		}
+
+		b.devices = append(b.devices, &localDevice{
+			index: uint32(i),
+			arch:  arch,
+			key:   key.(*ecdsa.PrivateKey),
+			cert:  fixture.MustRead(fixtures, "device.crt"),
+		})
+	}
+
+	return b, nil
+}
+
+func (b *LocalBackend) Devices() ([]Device, error) {
+	return b.devices, nil
+}
+
+func (d *localDevice) Arch() string {
+	return d.arch
+}
+
+func (d *localDevice) Certificate() ([]byte, error) {
+	return d.cert, nil
+}
+
+func (d *localDevice) Evidence(nonce []byte) ([]byte, error) {
+	msg := binary.BigEndian.AppendUint32(append([]byte{}, nonce...), d.index)
+	digest := sha512.Sum384(msg)
+
+	r, s, err := ecdsa.Sign(rand.Reader, d.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, localSignatureSize)
+	r.FillBytes(sig[:localSignatureSize/2])
+	s.FillBytes(sig[localSignatureSize/2:])
+
+	return append(msg, sig...), nil
+}
+
+// TrustAnchor returns the root certificate of the test CA that issued the
+// certificate of the devices of the local backend
+func TrustAnchor() *x509.Certificate {
+	return fixture.TrustAnchor(fixtures)
+}
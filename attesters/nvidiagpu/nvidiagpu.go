@@ -0,0 +1,229 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nvidiagpu implements a sub-attester collecting the evidence of the
+// NVIDIA GPUs of the machine, which it reaches through a Backend.
+package nvidiagpu
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const nonceSize = tokens.NvidiaGPUNonceSize
+
+// Environment variables holding the configuration of the NVIDIA GPU attester
+const (
+	// EnvBackend selects the backend, BackendLocal or none if unset
+	EnvBackend = "RATSD_NVIDIA_GPU_BACKEND"
+	// EnvLocalArchs is the comma-separated list of the architectures of
+	// the GPUs emulated by the local backend
+	EnvLocalArchs = "RATSD_NVIDIA_GPU_LOCAL_ARCHS"
+)
+
+// BackendLocal is the name of the stand-in backend, see LocalBackend
+const BackendLocal = "local"
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "nvidia-gpu",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.NvidiaGPUEvidenceMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.NvidiaGPUEvidenceMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	errNoBackend = errors.New("no backend configured")
+	errNoGPU     = errors.New("no NVIDIA GPU found")
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Device is a GPU able to attest itself
+type Device interface {
+	// Arch returns the architecture of the GPU, e.g.,
+	// tokens.NvidiaGPUArchHopper
+	Arch() string
+	// Certificate returns the certificate chain of the GPU
+	Certificate() ([]byte, error)
+	// Evidence returns the attestation report of the GPU, bound to nonce
+	Evidence(nonce []byte) ([]byte, error)
+}
+
+// Backend gives access to the GPUs of the machine
+type Backend interface {
+	// Devices returns the GPUs, in the order of their indices
+	Devices() ([]Device, error)
+}
+
+type GPUPlugin struct {
+	backend Backend
+}
+
+// NewPlugin returns an NVIDIA GPU attester using the given backend. A nil
+// backend makes the attester unavailable.
+func NewPlugin(backend Backend) *GPUPlugin {
+	return &GPUPlugin{backend: backend}
+}
+
+func (p *GPUPlugin) devices() ([]Device, error) {
+	if p.backend == nil {
+		return nil, errNoBackend
+	}
+
+	devices, err := p.backend.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, errNoGPU
+	}
+
+	return devices, nil
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *GPUPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (p *GPUPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *GPUPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if _, err := p.devices(); err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("NVIDIA GPU is not available: %s", err.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (p *GPUPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the NVIDIA GPU attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in nvidia-gpu plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	devices, err := p.devices()
+	if err != nil {
+		errMsg := fmt.Errorf("NVIDIA GPU is not available: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	out := make(tokens.NvidiaGPUEvidence, 0, len(devices))
+	for i, d := range devices {
+		cert, err := d.Certificate()
+		if err != nil {
+			errMsg := fmt.Errorf("failed to get the certificate of GPU %d: %v", i, err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+
+		evidence, err := d.Evidence(in.Nonce)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to get the evidence of GPU %d: %v", i, err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+
+		out = append(out, tokens.NvidiaGPU{
+			Arch:        d.Arch(),
+			Certificate: cert,
+			Evidence:    evidence,
+			Nonce:       in.Nonce,
+		})
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.NvidiaGPUEvidenceMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode GPU evidence as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// GetPlugin returns the NVIDIA GPU attester with the backend selected by
+// the environment
+func GetPlugin() (*GPUPlugin, error) {
+	switch backend := os.Getenv(EnvBackend); backend {
+	case "":
+		return NewPlugin(nil), nil
+	case BackendLocal:
+		archs := []string{tokens.NvidiaGPUArchHopper}
+		if v, ok := os.LookupEnv(EnvLocalArchs); ok {
+			archs = strings.Split(v, ",")
+			for i := range archs {
+				archs[i] = strings.TrimSpace(archs[i])
+			}
+		}
+
+		b, err := NewLocalBackend(archs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvLocalArchs, err)
+		}
+		return NewPlugin(b), nil
+	default:
+		return nil, fmt.Errorf("%s: backend %s is not supported", EnvBackend, backend)
+	}
+}
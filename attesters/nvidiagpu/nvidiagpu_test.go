@@ -0,0 +1,248 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package nvidiagpu
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+
+// fakeBackend fails in the ways selected by its fields
+type fakeBackend struct {
+	devices    []Device
+	devicesErr error
+}
+
+func (b *fakeBackend) Devices() ([]Device, error) {
+	return b.devices, b.devicesErr
+}
+
+type fakeDevice struct {
+	certErr     error
+	evidenceErr error
+}
+
+func (d *fakeDevice) Arch() string { return tokens.NvidiaGPUArchBlackwell }
+
+func (d *fakeDevice) Certificate() ([]byte, error) { return []byte("cert"), d.certErr }
+
+func (d *fakeDevice) Evidence(nonce []byte) ([]byte, error) { return nonce, d.evidenceErr }
+
+func newLocalPlugin(t *testing.T, archs ...string) *GPUPlugin {
+	b, err := NewLocalBackend(archs)
+	require.NoError(t, err)
+	return NewPlugin(b)
+}
+
+func getGPUEvidence(t *testing.T, p *GPUPlugin, contentType string) tokens.NvidiaGPUEvidence {
+	in := &compositor.EvidenceIn{
+		ContentType: contentType,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	e := tokens.NvidiaGPUEvidence{}
+	if contentType == tokens.NvidiaGPUEvidenceMediaTypeCBOR {
+		require.NoError(t, e.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, e.FromJSON(out.Evidence))
+	}
+
+	return e
+}
+
+func getEvidenceFailure(t *testing.T, p *GPUPlugin) *compositor.EvidenceOut {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.NvidiaGPUEvidenceMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+
+	return out
+}
+
+// verifyLocalEvidence checks the evidence of the device of the local backend
+// with the given index
+func verifyLocalEvidence(t *testing.T, g tokens.NvidiaGPU, index uint32) {
+	block, _ := pem.Decode(g.Certificate)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(TrustAnchor())
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots})
+	require.NoError(t, err)
+
+	require.Len(t, g.Evidence, nonceSize+4+localSignatureSize)
+	msg, sig := g.Evidence[:nonceSize+4], g.Evidence[nonceSize+4:]
+	assert.Equal(t, []byte(validNonceStr), []byte(msg[:nonceSize]))
+	assert.Equal(t, index, binary.BigEndian.Uint32(msg[nonceSize:]))
+
+	digest := sha512.Sum384(msg)
+	r := new(big.Int).SetBytes(sig[:localSignatureSize/2])
+	s := new(big.Int).SetBytes(sig[localSignatureSize/2:])
+	assert.True(t, ecdsa.Verify(cert.PublicKey.(*ecdsa.PublicKey), digest[:], r, s))
+}
+
+func Test_GetPlugin(t *testing.T) {
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(nil), p)
+
+	t.Setenv(EnvBackend, BackendLocal)
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, newLocalPlugin(t, tokens.NvidiaGPUArchHopper), p)
+
+	t.Setenv(EnvLocalArchs, "HOPPER, BLACKWELL")
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, newLocalPlugin(t, tokens.NvidiaGPUArchHopper, tokens.NvidiaGPUArchBlackwell), p)
+}
+
+func Test_GetPlugin_Fail(t *testing.T) {
+	t.Setenv(EnvBackend, "nvml")
+	_, err := GetPlugin()
+	assert.EqualError(t, err, "RATSD_NVIDIA_GPU_BACKEND: backend nvml is not supported")
+
+	t.Setenv(EnvBackend, BackendLocal)
+	t.Setenv(EnvLocalArchs, "HOPPER,AMPERE")
+	_, err = GetPlugin()
+	assert.EqualError(t, err, "RATSD_NVIDIA_GPU_LOCAL_ARCHS: arch AMPERE is not supported")
+
+	_, err = NewLocalBackend(nil)
+	assert.EqualError(t, err, "no GPU to emulate")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, newLocalPlugin(t, tokens.NvidiaGPUArchHopper).GetSupportedFormats())
+}
+
+func Test_GetSupportedFormats_unavailable(t *testing.T) {
+	tvs := []struct {
+		backend  Backend
+		expected string
+	}{
+		{nil, "NVIDIA GPU is not available: no backend configured"},
+		{&fakeBackend{}, "NVIDIA GPU is not available: no NVIDIA GPU found"},
+		{&fakeBackend{devicesErr: errors.New("driver not loaded")}, "NVIDIA GPU is not available: driver not loaded"},
+	}
+
+	for _, tv := range tvs {
+		out := NewPlugin(tv.backend).GetSupportedFormats()
+		assert.False(t, out.Status.Result)
+		assert.Equal(t, tv.expected, out.Status.Error)
+		assert.Empty(t, out.Formats)
+	}
+}
+
+func Test_GetEvidence_JSON(t *testing.T) {
+	p := newLocalPlugin(t, tokens.NvidiaGPUArchHopper, tokens.NvidiaGPUArchBlackwell)
+
+	e := getGPUEvidence(t, p, tokens.NvidiaGPUEvidenceMediaTypeJSON)
+	require.Len(t, e, 2)
+	assert.Equal(t, tokens.NvidiaGPUArchHopper, e[0].Arch)
+	assert.Equal(t, tokens.NvidiaGPUArchBlackwell, e[1].Arch)
+
+	for i, g := range e {
+		assert.Equal(t, tokens.HexString(validNonceStr), g.Nonce)
+		verifyLocalEvidence(t, g, uint32(i))
+	}
+}
+
+func Test_GetEvidence_CBOR(t *testing.T) {
+	e := getGPUEvidence(t, newLocalPlugin(t, tokens.NvidiaGPUArchHopper), tokens.NvidiaGPUEvidenceMediaTypeCBOR)
+	require.Len(t, e, 1)
+	verifyLocalEvidence(t, e[0], 0)
+}
+
+func Test_GetEvidence_backend_failure(t *testing.T) {
+	tvs := []struct {
+		backend  Backend
+		expected string
+	}{
+		{nil, "NVIDIA GPU is not available: no backend configured"},
+		{&fakeBackend{devicesErr: errors.New("driver not loaded")}, "NVIDIA GPU is not available: driver not loaded"},
+		{
+			&fakeBackend{devices: []Device{&fakeDevice{}, &fakeDevice{certErr: errors.New("no certificate")}}},
+			"failed to get the certificate of GPU 1: no certificate",
+		},
+		{
+			&fakeBackend{devices: []Device{&fakeDevice{evidenceErr: errors.New("GPU is not in CC mode")}}},
+			"failed to get the evidence of GPU 0: GPU is not in CC mode",
+		},
+	}
+
+	for _, tv := range tvs {
+		out := getEvidenceFailure(t, NewPlugin(tv.backend))
+		assert.Equal(t, tv.expected, out.Status.Error)
+	}
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.NvidiaGPUEvidenceMediaTypeJSON,
+		Nonce:       []byte("abcdefghijklmnop"),
+	}
+
+	out := newLocalPlugin(t, tokens.NvidiaGPUArchHopper).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the NVIDIA GPU attester should be 32, got 16", out.Status.Error)
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: "invalid-format",
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := newLocalPlugin(t, tokens.NvidiaGPUArchHopper).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in nvidia-gpu plugin matches the requested format", out.Status.Error)
+}
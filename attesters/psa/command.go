@@ -0,0 +1,56 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package psa
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commandTimeout bounds the time the platform attestation service has to
+// produce a token
+const commandTimeout = 30 * time.Second
+
+// CommandBackend gets the PSA token from the attestation service of the
+// platform, e.g., the one of Trusted Services or TF-M, through a command
+// calling psa_initial_attest_get_token(). The command is run with the
+// hex-encoded challenge as its last argument, and writes the token to its
+// standard output.
+type CommandBackend struct {
+	args []string
+}
+
+// NewCommandBackend returns a backend running the command made of args
+func NewCommandBackend(args []string) *CommandBackend {
+	return &CommandBackend{args: args}
+}
+
+func (b *CommandBackend) Token(challenge []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	args := append(b.args[1:len(b.args):len(b.args)], hex.EncodeToString(challenge))
+	cmd := exec.CommandContext(ctx, b.args[0], args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %v: %s", b.args[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s: %v", b.args[0], err)
+	}
+
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("%s: no token", b.args[0])
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package psa implements a sub-attester returning the PSA initial attestation
+// token of the platform, which it gets from a Backend.
+package psa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// Environment variables holding the configuration of the PSA attester
+const (
+	// EnvBackend selects the backend, BackendCommand, BackendSoftware, or
+	// none if unset
+	EnvBackend = "RATSD_PSA_BACKEND"
+	// EnvCommand is the command line of the command backend
+	EnvCommand = "RATSD_PSA_COMMAND"
+	// EnvKey is the path of the PEM encoded IAK of the software backend
+	EnvKey = "RATSD_PSA_KEY"
+)
+
+// Names of the backends
+const (
+	// BackendCommand gets the token from the platform attestation service
+	// through a command, see CommandBackend
+	BackendCommand = "command"
+	// BackendSoftware signs the token with a test key, see SoftwareBackend
+	BackendSoftware = "software"
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "psa",
+		Version: "1.0.0",
+	}
+
+	// supportedFormats lists the nonce sizes allowed by PSA, largest first,
+	// as ratsd uses the first format by default
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.PSATokenMediaType,
+			NonceSize:   64,
+		},
+		&compositor.Format{
+			ContentType: tokens.PSATokenMediaType,
+			NonceSize:   48,
+		},
+		&compositor.Format{
+			ContentType: tokens.PSATokenMediaType,
+			NonceSize:   32,
+		},
+	}
+
+	errNoBackend = errors.New("no backend configured")
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Backend gets PSA tokens from the platform
+type Backend interface {
+	// Token returns a PSA token whose nonce claim is challenge
+	Token(challenge []byte) ([]byte, error)
+}
+
+type PSAPlugin struct {
+	backend Backend
+}
+
+// NewPlugin returns a PSA attester using the given backend. A nil backend
+// makes the attester unavailable.
+func NewPlugin(backend Backend) *PSAPlugin {
+	return &PSAPlugin{backend: backend}
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *PSAPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (p *PSAPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *PSAPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if p.backend == nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("PSA attestation is not available: %s", errNoBackend.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (p *PSAPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if !slices.Contains(tokens.PSANonceSizes, len(in.Nonce)) {
+		errMsg := fmt.Errorf(
+			"nonce size of the PSA attester should be one of %v, got %d",
+			tokens.PSANonceSizes, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if in.ContentType != tokens.PSATokenMediaType {
+		errMsg := fmt.Errorf("no supported format in psa plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if p.backend == nil {
+		errMsg := fmt.Errorf("PSA attestation is not available: %v", errNoBackend)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	token, err := p.backend.Token(in.Nonce)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to get PSA token: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	// catch a backend returning a malformed or stale token
+	t, err := tokens.ParsePSAToken(token)
+	if err != nil {
+		errMsg := fmt.Errorf("backend returned an invalid PSA token: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	if !bytes.Equal(t.Claims.Nonce, in.Nonce) {
+		errMsg := fmt.Errorf("backend returned a PSA token bound to another nonce")
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   token,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// GetPlugin returns the PSA attester with the backend selected by the
+// environment
+func GetPlugin() (*PSAPlugin, error) {
+	switch backend := os.Getenv(EnvBackend); backend {
+	case "":
+		return NewPlugin(nil), nil
+	case BackendCommand:
+		args := strings.Fields(os.Getenv(EnvCommand))
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s: no command for the %s backend", EnvCommand, backend)
+		}
+		return NewPlugin(NewCommandBackend(args)), nil
+	case BackendSoftware:
+		path := os.Getenv(EnvKey)
+		if path == "" {
+			return nil, fmt.Errorf("%s: no key for the %s backend", EnvKey, backend)
+		}
+
+		b, err := NewSoftwareBackend(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvKey, err)
+		}
+		return NewPlugin(b), nil
+	default:
+		return nil, fmt.Errorf("%s: backend %s is not supported", EnvBackend, backend)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package psa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var testKey = filepath.Join("testdata", "iak.pem")
+
+// fakeBackend returns a fixed token, or fails
+type fakeBackend struct {
+	token []byte
+	err   error
+}
+
+func (b *fakeBackend) Token(challenge []byte) ([]byte, error) {
+	return b.token, b.err
+}
+
+func newSoftwarePlugin(t *testing.T) *PSAPlugin {
+	b, err := NewSoftwareBackend(testKey)
+	require.NoError(t, err)
+	return NewPlugin(b)
+}
+
+func testNonce(size int) []byte {
+	return bytes.Repeat([]byte{0x5a}, size)
+}
+
+func getEvidenceFailure(t *testing.T, p *PSAPlugin, statusCode uint32) *compositor.EvidenceOut {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.PSATokenMediaType,
+		Nonce:       testNonce(32),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, statusCode, out.StatusCode)
+
+	return out
+}
+
+func testIAK(t *testing.T) *ecdsa.PublicKey {
+	data, err := os.ReadFile(testKey)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	return &key.(*ecdsa.PrivateKey).PublicKey
+}
+
+func Test_GetPlugin(t *testing.T) {
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(nil), p)
+
+	t.Setenv(EnvBackend, BackendCommand)
+	t.Setenv(EnvCommand, "psa-token --verbose")
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(NewCommandBackend([]string{"psa-token", "--verbose"})), p)
+
+	t.Setenv(EnvBackend, BackendSoftware)
+	t.Setenv(EnvKey, testKey)
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.IsType(t, &SoftwareBackend{}, p.backend)
+}
+
+func Test_GetPlugin_Fail(t *testing.T) {
+	t.Setenv(EnvBackend, "tfm")
+	_, err := GetPlugin()
+	assert.EqualError(t, err, "RATSD_PSA_BACKEND: backend tfm is not supported")
+
+	t.Setenv(EnvBackend, BackendCommand)
+	_, err = GetPlugin()
+	assert.EqualError(t, err, "RATSD_PSA_COMMAND: no command for the command backend")
+
+	t.Setenv(EnvBackend, BackendSoftware)
+	_, err = GetPlugin()
+	assert.EqualError(t, err, "RATSD_PSA_KEY: no key for the software backend")
+
+	t.Setenv(EnvKey, filepath.Join("testdata", "missing.pem"))
+	_, err = GetPlugin()
+	assert.ErrorContains(t, err, "RATSD_PSA_KEY: open testdata/missing.pem: ")
+}
+
+func Test_NewSoftwareBackend_Fail(t *testing.T) {
+	dir := t.TempDir()
+
+	notPEM := filepath.Join(dir, "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("key"), 0o600))
+	_, err := NewSoftwareBackend(notPEM)
+	assert.EqualError(t, err, "no PEM data in "+notPEM)
+
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	p224 := filepath.Join(dir, "p224.pem")
+	require.NoError(t, os.WriteFile(p224, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	_, err = NewSoftwareBackend(p224)
+	assert.EqualError(t, err, "curve P-224 is not supported")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, newSoftwarePlugin(t).GetSupportedFormats())
+
+	var sizes []int
+	for _, f := range supportedFormats {
+		sizes = append(sizes, int(f.NonceSize))
+	}
+	assert.ElementsMatch(t, tokens.PSANonceSizes, sizes)
+}
+
+func Test_GetSupportedFormats_no_backend(t *testing.T) {
+	out := NewPlugin(nil).GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, "PSA attestation is not available: no backend configured", out.Status.Error)
+	assert.Empty(t, out.Formats)
+}
+
+func Test_GetEvidence_software(t *testing.T) {
+	p := newSoftwarePlugin(t)
+
+	for _, size := range tokens.PSANonceSizes {
+		in := &compositor.EvidenceIn{
+			ContentType: tokens.PSATokenMediaType,
+			Nonce:       testNonce(size),
+		}
+
+		out := p.GetEvidence(in)
+		require.True(t, out.Status.Result, out.Status.Error)
+		assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+		token, err := tokens.ParsePSAToken(out.Evidence)
+		require.NoError(t, err)
+		assert.NoError(t, token.Verify(testIAK(t)))
+		assert.Equal(t, testNonce(size), token.Claims.Nonce)
+		assert.Len(t, token.Claims.SoftwareComponents, 3)
+
+		instanceID, err := tokens.PSAInstanceID(testIAK(t))
+		require.NoError(t, err)
+		assert.Equal(t, instanceID, token.Claims.InstanceID)
+	}
+}
+
+func Test_GetEvidence_command(t *testing.T) {
+	nonce := testNonce(32)
+
+	b, err := NewSoftwareBackend(testKey)
+	require.NoError(t, err)
+	token, err := b.Token(nonce)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token.cbor")
+	require.NoError(t, os.WriteFile(tokenFile, token, 0o600))
+
+	// the challenge is the last argument
+	script := `test "$1" = "` + hex.EncodeToString(nonce) + `" || { echo "bad challenge $1" >&2; exit 1; }; cat ` + tokenFile
+	p := NewPlugin(NewCommandBackend([]string{"sh", "-c", script, "sh"}))
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.PSATokenMediaType,
+		Nonce:       nonce,
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, token, out.Evidence)
+
+	in.Nonce = testNonce(64)
+	out = p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to get PSA token: sh: exit status 1: bad challenge "+hex.EncodeToString(in.Nonce), out.Status.Error)
+}
+
+func Test_GetEvidence_backend_failure(t *testing.T) {
+	b, err := NewSoftwareBackend(testKey)
+	require.NoError(t, err)
+	stale, err := b.Token(testNonce(48))
+	require.NoError(t, err)
+
+	tvs := []struct {
+		backend  Backend
+		expected string
+	}{
+		{nil, "PSA attestation is not available: no backend configured"},
+		{&fakeBackend{err: errors.New("service unavailable")}, "failed to get PSA token: service unavailable"},
+		{&fakeBackend{token: []byte("token")}, "backend returned an invalid PSA token: PSA token decoding failed: "},
+		{&fakeBackend{token: stale}, "backend returned a PSA token bound to another nonce"},
+		{NewCommandBackend([]string{"true"}), "failed to get PSA token: true: no token"},
+	}
+
+	for _, tv := range tvs {
+		out := getEvidenceFailure(t, NewPlugin(tv.backend), http.StatusInternalServerError)
+		assert.Contains(t, out.Status.Error, tv.expected)
+	}
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.PSATokenMediaType,
+		Nonce:       testNonce(16),
+	}
+
+	out := newSoftwarePlugin(t).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the PSA attester should be one of [32 48 64], got 16", out.Status.Error)
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.MockEATMediaType,
+		Nonce:       testNonce(32),
+	}
+
+	out := newSoftwarePlugin(t).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in psa plugin matches the requested format", out.Status.Error)
+}
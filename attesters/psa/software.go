@@ -0,0 +1,143 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package psa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	// lifeCycleSecured is the "secured" PSA security lifecycle state
+	lifeCycleSecured = 0x3000
+	// nonSecureClientID identifies the non-secure caller of the attestation
+	// service
+	nonSecureClientID = -1
+)
+
+// SoftwareBackend is a software implementation of the PSA attestation
+// service, for running the attester and ratsd without PSA hardware. It signs
+// PSA tokens with a test IAK, and claims a fixed implementation and fixed
+// software components. Its tokens must never be trusted outside of tests.
+type SoftwareBackend struct {
+	signer cose.Signer
+	claims tokens.PSAClaims
+}
+
+// NewSoftwareBackend returns a backend signing with the IAK in the PEM
+// encoded PKCS #8 file at path. The key must be an ECDSA key on P-256,
+// P-384 or P-521.
+func NewSoftwareBackend(path string) (*SoftwareBackend, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", path)
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := k.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an ECDSA key", path)
+	}
+
+	alg, err := coseAlgorithm(key.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := cose.NewSigner(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceID, err := tokens.PSAInstanceID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// the boot seed changes at every boot, i.e., at every plugin launch
+	bootSeed := make([]byte, 32)
+	if _, err := rand.Read(bootSeed); err != nil {
+		return nil, err
+	}
+
+	implementationID := sha256.Sum256([]byte("veraison/ratsd psa software backend"))
+	signerID := sha256.Sum256([]byte("veraison/ratsd psa software signer"))
+
+	var components []tokens.PSASoftwareComponent
+	for _, name := range []string{"BL", "PRoT", "ARoT"} {
+		measurement := sha256.Sum256([]byte(name))
+		components = append(components, tokens.PSASoftwareComponent{
+			MeasurementType:  name,
+			MeasurementValue: measurement[:],
+			Version:          sid.Version,
+			SignerID:         signerID[:],
+			MeasurementDesc:  "sha-256",
+		})
+	}
+
+	return &SoftwareBackend{
+		signer: signer,
+		claims: tokens.PSAClaims{
+			Profile:            tokens.PSAProfile,
+			ClientID:           nonSecureClientID,
+			SecurityLifeCycle:  lifeCycleSecured,
+			ImplementationID:   implementationID[:],
+			BootSeed:           bootSeed,
+			SoftwareComponents: components,
+			InstanceID:         instanceID,
+		},
+	}, nil
+}
+
+func (b *SoftwareBackend) Token(challenge []byte) ([]byte, error) {
+	claims := b.claims
+	claims.Nonce = challenge
+
+	payload, err := cbor.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := cose.NewSign1Message()
+	msg.Headers.Protected.SetAlgorithm(b.signer.Algorithm())
+	msg.Payload = payload
+
+	if err := msg.Sign(rand.Reader, nil, b.signer); err != nil {
+		return nil, err
+	}
+
+	return msg.MarshalCBOR()
+}
+
+// coseAlgorithm returns the ECDSA COSE algorithm for the given curve
+func coseAlgorithm(curve elliptic.Curve) (cose.Algorithm, error) {
+	switch curve {
+	case elliptic.P256():
+		return cose.AlgorithmES256, nil
+	case elliptic.P384():
+		return cose.AlgorithmES384, nil
+	case elliptic.P521():
+		return cose.AlgorithmES512, nil
+	default:
+		return 0, fmt.Errorf("curve %s is not supported", curve.Params().Name)
+	}
+}
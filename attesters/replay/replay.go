@@ -0,0 +1,281 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package replay implements a sub-attester that serves evidence recorded
+// elsewhere, e.g., on a customer host, so that issues can be reproduced
+// deterministically.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+const (
+	// EnvDir is the environment variable holding the directory of the
+	// recordings, since ratsd passes no configuration to its plugins
+	EnvDir = "RATSD_REPLAY_DIR"
+
+	defaultNonceSize = 64
+	recordingExt     = ".json"
+)
+
+// Replay modes
+const (
+	// ModeVerbatim returns the recorded evidence whatever the nonce
+	ModeVerbatim = "verbatim"
+	// ModeCheckNonce returns the recorded evidence only if the nonce of the
+	// request is the one the evidence was recorded with
+	ModeCheckNonce = "check-nonce"
+)
+
+// Recording is a piece of evidence recorded with a given nonce. It is stored
+// as a JSON file, named after the recording, in the recordings directory.
+type Recording struct {
+	Name      string `json:"-"`
+	MediaType string `json:"media-type"`
+	Evidence  []byte `json:"evidence"`
+	// Nonce is the adjusted nonce the evidence is bound to. It is only
+	// needed to replay in ModeCheckNonce.
+	Nonce []byte `json:"nonce,omitempty"`
+	// NonceSize is the nonce size advertised for the media type. It
+	// defaults to 64, or to the size of Nonce if set.
+	NonceSize uint32 `json:"nonce-size,omitempty"`
+}
+
+// LoadRecordings loads the recordings of a directory, sorted by name
+func LoadRecordings(dir string) ([]*Recording, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+recordingExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var recordings []*Recording
+	for _, path := range paths {
+		r, err := loadRecording(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recording %s: %w", path, err)
+		}
+		recordings = append(recordings, r)
+	}
+
+	if len(recordings) == 0 {
+		return nil, fmt.Errorf("no recordings in %s", dir)
+	}
+
+	return recordings, nil
+}
+
+func loadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recording{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(r); err != nil {
+		return nil, err
+	}
+
+	r.Name = strings.TrimSuffix(filepath.Base(path), recordingExt)
+
+	if r.MediaType == "" {
+		return nil, errors.New("missing media-type")
+	}
+
+	if len(r.Evidence) == 0 {
+		return nil, errors.New("missing evidence")
+	}
+
+	if r.NonceSize == 0 {
+		r.NonceSize = defaultNonceSize
+		if len(r.Nonce) > 0 {
+			r.NonceSize = uint32(len(r.Nonce))
+		}
+	}
+
+	if len(r.Nonce) > 0 && uint32(len(r.Nonce)) != r.NonceSize {
+		return nil, fmt.Errorf("nonce size %d does not match nonce-size %d",
+			len(r.Nonce), r.NonceSize)
+	}
+
+	return r, nil
+}
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "replay",
+		Version: "1.0.0",
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type Plugin struct {
+	recordings []*Recording
+	formats    []*compositor.Format
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *Plugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "recording", Type: "string"},
+			&compositor.Option{Name: "mode", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: p.formats,
+	}
+}
+
+func (p *Plugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	options := make(map[string]string)
+	if len(in.Options) > 0 {
+		if err := json.Unmarshal(in.Options, &options); err != nil {
+			errMsg := fmt.Errorf(
+				"failed to parse %s: %v", in.Options, err)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+	}
+
+	mode := ModeVerbatim
+	if v, ok := options["mode"]; ok {
+		if v != ModeVerbatim && v != ModeCheckNonce {
+			errMsg := fmt.Errorf("mode %s is invalid", v)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+		mode = v
+	}
+
+	r, err := p.lookup(options["recording"], in.ContentType)
+	if err != nil {
+		return getEvidenceError(err, http.StatusBadRequest)
+	}
+
+	if uint32(len(in.Nonce)) != r.NonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of recording %s should be %d, got %d",
+			r.Name, r.NonceSize, len(in.Nonce))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if mode == ModeCheckNonce {
+		if len(r.Nonce) == 0 {
+			errMsg := fmt.Errorf("recording %s has no nonce", r.Name)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+
+		if !bytes.Equal(in.Nonce, r.Nonce) {
+			errMsg := fmt.Errorf("nonce does not match recording %s", r.Name)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   r.Evidence,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// lookup returns the recording with the given name, or the first recording of
+// the given media type if name is empty
+func (p *Plugin) lookup(name, mediaType string) (*Recording, error) {
+	for _, r := range p.recordings {
+		if name != "" && r.Name != name {
+			continue
+		}
+
+		if r.MediaType == mediaType {
+			return r, nil
+		}
+
+		if name != "" {
+			return nil, fmt.Errorf("recording %s has media type %s, not %s",
+				name, r.MediaType, mediaType)
+		}
+	}
+
+	if name != "" {
+		return nil, fmt.Errorf("no recording %s", name)
+	}
+
+	return nil, fmt.Errorf(
+		"no supported format in replay plugin matches the requested format")
+}
+
+// NewPlugin returns a replay attester serving the given recordings. The
+// supported formats are the media types of the recordings.
+func NewPlugin(recordings []*Recording) (*Plugin, error) {
+	p := &Plugin{recordings: recordings}
+
+	nonceSizes := make(map[string]uint32)
+	for _, r := range recordings {
+		size, ok := nonceSizes[r.MediaType]
+		if !ok {
+			nonceSizes[r.MediaType] = r.NonceSize
+			p.formats = append(p.formats, &compositor.Format{
+				ContentType: r.MediaType,
+				NonceSize:   r.NonceSize,
+			})
+			continue
+		}
+
+		if size != r.NonceSize {
+			return nil, fmt.Errorf(
+				"recording %s: nonce size %d differs from %d for media type %s",
+				r.Name, r.NonceSize, size, r.MediaType)
+		}
+	}
+
+	return p, nil
+}
+
+// GetPlugin returns a replay attester serving the recordings of the
+// directory given in the environment
+func GetPlugin() (*Plugin, error) {
+	dir, ok := os.LookupEnv(EnvDir)
+	if !ok || dir == "" {
+		return nil, fmt.Errorf("%s is not set", EnvDir)
+	}
+
+	recordings, err := LoadRecordings(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlugin(recordings)
+}
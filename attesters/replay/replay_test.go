@@ -0,0 +1,233 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package replay
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/attesters/mockeat"
+	"github.com/veraison/ratsd/proto/compositor"
+	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// testNonce is the nonce sent to ratsd when the recordings in testdata were
+// made
+const testNonce = "TUlEQk5IMjhpaW9pc2pQeXh4eHh4eHh4eHh4eHh4eHhNSURCTkgyOGlpb2lzalB5eHh4eHh4eHh4eHh4eHh4eA"
+
+func adjustedTestNonce(t *testing.T, size uint32) []byte {
+	t.Helper()
+
+	nonce, err := base64.RawURLEncoding.DecodeString(testNonce)
+	require.NoError(t, err)
+
+	adjusted, err := ratsdtoken.AdjustNonce(nonce, size, ratsdtoken.NonceAdjustFunctionShake256)
+	require.NoError(t, err)
+
+	return adjusted
+}
+
+func testPlugin(t *testing.T) *Plugin {
+	t.Helper()
+
+	recordings, err := LoadRecordings("testdata")
+	require.NoError(t, err)
+
+	p, err := NewPlugin(recordings)
+	require.NoError(t, err)
+
+	return p
+}
+
+func writeRecording(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+recordingExt), []byte(content), 0o600))
+}
+
+func Test_LoadRecordings(t *testing.T) {
+	recordings, err := LoadRecordings("testdata")
+	require.NoError(t, err)
+	require.Len(t, recordings, 2)
+
+	assert.Equal(t, "mock-eat", recordings[0].Name)
+	assert.Equal(t, tokens.MockEATMediaType, recordings[0].MediaType)
+	assert.Equal(t, uint32(32), recordings[0].NonceSize)
+
+	assert.Equal(t, "sev-guest", recordings[1].Name)
+	assert.Equal(t, tokens.TSMReportMediaTypeJSON, recordings[1].MediaType)
+	assert.Equal(t, uint32(64), recordings[1].NonceSize)
+}
+
+func Test_LoadRecordings_Fail(t *testing.T) {
+	tests := []struct{ name, content, msg string }{
+		{"invalid json", `{`, "unexpected EOF"},
+		{"unknown field", `{"media-type": "a/b", "evidence": "AA==", "other": 1}`,
+			`json: unknown field "other"`},
+		{"no media type", `{"evidence": "AA=="}`, "missing media-type"},
+		{"no evidence", `{"media-type": "a/b"}`, "missing evidence"},
+		{"nonce size mismatch", `{"media-type": "a/b", "evidence": "AA==", "nonce": "AAAA", "nonce-size": 8}`,
+			"nonce size 3 does not match nonce-size 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeRecording(t, dir, "case", tt.content)
+
+			_, err := LoadRecordings(dir)
+			assert.ErrorContains(t, err, tt.msg)
+		})
+	}
+
+	_, err := LoadRecordings(t.TempDir())
+	assert.ErrorContains(t, err, "no recordings in")
+}
+
+func Test_NewPlugin_Fail(t *testing.T) {
+	_, err := NewPlugin([]*Recording{
+		{Name: "a", MediaType: "a/b", NonceSize: 32},
+		{Name: "b", MediaType: "a/b", NonceSize: 64},
+	})
+	assert.EqualError(t, err, "recording b: nonce size 64 differs from 32 for media type a/b")
+}
+
+func Test_GetPlugin(t *testing.T) {
+	t.Setenv(EnvDir, "")
+	_, err := GetPlugin()
+	assert.EqualError(t, err, "RATSD_REPLAY_DIR is not set")
+
+	t.Setenv(EnvDir, "testdata")
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Len(t, p.recordings, 2)
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status: statusSucceeded,
+		Formats: []*compositor.Format{
+			&compositor.Format{ContentType: tokens.MockEATMediaType, NonceSize: 32},
+			&compositor.Format{ContentType: tokens.TSMReportMediaTypeJSON, NonceSize: 64},
+		},
+	}
+
+	assert.Equal(t, expected, testPlugin(t).GetSupportedFormats())
+}
+
+func Test_GetEvidence(t *testing.T) {
+	p := testPlugin(t)
+
+	tests := []struct {
+		name, options string
+		nonce         []byte
+	}{
+		{"verbatim", "", make([]byte, 32)},
+		{"check nonce", `{"mode": "check-nonce"}`, adjustedTestNonce(t, 32)},
+		{"by name", `{"recording": "mock-eat", "mode": "check-nonce"}`, adjustedTestNonce(t, 32)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := p.GetEvidence(&compositor.EvidenceIn{
+				ContentType: tokens.MockEATMediaType,
+				Nonce:       tt.nonce,
+				Options:     []byte(tt.options),
+			})
+			require.True(t, out.Status.Result, out.Status.Error)
+			assert.Equal(t, p.recordings[0].Evidence, out.Evidence)
+		})
+	}
+}
+
+// The recorded evidence can still be appraised, e.g., the mock-eat evidence
+// against the trust anchor of the mock-eat attester
+func Test_GetEvidence_Verifiable(t *testing.T) {
+	nonce := adjustedTestNonce(t, 32)
+	out := testPlugin(t).GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.MockEATMediaType,
+		Nonce:       nonce,
+		Options:     []byte(`{"mode": "check-nonce"}`),
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	eat, err := tokens.ParseEAT(out.Evidence)
+	require.NoError(t, err)
+	assert.Equal(t, nonce, eat.Claims.Nonce)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(mockeat.TrustAnchor())
+	assert.NoError(t, eat.Verify(roots, time.Time{}))
+}
+
+func Test_GetEvidence_Fail(t *testing.T) {
+	p := testPlugin(t)
+
+	tests := []struct {
+		name, contentType, options string
+		nonce                      []byte
+		msg                        string
+		statusCode                 uint32
+	}{
+		{"invalid json", tokens.MockEATMediaType, `{"mode"}`, make([]byte, 32),
+			`failed to parse {"mode"}: invalid character '}' after object key`, http.StatusBadRequest},
+		{"invalid mode", tokens.MockEATMediaType, `{"mode": "other"}`, make([]byte, 32),
+			"mode other is invalid", http.StatusBadRequest},
+		{"unknown format", "application/json", "", make([]byte, 32),
+			"no supported format in replay plugin matches the requested format", http.StatusBadRequest},
+		{"unknown recording", tokens.MockEATMediaType, `{"recording": "other"}`, make([]byte, 32),
+			"no recording other", http.StatusBadRequest},
+		{"recording of other format", tokens.MockEATMediaType, `{"recording": "sev-guest"}`, make([]byte, 32),
+			"recording sev-guest has media type " + tokens.TSMReportMediaTypeJSON + ", not " + tokens.MockEATMediaType,
+			http.StatusBadRequest},
+		{"wrong nonce size", tokens.MockEATMediaType, "", make([]byte, 64),
+			"nonce size of recording mock-eat should be 32, got 64", http.StatusBadRequest},
+		{"nonce mismatch", tokens.MockEATMediaType, `{"mode": "check-nonce"}`, make([]byte, 32),
+			"nonce does not match recording mock-eat", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := &compositor.EvidenceOut{
+				Status: &compositor.Status{
+					Result: false,
+					Error:  tt.msg,
+				},
+				StatusCode: tt.statusCode,
+			}
+
+			assert.Equal(t, expected, p.GetEvidence(&compositor.EvidenceIn{
+				ContentType: tt.contentType,
+				Nonce:       tt.nonce,
+				Options:     []byte(tt.options),
+			}))
+		})
+	}
+}
+
+func Test_GetEvidence_No_Recorded_Nonce(t *testing.T) {
+	p, err := NewPlugin([]*Recording{
+		{Name: "raw", MediaType: "application/octet-stream", Evidence: []byte("raw"), NonceSize: 8},
+	})
+	require.NoError(t, err)
+
+	in := &compositor.EvidenceIn{
+		ContentType: "application/octet-stream",
+		Nonce:       make([]byte, 8),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result)
+	assert.Equal(t, []byte("raw"), out.Evidence)
+
+	in.Options = []byte(`{"mode": "check-nonce"}`)
+	out = p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "recording raw has no nonce", out.Status.Error)
+}
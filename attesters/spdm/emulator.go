@@ -0,0 +1,317 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package spdm
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"embed"
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/veraison/ratsd/attesters/internal/fixture"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// SPDM error codes returned by the emulator
+const (
+	errInvalidRequest     = 0x01
+	errUnexpectedRequest  = 0x04
+	errUnsupportedRequest = 0x07
+)
+
+// States of the connection of the emulator
+const (
+	stateNotStarted = iota
+	stateVersion
+	stateCapabilities
+	stateNegotiated
+)
+
+// emulatorSignatureSize is the size of the raw r || s ECDSA P-384 signature
+// of the emulator
+const emulatorSignatureSize = 96
+
+// The fixtures are a test root CA, and the device key and certificate issued
+// by it. They must never be trusted outside of tests.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// emulatorMeasurements are the DMTF measurements of the emulated devices:
+// digests of their ROM and firmware, and the raw version of their firmware
+var emulatorMeasurements = []tokens.SPDMMeasurement{
+	{Index: 1, Type: 0x00, Value: sha384("rom")},
+	{Index: 2, Type: 0x01, Value: sha384("firmware")},
+	{Index: 3, Type: 0x86, Value: []byte("1.0.0")},
+}
+
+// EmulatorBackend is a stand-in for the SPDM devices of the machine, for
+// running the attester and ratsd without SPDM hardware. Its devices are
+// Emulators.
+type EmulatorBackend struct {
+	devices []Device
+}
+
+// NewEmulatorBackend returns a backend emulating one device per name in
+// names
+func NewEmulatorBackend(names []string) (*EmulatorBackend, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no device to emulate")
+	}
+
+	b := &EmulatorBackend{}
+	for _, name := range names {
+		if name == "" {
+			return nil, errors.New("empty device name")
+		}
+
+		e, err := NewEmulator()
+		if err != nil {
+			return nil, err
+		}
+
+		b.devices = append(b.devices, Device{Name: name, Transport: e})
+	}
+
+	return b, nil
+}
+
+func (b *EmulatorBackend) Devices() ([]Device, error) {
+	return b.devices, nil
+}
+
+// Emulator is an SPDM 1.2 responder, negotiating ECDSA P-384 and SHA-384. Its
+// certificate chain is made of the test root CA and of the device
+// certificate of the fixtures, and it returns fixed measurements. It is a
+// Transport, reaching the emulated device in-process.
+type Emulator struct {
+	key   *ecdsa.PrivateKey
+	chain []byte
+
+	state int
+	// vca holds the version, capabilities and algorithms messages
+	vca []byte
+	// messages holds the measurement messages since the last signed
+	// MEASUREMENTS response
+	messages []byte
+}
+
+// NewEmulator returns an emulated SPDM device
+func NewEmulator() (*Emulator, error) {
+	key, err := x509.ParsePKCS8PrivateKey(fixture.MustReadPEM(fixtures, "device.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	root := fixture.MustReadPEM(fixtures, "root.crt")
+	rootHash := sha512.Sum384(root)
+
+	certs := slices.Concat(root, fixture.MustReadPEM(fixtures, "device.crt"))
+	chain := binary.LittleEndian.AppendUint16(nil, uint16(4+len(rootHash)+len(certs)))
+	chain = append(chain, 0, 0)
+	chain = append(chain, rootHash[:]...)
+	chain = append(chain, certs...)
+
+	return &Emulator{key: key.(*ecdsa.PrivateKey), chain: chain}, nil
+}
+
+// SendReceive handles an SPDM request, returning an ERROR response for
+// unsupported or out-of-order requests
+func (e *Emulator) SendReceive(req []byte) ([]byte, error) {
+	if len(req) < 4 {
+		return errorResponse(errInvalidRequest), nil
+	}
+
+	if req[1] == tokens.SPDMGetVersion {
+		return e.version(req), nil
+	}
+
+	if req[0] != tokens.SPDMVersion12 {
+		return errorResponse(errInvalidRequest), nil
+	}
+
+	switch req[1] {
+	case tokens.SPDMGetCapabilities:
+		return e.capabilities(req), nil
+	case tokens.SPDMNegotiateAlgorithms:
+		return e.algorithms(req), nil
+	case tokens.SPDMGetCertificate:
+		return e.certificate(req), nil
+	case tokens.SPDMGetMeasurements:
+		return e.measurements(req)
+	default:
+		return errorResponse(errUnsupportedRequest), nil
+	}
+}
+
+func (e *Emulator) version(req []byte) []byte {
+	if len(req) != 4 || req[0] != 0x10 {
+		return errorResponse(errInvalidRequest)
+	}
+
+	// GET_VERSION resets the connection
+	resp := []byte{0x10, tokens.SPDMVersion, 0, 0, 0, 1, 0x00, tokens.SPDMVersion12}
+	e.vca = append(append([]byte{}, req...), resp...)
+	e.state = stateVersion
+	e.messages = nil
+
+	return resp
+}
+
+func (e *Emulator) capabilities(req []byte) []byte {
+	if e.state != stateVersion {
+		return errorResponse(errUnexpectedRequest)
+	}
+
+	if len(req) != 20 {
+		return errorResponse(errInvalidRequest)
+	}
+
+	resp := []byte{tokens.SPDMVersion12, tokens.SPDMCapabilities, 0, 0, 0, 0, 0, 0}
+	resp = binary.LittleEndian.AppendUint32(resp, capCert|capMeasSig)
+	resp = binary.LittleEndian.AppendUint32(resp, requesterMessageSize)
+	resp = binary.LittleEndian.AppendUint32(resp, requesterMessageSize)
+	e.vca = append(append(e.vca, req...), resp...)
+	e.state = stateCapabilities
+
+	return resp
+}
+
+func (e *Emulator) algorithms(req []byte) []byte {
+	if e.state != stateCapabilities {
+		return errorResponse(errUnexpectedRequest)
+	}
+
+	if len(req) < 32 || int(binary.LittleEndian.Uint16(req[4:])) != len(req) {
+		return errorResponse(errInvalidRequest)
+	}
+
+	if req[6]&tokens.SPDMMeasurementSpecDMTF == 0 ||
+		binary.LittleEndian.Uint32(req[8:])&tokens.SPDMAsymECDSAP384 == 0 ||
+		binary.LittleEndian.Uint32(req[12:])&tokens.SPDMHashSHA384 == 0 {
+		return errorResponse(errInvalidRequest)
+	}
+
+	resp := []byte{tokens.SPDMVersion12, tokens.SPDMAlgorithms, 0, 0, 36, 0, tokens.SPDMMeasurementSpecDMTF, 0}
+	resp = binary.LittleEndian.AppendUint32(resp, tokens.SPDMMeasurementHashSHA384)
+	resp = binary.LittleEndian.AppendUint32(resp, tokens.SPDMAsymECDSAP384)
+	resp = binary.LittleEndian.AppendUint32(resp, tokens.SPDMHashSHA384)
+	resp = append(resp, make([]byte, 16)...)
+	e.vca = append(append(e.vca, req...), resp...)
+	e.state = stateNegotiated
+
+	return resp
+}
+
+func (e *Emulator) certificate(req []byte) []byte {
+	if e.state != stateNegotiated {
+		return errorResponse(errUnexpectedRequest)
+	}
+
+	if len(req) != 8 || req[2]&0x0f != 0 {
+		return errorResponse(errInvalidRequest)
+	}
+
+	offset := int(binary.LittleEndian.Uint16(req[4:]))
+	if offset >= len(e.chain) {
+		return errorResponse(errInvalidRequest)
+	}
+
+	portion := min(int(binary.LittleEndian.Uint16(req[6:])), len(e.chain)-offset)
+
+	resp := []byte{tokens.SPDMVersion12, tokens.SPDMCertificate, 0, 0}
+	resp = binary.LittleEndian.AppendUint16(resp, uint16(portion))
+	resp = binary.LittleEndian.AppendUint16(resp, uint16(len(e.chain)-offset-portion))
+	return append(resp, e.chain[offset:offset+portion]...)
+}
+
+func (e *Emulator) measurements(req []byte) ([]byte, error) {
+	if e.state != stateNegotiated {
+		return errorResponse(errUnexpectedRequest), nil
+	}
+
+	signed := req[2]&1 != 0
+	if (signed && (len(req) != 4+tokens.SPDMNonceSize+1 || req[len(req)-1] != 0)) ||
+		(!signed && len(req) != 4) {
+		return errorResponse(errInvalidRequest), nil
+	}
+
+	var blocks []tokens.SPDMMeasurement
+	param1 := byte(0)
+	switch op := req[3]; op {
+	case 0:
+		param1 = byte(len(emulatorMeasurements))
+	case measurementsAll:
+		blocks = emulatorMeasurements
+	default:
+		for _, m := range emulatorMeasurements {
+			if m.Index == op {
+				blocks = append(blocks, m)
+			}
+		}
+		if len(blocks) == 0 {
+			return errorResponse(errInvalidRequest), nil
+		}
+	}
+
+	var record []byte
+	for _, m := range blocks {
+		record = append(record, m.Index, tokens.SPDMMeasurementSpecDMTF)
+		record = binary.LittleEndian.AppendUint16(record, uint16(3+len(m.Value)))
+		record = append(record, m.Type)
+		record = binary.LittleEndian.AppendUint16(record, uint16(len(m.Value)))
+		record = append(record, m.Value...)
+	}
+
+	nonce := make([]byte, tokens.SPDMNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	resp := []byte{tokens.SPDMVersion12, tokens.SPDMMeasurements, param1, 0, byte(len(blocks))}
+	resp = append(resp, byte(len(record)), byte(len(record)>>8), byte(len(record)>>16))
+	resp = append(resp, record...)
+	resp = append(resp, nonce...)
+	// no opaque data
+	resp = append(resp, 0, 0)
+
+	e.messages = append(append(e.messages, req...), resp...)
+	if !signed {
+		return resp, nil
+	}
+
+	transcript := append(append([]byte{}, e.vca...), e.messages...)
+	e.messages = nil
+
+	digest := tokens.SPDMMeasurementsDigest(crypto.SHA384, transcript)
+	r, s, err := ecdsa.Sign(rand.Reader, e.key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, emulatorSignatureSize)
+	r.FillBytes(sig[:emulatorSignatureSize/2])
+	s.FillBytes(sig[emulatorSignatureSize/2:])
+
+	return append(resp, sig...), nil
+}
+
+func errorResponse(code byte) []byte {
+	return []byte{tokens.SPDMVersion12, tokens.SPDMError, code, 0}
+}
+
+// TrustAnchor returns the root certificate of the test CA that issued the
+// certificate of the emulated devices
+func TrustAnchor() *x509.Certificate {
+	return fixture.TrustAnchor(fixtures)
+}
+
+func sha384(s string) []byte {
+	digest := sha512.Sum384([]byte(s))
+	return digest[:]
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package spdm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	// requesterAsymAlgos are the signature algorithms offered to devices
+	requesterAsymAlgos = tokens.SPDMAsymECDSAP256 | tokens.SPDMAsymECDSAP384 | tokens.SPDMAsymECDSAP521 |
+		tokens.SPDMAsymRSASSA2048 | tokens.SPDMAsymRSASSA3072 | tokens.SPDMAsymRSASSA4096 |
+		tokens.SPDMAsymRSAPSS2048 | tokens.SPDMAsymRSAPSS3072 | tokens.SPDMAsymRSAPSS4096
+	// requesterHashAlgos are the hash algorithms offered to devices
+	requesterHashAlgos = tokens.SPDMHashSHA256 | tokens.SPDMHashSHA384 | tokens.SPDMHashSHA512
+
+	// requesterMessageSize is the DataTransferSize and MaxSPDMmsgSize of the
+	// requester
+	requesterMessageSize = 4096
+	// certificatePortion is the size of the portions of the certificate
+	// chain requested
+	certificatePortion = 1024
+
+	// capCert and capMeasSig are the CERT_CAP and the MEAS_CAP with signature
+	// flags of the CAPABILITIES response
+	capCert    = 1 << 1
+	capMeasSig = 2 << 3
+	capMeas    = 3 << 3
+
+	// measurementsAll is the GET_MEASUREMENTS operation requesting all the
+	// measurement blocks
+	measurementsAll = 0xff
+)
+
+// requester runs an SPDM 1.2 exchange with a device, recording the L1/L2
+// transcript
+type requester struct {
+	t          Transport
+	transcript []byte
+}
+
+// attest negotiates SPDM 1.2 with the device, then gets its certificate chain
+// of slot 0, and all its measurements signed over nonce
+func attest(t Transport, nonce []byte) (*tokens.SPDMDevice, error) {
+	r := &requester{t: t}
+
+	if err := r.negotiate(); err != nil {
+		return nil, err
+	}
+
+	chain, err := r.certificateChain()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := r.measurements(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &tokens.SPDMDevice{
+		CertificateChain: chain,
+		Transcript:       r.transcript,
+		Signature:        sig,
+	}
+
+	tr, err := d.ParseTranscript()
+	if err != nil {
+		return nil, err
+	}
+	d.Measurements = tr.Measurements
+
+	return d, nil
+}
+
+// exchange sends req and returns the response, which must have the given
+// code and be at least minSize long
+func (r *requester) exchange(name string, req []byte, code byte, minSize int) ([]byte, error) {
+	resp, err := r.t.SendReceive(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if len(resp) >= 4 && resp[1] == tokens.SPDMError {
+		return nil, fmt.Errorf("%s: SPDM error %#x", name, resp[2])
+	}
+
+	if len(resp) < minSize || resp[1] != code {
+		return nil, fmt.Errorf("%s: malformed response", name)
+	}
+
+	return resp, nil
+}
+
+func (r *requester) negotiate() error {
+	req := []byte{0x10, tokens.SPDMGetVersion, 0, 0}
+	resp, err := r.exchange("GET_VERSION", req, tokens.SPDMVersion, 6)
+	if err != nil {
+		return err
+	}
+
+	entries := resp[6:]
+	if len(entries) < 2*int(resp[5]) {
+		return errors.New("GET_VERSION: malformed response")
+	}
+
+	supported := false
+	for i := 0; i < int(resp[5]); i++ {
+		if entries[2*i+1] == tokens.SPDMVersion12 {
+			supported = true
+		}
+	}
+	if !supported {
+		return errors.New("SPDM 1.2 is not supported by the device")
+	}
+	r.transcript = append(r.transcript, req...)
+	r.transcript = append(r.transcript, resp[:6+2*int(resp[5])]...)
+
+	req = []byte{tokens.SPDMVersion12, tokens.SPDMGetCapabilities, 0, 0, 0, 0, 0, 0}
+	req = binary.LittleEndian.AppendUint32(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, requesterMessageSize)
+	req = binary.LittleEndian.AppendUint32(req, requesterMessageSize)
+	resp, err = r.exchange("GET_CAPABILITIES", req, tokens.SPDMCapabilities, 20)
+	if err != nil {
+		return err
+	}
+
+	flags := binary.LittleEndian.Uint32(resp[8:])
+	if flags&capCert == 0 || flags&capMeas != capMeasSig {
+		return errors.New("signed measurements are not supported by the device")
+	}
+	r.transcript = append(r.transcript, req...)
+	r.transcript = append(r.transcript, resp[:20]...)
+
+	req = []byte{tokens.SPDMVersion12, tokens.SPDMNegotiateAlgorithms, 0, 0, 32, 0, tokens.SPDMMeasurementSpecDMTF, 0}
+	req = binary.LittleEndian.AppendUint32(req, requesterAsymAlgos)
+	req = binary.LittleEndian.AppendUint32(req, requesterHashAlgos)
+	req = append(req, make([]byte, 16)...)
+	resp, err = r.exchange("NEGOTIATE_ALGORITHMS", req, tokens.SPDMAlgorithms, 36)
+	if err != nil {
+		return err
+	}
+
+	if int(binary.LittleEndian.Uint16(resp[4:])) != len(resp) || resp[6] != tokens.SPDMMeasurementSpecDMTF {
+		return errors.New("NEGOTIATE_ALGORITHMS: malformed response")
+	}
+
+	asym := binary.LittleEndian.Uint32(resp[12:])
+	hash := binary.LittleEndian.Uint32(resp[16:])
+	if !isAlgorithm(asym, requesterAsymAlgos) || !isAlgorithm(hash, requesterHashAlgos) {
+		return errors.New("NEGOTIATE_ALGORITHMS: no algorithm selected among those offered")
+	}
+	r.transcript = append(r.transcript, req...)
+	r.transcript = append(r.transcript, resp...)
+
+	return nil
+}
+
+// isAlgorithm reports whether sel is one of the algorithms of offered
+func isAlgorithm(sel, offered uint32) bool {
+	return sel != 0 && sel&(sel-1) == 0 && sel&offered != 0
+}
+
+// certificateChain reads the certificate chain of slot 0 by portions. The
+// messages are not part of the L1/L2 transcript.
+func (r *requester) certificateChain() ([]byte, error) {
+	var chain []byte
+
+	for {
+		req := []byte{tokens.SPDMVersion12, tokens.SPDMGetCertificate, 0, 0}
+		req = binary.LittleEndian.AppendUint16(req, uint16(len(chain)))
+		req = binary.LittleEndian.AppendUint16(req, certificatePortion)
+		resp, err := r.exchange("GET_CERTIFICATE", req, tokens.SPDMCertificate, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		portion := int(binary.LittleEndian.Uint16(resp[4:]))
+		remainder := int(binary.LittleEndian.Uint16(resp[6:]))
+		if portion == 0 || len(resp) != 8+portion || len(chain)+portion+remainder > 0xffff {
+			return nil, errors.New("GET_CERTIFICATE: malformed response")
+		}
+
+		chain = append(chain, resp[8:]...)
+		if remainder == 0 {
+			return chain, nil
+		}
+	}
+}
+
+// measurements gets all the measurement blocks, signed over nonce, and
+// returns the signature
+func (r *requester) measurements(nonce []byte) ([]byte, error) {
+	req := []byte{tokens.SPDMVersion12, tokens.SPDMGetMeasurements, 1, measurementsAll}
+	req = append(req, nonce...)
+	req = append(req, 0)
+	resp, err := r.exchange("GET_MEASUREMENTS", req, tokens.SPDMMeasurements, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	// the signature follows the record, the responder nonce and the opaque
+	// data
+	size := 8 + (int(resp[5]) | int(resp[6])<<8 | int(resp[7])<<16) + tokens.SPDMNonceSize + 2
+	if len(resp) < size {
+		return nil, errors.New("GET_MEASUREMENTS: malformed response")
+	}
+
+	size += int(binary.LittleEndian.Uint16(resp[size-2:]))
+	if len(resp) <= size {
+		return nil, errors.New("GET_MEASUREMENTS: unsigned response")
+	}
+
+	r.transcript = append(r.transcript, req...)
+	r.transcript = append(r.transcript, resp[:size]...)
+
+	return bytes.Clone(resp[size:]), nil
+}
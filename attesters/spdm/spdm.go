@@ -0,0 +1,223 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spdm implements a sub-attester collecting the signed SPDM
+// measurements of the devices attached to the machine, e.g., NICs or
+// accelerators, which it reaches through a Backend.
+package spdm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const nonceSize = tokens.SPDMNonceSize
+
+// Environment variables holding the configuration of the SPDM attester
+const (
+	// EnvBackend selects the backend, BackendEmulator or none if unset
+	EnvBackend = "RATSD_SPDM_BACKEND"
+	// EnvEmulatorDevices is the comma-separated list of the names of the
+	// devices emulated by the emulator backend
+	EnvEmulatorDevices = "RATSD_SPDM_EMULATOR_DEVICES"
+)
+
+// BackendEmulator is the name of the stand-in backend, see EmulatorBackend
+const BackendEmulator = "emulator"
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "spdm",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.SPDMEvidenceMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.SPDMEvidenceMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	errNoBackend = errors.New("no backend configured")
+	errNoDevice  = errors.New("no SPDM device found")
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Transport carries SPDM messages to a device, e.g., over PCIe DOE or MCTP
+type Transport interface {
+	// SendReceive sends an SPDM request message to the device, and returns
+	// its response message
+	SendReceive(request []byte) ([]byte, error)
+}
+
+// Device is an SPDM responder
+type Device struct {
+	// Name identifies the device on the machine, e.g., its PCI address
+	Name      string
+	Transport Transport
+}
+
+// Backend gives access to the SPDM responders of the machine
+type Backend interface {
+	// Devices returns the SPDM responders
+	Devices() ([]Device, error)
+}
+
+type SPDMPlugin struct {
+	backend Backend
+}
+
+// NewPlugin returns an SPDM attester using the given backend. A nil backend
+// makes the attester unavailable.
+func NewPlugin(backend Backend) *SPDMPlugin {
+	return &SPDMPlugin{backend: backend}
+}
+
+func (p *SPDMPlugin) devices() ([]Device, error) {
+	if p.backend == nil {
+		return nil, errNoBackend
+	}
+
+	devices, err := p.backend.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, errNoDevice
+	}
+
+	return devices, nil
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *SPDMPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (p *SPDMPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *SPDMPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if _, err := p.devices(); err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("SPDM device is not available: %s", err.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+func (p *SPDMPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the SPDM attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in spdm plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	devices, err := p.devices()
+	if err != nil {
+		errMsg := fmt.Errorf("SPDM device is not available: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	out := &tokens.SPDMEvidence{Nonce: in.Nonce}
+	for _, d := range devices {
+		device, err := attest(d.Transport, in.Nonce)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to get the measurements of SPDM device %s: %v", d.Name, err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+
+		device.Name = d.Name
+		out.Devices = append(out.Devices, *device)
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.SPDMEvidenceMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode SPDM evidence as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// GetPlugin returns the SPDM attester with the backend selected by the
+// environment
+func GetPlugin() (*SPDMPlugin, error) {
+	switch backend := os.Getenv(EnvBackend); backend {
+	case "":
+		return NewPlugin(nil), nil
+	case BackendEmulator:
+		names := []string{"emulator"}
+		if v, ok := os.LookupEnv(EnvEmulatorDevices); ok {
+			names = strings.Split(v, ",")
+			for i := range names {
+				names[i] = strings.TrimSpace(names[i])
+			}
+		}
+
+		b, err := NewEmulatorBackend(names)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvEmulatorDevices, err)
+		}
+		return NewPlugin(b), nil
+	default:
+		return nil, fmt.Errorf("%s: backend %s is not supported", EnvBackend, backend)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package spdm
+
+import (
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+
+// fakeBackend returns the devices of its fields, or fails
+type fakeBackend struct {
+	devices    []Device
+	devicesErr error
+}
+
+func (b *fakeBackend) Devices() ([]Device, error) {
+	return b.devices, b.devicesErr
+}
+
+// fakeTransport alters the responses of an Emulator
+type fakeTransport struct {
+	e      *Emulator
+	err    error
+	update func(req, resp []byte) []byte
+}
+
+func (t *fakeTransport) SendReceive(req []byte) ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	resp, err := t.e.SendReceive(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.update(req, resp), nil
+}
+
+func newEmulatorPlugin(t *testing.T, names ...string) *SPDMPlugin {
+	b, err := NewEmulatorBackend(names)
+	require.NoError(t, err)
+	return NewPlugin(b)
+}
+
+func newEmulator(t *testing.T) *Emulator {
+	e, err := NewEmulator()
+	require.NoError(t, err)
+	return e
+}
+
+func getSPDMEvidence(t *testing.T, p *SPDMPlugin, contentType string) *tokens.SPDMEvidence {
+	in := &compositor.EvidenceIn{
+		ContentType: contentType,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	e := &tokens.SPDMEvidence{}
+	if contentType == tokens.SPDMEvidenceMediaTypeCBOR {
+		require.NoError(t, e.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, e.FromJSON(out.Evidence))
+	}
+
+	return e
+}
+
+func getEvidenceFailure(t *testing.T, p *SPDMPlugin) *compositor.EvidenceOut {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.SPDMEvidenceMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+
+	return out
+}
+
+// verifyEmulatorDevice checks the measurements of an emulated device
+func verifyEmulatorDevice(t *testing.T, d tokens.SPDMDevice) {
+	require.NoError(t, d.Verify([]*x509.Certificate{TrustAnchor()}, time.Time{}))
+
+	tr, err := d.ParseTranscript()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(validNonceStr), tr.Nonce)
+	assert.Equal(t, uint32(tokens.SPDMAsymECDSAP384), tr.AsymAlgo)
+	assert.Equal(t, uint32(tokens.SPDMHashSHA384), tr.HashAlgo)
+	assert.Equal(t, emulatorMeasurements, d.Measurements)
+}
+
+func Test_GetPlugin(t *testing.T) {
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(nil), p)
+
+	t.Setenv(EnvBackend, BackendEmulator)
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, newEmulatorPlugin(t, "emulator"), p)
+
+	t.Setenv(EnvEmulatorDevices, "0000:3b:00.0, 0000:af:00.0")
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, newEmulatorPlugin(t, "0000:3b:00.0", "0000:af:00.0"), p)
+}
+
+func Test_GetPlugin_Fail(t *testing.T) {
+	t.Setenv(EnvBackend, "doe")
+	_, err := GetPlugin()
+	assert.EqualError(t, err, "RATSD_SPDM_BACKEND: backend doe is not supported")
+
+	t.Setenv(EnvBackend, BackendEmulator)
+	t.Setenv(EnvEmulatorDevices, "0000:3b:00.0,")
+	_, err = GetPlugin()
+	assert.EqualError(t, err, "RATSD_SPDM_EMULATOR_DEVICES: empty device name")
+
+	_, err = NewEmulatorBackend(nil)
+	assert.EqualError(t, err, "no device to emulate")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, newEmulatorPlugin(t, "emulator").GetSupportedFormats())
+}
+
+func Test_GetSupportedFormats_unavailable(t *testing.T) {
+	tvs := []struct {
+		backend  Backend
+		expected string
+	}{
+		{nil, "SPDM device is not available: no backend configured"},
+		{&fakeBackend{}, "SPDM device is not available: no SPDM device found"},
+		{&fakeBackend{devicesErr: errors.New("no DOE mailbox")}, "SPDM device is not available: no DOE mailbox"},
+	}
+
+	for _, tv := range tvs {
+		out := NewPlugin(tv.backend).GetSupportedFormats()
+		assert.False(t, out.Status.Result)
+		assert.Equal(t, tv.expected, out.Status.Error)
+		assert.Empty(t, out.Formats)
+	}
+}
+
+func Test_GetEvidence_JSON(t *testing.T) {
+	e := getSPDMEvidence(t, newEmulatorPlugin(t, "0000:3b:00.0", "0000:af:00.0"), tokens.SPDMEvidenceMediaTypeJSON)
+	assert.Equal(t, tokens.BinaryString(validNonceStr), e.Nonce)
+	require.Len(t, e.Devices, 2)
+	assert.Equal(t, "0000:3b:00.0", e.Devices[0].Name)
+	assert.Equal(t, "0000:af:00.0", e.Devices[1].Name)
+
+	for _, d := range e.Devices {
+		verifyEmulatorDevice(t, d)
+	}
+}
+
+func Test_GetEvidence_CBOR(t *testing.T) {
+	p := newEmulatorPlugin(t, "emulator")
+
+	// the emulators can be attested repeatedly
+	for range 2 {
+		e := getSPDMEvidence(t, p, tokens.SPDMEvidenceMediaTypeCBOR)
+		require.Len(t, e.Devices, 1)
+		verifyEmulatorDevice(t, e.Devices[0])
+	}
+}
+
+func Test_GetEvidence_BadRequest(t *testing.T) {
+	p := newEmulatorPlugin(t, "emulator")
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.SPDMEvidenceMediaTypeJSON,
+		Nonce:       []byte("nonce"),
+	})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the SPDM attester should be 32, got 5", out.Status.Error)
+
+	out = p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.NvidiaGPUEvidenceMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in spdm plugin matches the requested format", out.Status.Error)
+}
+
+func Test_GetEvidence_unavailable(t *testing.T) {
+	out := getEvidenceFailure(t, NewPlugin(nil))
+	assert.Equal(t, "SPDM device is not available: no backend configured", out.Status.Error)
+}
+
+func Test_GetEvidence_DeviceFailure(t *testing.T) {
+	tvs := []struct {
+		transport *fakeTransport
+		expected  string
+	}{
+		{
+			&fakeTransport{err: errors.New("DOE busy")},
+			"GET_VERSION: DOE busy",
+		},
+		{
+			&fakeTransport{update: func(req, resp []byte) []byte {
+				if req[1] == tokens.SPDMGetVersion {
+					resp[7] = 0x11
+				}
+				return resp
+			}},
+			"SPDM 1.2 is not supported by the device",
+		},
+		{
+			&fakeTransport{update: func(req, resp []byte) []byte {
+				if req[1] == tokens.SPDMGetCapabilities {
+					resp[8] = capCert
+				}
+				return resp
+			}},
+			"signed measurements are not supported by the device",
+		},
+		{
+			&fakeTransport{update: func(req, resp []byte) []byte {
+				if req[1] == tokens.SPDMGetCertificate {
+					return errorResponse(errUnsupportedRequest)
+				}
+				return resp
+			}},
+			"GET_CERTIFICATE: SPDM error 0x7",
+		},
+		{
+			&fakeTransport{update: func(req, resp []byte) []byte {
+				if req[1] == tokens.SPDMGetMeasurements {
+					return resp[:len(resp)-emulatorSignatureSize]
+				}
+				return resp
+			}},
+			"GET_MEASUREMENTS: unsigned response",
+		},
+	}
+
+	for _, tv := range tvs {
+		tv.transport.e = newEmulator(t)
+		p := NewPlugin(&fakeBackend{devices: []Device{{Name: "nic0", Transport: tv.transport}}})
+
+		out := getEvidenceFailure(t, p)
+		assert.Equal(t, "failed to get the measurements of SPDM device nic0: "+tv.expected, out.Status.Error)
+	}
+}
+
+func Test_Emulator_Errors(t *testing.T) {
+	e := newEmulator(t)
+
+	// requests before the negotiation
+	resp, err := e.SendReceive([]byte{tokens.SPDMVersion12, tokens.SPDMGetMeasurements, 0, 0})
+	require.NoError(t, err)
+	assert.Equal(t, errorResponse(errUnexpectedRequest), resp)
+
+	resp, err = e.SendReceive([]byte{tokens.SPDMVersion12, 0x85, 0, 0})
+	require.NoError(t, err)
+	assert.Equal(t, errorResponse(errUnsupportedRequest), resp)
+
+	resp, err = e.SendReceive([]byte{0x10, tokens.SPDMGetVersion})
+	require.NoError(t, err)
+	assert.Equal(t, errorResponse(errInvalidRequest), resp)
+}
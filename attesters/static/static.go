@@ -0,0 +1,275 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package static implements a sub-attester returning static artifacts, e.g.,
+// endorsements or reference values provisioned on the machine, alongside the
+// evidence of the other sub-attesters. The artifacts are returned unchanged
+// and are not bound to the nonce: they are marked with a CMW indicator other
+// than evidence, and collected under a dedicated collection type.
+package static
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+// EnvConfig is the environment variable holding the path of the
+// configuration of the static artifacts, since ratsd passes no configuration
+// to its plugins
+const EnvConfig = "RATSD_STATIC_CONFIG"
+
+// CollectionType is the type of the CMW collection of the static artifacts
+const CollectionType = "tag:github.com,2026:veraison/ratsd/cmw/static-artifacts"
+
+// indicators maps the names of the CMW indicators allowed for static
+// artifacts to their value. Evidence is left out, as it must be bound to the
+// nonce of the request.
+var indicators = map[string]cmw.Indicator{
+	"reference-values":    cmw.ReferenceValues,
+	"endorsements":        cmw.Endorsements,
+	"attestation-results": cmw.AttestationResults,
+	"trust-anchors":       cmw.TrustAnchors,
+}
+
+// Artifact is a file returned unchanged by the static attester. The
+// configuration is a JSON array of artifacts.
+type Artifact struct {
+	// Name is the key of the artifact in the collection. It defaults to
+	// the base name of Path.
+	Name string `json:"name,omitempty"`
+	// Path is the path of the file, relative to the configuration file
+	// unless absolute
+	Path      string `json:"path"`
+	MediaType string `json:"media-type"`
+	// Indicators are the CMW indicators of the artifact, among
+	// reference-values, endorsements, attestation-results and
+	// trust-anchors
+	Indicators []string `json:"indicators"`
+
+	value     []byte
+	indicator cmw.Indicator
+}
+
+// LoadConfig loads the artifacts listed in the configuration file at path,
+// and reads their files
+func LoadConfig(path string) ([]*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []*Artifact
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&artifacts); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("no artifacts in %s", path)
+	}
+
+	names := make(map[string]bool)
+	for i, a := range artifacts {
+		if err := a.load(filepath.Dir(path)); err != nil {
+			return nil, fmt.Errorf("artifact %d: %w", i, err)
+		}
+
+		if names[a.Name] {
+			return nil, fmt.Errorf("artifact %d: duplicate name %s", i, a.Name)
+		}
+		names[a.Name] = true
+	}
+
+	return artifacts, nil
+}
+
+func (a *Artifact) load(dir string) error {
+	if a == nil {
+		return errors.New("null artifact")
+	}
+
+	if a.Path == "" {
+		return errors.New("missing path")
+	}
+
+	if a.MediaType == "" {
+		return errors.New("missing media-type")
+	}
+
+	if len(a.Indicators) == 0 {
+		return errors.New("missing indicators")
+	}
+
+	a.indicator = cmw.IndicatorNone
+	for _, name := range a.Indicators {
+		ind, ok := indicators[name]
+		if !ok {
+			if name == "evidence" {
+				return errors.New("static artifacts cannot be evidence, which must be bound to a nonce")
+			}
+			return fmt.Errorf("unknown indicator %s", name)
+		}
+		a.indicator |= ind
+	}
+
+	path := a.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(value) == 0 {
+		return fmt.Errorf("%s is empty", path)
+	}
+	a.value = value
+
+	if a.Name == "" {
+		a.Name = filepath.Base(a.Path)
+	}
+
+	return nil
+}
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "static",
+		Version: "1.0.0",
+	}
+
+	errNoArtifact = errors.New("no artifact configured")
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+type Plugin struct {
+	artifacts []*Artifact
+	formats   []*compositor.Format
+}
+
+// NewPlugin returns a static attester returning the given artifacts. The
+// supported formats are the media types of the artifacts, which are unbound.
+// Without artifacts, the attester is unavailable.
+func NewPlugin(artifacts []*Artifact) *Plugin {
+	p := &Plugin{artifacts: artifacts}
+
+	for _, a := range artifacts {
+		if !slices.ContainsFunc(p.formats, func(f *compositor.Format) bool {
+			return f.ContentType == a.MediaType
+		}) {
+			p.formats = append(p.formats, &compositor.Format{
+				ContentType: a.MediaType,
+				Unbound:     true,
+			})
+		}
+	}
+
+	return p
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (p *Plugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (p *Plugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	if len(p.artifacts) == 0 {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("static artifacts are not available: %s", errNoArtifact.Error()),
+			},
+		}
+	}
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: p.formats,
+	}
+}
+
+// GetEvidence returns all the artifacts as records, whatever the requested
+// format, which only has to be one of the supported formats
+func (p *Plugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if len(in.Nonce) != 0 {
+		errMsg := fmt.Errorf(
+			"nonce size of the static attester should be 0, got %d", len(in.Nonce))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if len(p.artifacts) == 0 {
+		errMsg := fmt.Errorf("static artifacts are not available: %v", errNoArtifact)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	if !slices.ContainsFunc(p.formats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in static plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	out := &compositor.EvidenceOut{
+		Status:         statusSucceeded,
+		StatusCode:     http.StatusOK,
+		CollectionType: CollectionType,
+	}
+
+	for _, a := range p.artifacts {
+		out.Records = append(out.Records, &compositor.Record{
+			Key:         a.Name,
+			ContentType: a.MediaType,
+			Value:       a.value,
+			Indicator:   uint32(a.indicator),
+		})
+	}
+
+	return out
+}
+
+// GetPlugin returns a static attester returning the artifacts of the
+// configuration given in the environment
+func GetPlugin() (*Plugin, error) {
+	path, ok := os.LookupEnv(EnvConfig)
+	if !ok || path == "" {
+		return NewPlugin(nil), nil
+	}
+
+	artifacts, err := LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EnvConfig, err)
+	}
+
+	return NewPlugin(artifacts), nil
+}
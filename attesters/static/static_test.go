@@ -0,0 +1,191 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package static
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/proto/compositor"
+)
+
+const testConfig = "testdata/artifacts.json"
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+// writeConfig writes a configuration next to a copy of corim.cbor
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corim.cbor"), readTestdata(t, "corim.cbor"), 0o644))
+
+	path := filepath.Join(dir, "artifacts.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+	return path
+}
+
+func newTestPlugin(t *testing.T) *Plugin {
+	artifacts, err := LoadConfig(testConfig)
+	require.NoError(t, err)
+	return NewPlugin(artifacts)
+}
+
+func Test_LoadConfig(t *testing.T) {
+	artifacts, err := LoadConfig(testConfig)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+
+	assert.Equal(t, "corim", artifacts[0].Name)
+	assert.Equal(t, readTestdata(t, "corim.cbor"), artifacts[0].value)
+	assert.Equal(t, cmw.Indicator(cmw.ReferenceValues|cmw.Endorsements), artifacts[0].indicator)
+
+	// the name defaults to that of the file
+	assert.Equal(t, "root.pem", artifacts[1].Name)
+	assert.Equal(t, readTestdata(t, "root.pem"), artifacts[1].value)
+	assert.Equal(t, cmw.Indicator(cmw.TrustAnchors), artifacts[1].indicator)
+}
+
+func Test_LoadConfig_Fail(t *testing.T) {
+	tvs := []struct {
+		config   string
+		expected string
+	}{
+		{`[]`, "no artifacts in "},
+		{`{}`, "failed to decode "},
+		{`[{"path": "corim.cbor", "media-type": "application/rim+cbor", "indicators": ["endorsements"], "nonce": ""}]`,
+			`unknown field "nonce"`},
+		{`[null]`, "artifact 0: null artifact"},
+		{`[{"media-type": "application/rim+cbor", "indicators": ["endorsements"]}]`, "artifact 0: missing path"},
+		{`[{"path": "corim.cbor", "indicators": ["endorsements"]}]`, "artifact 0: missing media-type"},
+		{`[{"path": "corim.cbor", "media-type": "application/rim+cbor"}]`, "artifact 0: missing indicators"},
+		{`[{"path": "corim.cbor", "media-type": "application/rim+cbor", "indicators": ["evidence"]}]`,
+			"artifact 0: static artifacts cannot be evidence, which must be bound to a nonce"},
+		{`[{"path": "corim.cbor", "media-type": "application/rim+cbor", "indicators": ["endorsement"]}]`,
+			"artifact 0: unknown indicator endorsement"},
+		{`[{"path": "comid.cbor", "media-type": "application/rim+cbor", "indicators": ["endorsements"]}]`,
+			"artifact 0: open "},
+		{`[{"path": "corim.cbor", "media-type": "application/rim+cbor", "indicators": ["endorsements"]},
+		   {"path": "corim.cbor", "media-type": "application/rim+cbor", "indicators": ["reference-values"]}]`,
+			"artifact 1: duplicate name corim.cbor"},
+	}
+
+	for _, tv := range tvs {
+		_, err := LoadConfig(writeConfig(t, tv.config))
+		assert.ErrorContains(t, err, tv.expected, tv.config)
+	}
+
+	empty := writeConfig(t, `[{"path": "empty", "media-type": "text/plain", "indicators": ["endorsements"]}]`)
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(empty), "empty"), nil, 0o644))
+	_, err := LoadConfig(empty)
+	assert.ErrorContains(t, err, "empty is empty")
+}
+
+func Test_GetPlugin(t *testing.T) {
+	p, err := GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, NewPlugin(nil), p)
+
+	t.Setenv(EnvConfig, testConfig)
+	p, err = GetPlugin()
+	require.NoError(t, err)
+	assert.Equal(t, newTestPlugin(t), p)
+
+	t.Setenv(EnvConfig, "testdata/none.json")
+	_, err = GetPlugin()
+	assert.ErrorContains(t, err, "RATSD_STATIC_CONFIG: open testdata/none.json: ")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(nil).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status: statusSucceeded,
+		Formats: []*compositor.Format{
+			{ContentType: "application/rim+cbor", Unbound: true},
+			{ContentType: "application/pem-certificate-chain", Unbound: true},
+		},
+	}
+
+	assert.Equal(t, expected, newTestPlugin(t).GetSupportedFormats())
+
+	out := NewPlugin(nil).GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, "static artifacts are not available: no artifact configured", out.Status.Error)
+	assert.Empty(t, out.Formats)
+}
+
+func Test_GetEvidence(t *testing.T) {
+	p := newTestPlugin(t)
+
+	for _, ct := range []string{"application/rim+cbor", "application/pem-certificate-chain"} {
+		out := p.GetEvidence(&compositor.EvidenceIn{ContentType: ct})
+		require.True(t, out.Status.Result, out.Status.Error)
+		assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+		assert.Empty(t, out.Evidence)
+		assert.Equal(t, CollectionType, out.CollectionType)
+		assert.Equal(t, []*compositor.Record{
+			{
+				Key:         "corim",
+				ContentType: "application/rim+cbor",
+				Value:       readTestdata(t, "corim.cbor"),
+				Indicator:   uint32(cmw.ReferenceValues | cmw.Endorsements),
+			},
+			{
+				Key:         "root.pem",
+				ContentType: "application/pem-certificate-chain",
+				Value:       readTestdata(t, "root.pem"),
+				Indicator:   uint32(cmw.TrustAnchors),
+			},
+		}, out.Records)
+	}
+}
+
+func Test_GetEvidence_Fail(t *testing.T) {
+	p := newTestPlugin(t)
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: "application/rim+cbor",
+		Nonce:       []byte("nonce"),
+	})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the static attester should be 0, got 5", out.Status.Error)
+
+	out = p.GetEvidence(&compositor.EvidenceIn{ContentType: "application/eat+cwt"})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in static plugin matches the requested format", out.Status.Error)
+
+	out = NewPlugin(nil).GetEvidence(&compositor.EvidenceIn{ContentType: "application/rim+cbor"})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "static artifacts are not available: no artifact configured", out.Status.Error)
+}
@@ -0,0 +1,494 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tpm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/linuxtpm"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	nonceSize = 32
+
+	// maxPCR is the highest PCR of the PC Client platform TPM profile
+	maxPCR = 23
+	// maxPCRRead is the number of PCR values returned by a single
+	// TPM2_PCR_Read
+	maxPCRRead = 8
+	// maxNVRead is the size of the chunks read from NV indices, which is
+	// below the TPM_PT_NV_BUFFER_MAX of all known TPMs
+	maxNVRead = 512
+	// maxQuoteAttempts is the number of times the PCRs are read and quoted
+	// before giving up, should they keep being extended in between
+	maxQuoteAttempts = 3
+
+	defaultDevice   = "/dev/tpmrm0"
+	defaultEventLog = "/sys/kernel/security/tpm0/binary_bios_measurements"
+	defaultHashAlg  = "sha256"
+)
+
+// Environment variables holding the configuration of the TPM attester
+const (
+	EnvDevice   = "RATSD_TPM_DEVICE"
+	EnvEventLog = "RATSD_TPM_EVENTLOG"
+	EnvAKCertNV = "RATSD_TPM_AK_CERT_NV"
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    "tpm",
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.TPMQuoteMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.TPMQuoteMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	// defaultPCRs are the PCRs of the SRTM measured by the platform firmware
+	defaultPCRs = []uint{0, 1, 2, 3, 4, 5, 6, 7}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// akTemplate is the template of the ECC P-256 restricted signing key that
+// signs the quotes. Being a primary key of the endorsement hierarchy, the AK
+// is the same every time it is created on a given TPM.
+var akTemplate = tpm2.TPMTPublic{
+	Type:    tpm2.TPMAlgECC,
+	NameAlg: tpm2.TPMAlgSHA256,
+	ObjectAttributes: tpm2.TPMAObject{
+		FixedTPM:            true,
+		FixedParent:         true,
+		SensitiveDataOrigin: true,
+		UserWithAuth:        true,
+		NoDA:                true,
+		Restricted:          true,
+		SignEncrypt:         true,
+	},
+	Parameters: tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+		Symmetric: tpm2.TPMTSymDefObject{Algorithm: tpm2.TPMAlgNull},
+		Scheme: tpm2.TPMTECCScheme{
+			Scheme: tpm2.TPMAlgECDSA,
+			Details: tpm2.NewTPMUAsymScheme(tpm2.TPMAlgECDSA, &tpm2.TPMSSigSchemeECDSA{
+				HashAlg: tpm2.TPMAlgSHA256,
+			}),
+		},
+		CurveID: tpm2.TPMECCNistP256,
+		KDF:     tpm2.TPMTKDFScheme{Scheme: tpm2.TPMAlgNull},
+	}),
+}
+
+// Config is the configuration of the TPM attester
+type Config struct {
+	// Device is the path of the TPM character device
+	Device string
+	// EventLog is the path of the measured-boot event log
+	EventLog string
+	// AKCertNV is the NV index holding the DER-encoded AK certificate, or 0
+	// if the AK is not certified
+	AKCertNV uint32
+}
+
+// DefaultConfig returns the configuration of the TPM attester on Linux
+func DefaultConfig() Config {
+	return Config{
+		Device:   defaultDevice,
+		EventLog: defaultEventLog,
+	}
+}
+
+// ConfigFromEnv returns the default configuration updated with the
+// environment variables that are set
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	if v, ok := os.LookupEnv(EnvDevice); ok {
+		cfg.Device = v
+	}
+
+	if v, ok := os.LookupEnv(EnvEventLog); ok {
+		cfg.EventLog = v
+	}
+
+	if v, ok := os.LookupEnv(EnvAKCertNV); ok {
+		index, err := strconv.ParseUint(v, 0, 32)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: NV index %s is invalid", EnvAKCertNV, v)
+		}
+		cfg.AKCertNV = uint32(index)
+	}
+
+	return cfg, nil
+}
+
+type TPMPlugin struct {
+	cfg Config
+	// open overrides the opening of the TPM device, for testing
+	open func() (transport.TPMCloser, error)
+}
+
+// NewPlugin returns a TPM attester using the given configuration
+func NewPlugin(cfg Config) *TPMPlugin {
+	return &TPMPlugin{cfg: cfg}
+}
+
+func (t *TPMPlugin) openTPM() (transport.TPMCloser, error) {
+	if t.open != nil {
+		return t.open()
+	}
+
+	return linuxtpm.Open(t.cfg.Device)
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (t *TPMPlugin) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "pcrs", Type: "string"},
+			&compositor.Option{Name: "hash_alg", Type: "string"},
+			&compositor.Option{Name: "event_log", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+}
+
+func (t *TPMPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (t *TPMPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	tpm, err := t.openTPM()
+	if err != nil {
+		return &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{
+				Result: false,
+				Error:  fmt.Sprintf("TPM is not available: %s", err.Error()),
+			},
+		}
+	}
+	tpm.Close() //nolint:errcheck
+
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+// quoteRequest holds the options of an evidence request
+type quoteRequest struct {
+	pcrs     []uint
+	hashAlg  string
+	eventLog bool
+}
+
+func parseOptions(in []byte) (*quoteRequest, error) {
+	req := &quoteRequest{
+		pcrs:    defaultPCRs,
+		hashAlg: defaultHashAlg,
+	}
+
+	options := make(map[string]string)
+	if len(in) > 0 {
+		if err := json.Unmarshal(in, &options); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", in, err)
+		}
+	}
+
+	if v, ok := options["pcrs"]; ok {
+		pcrs, err := parsePCRs(v)
+		if err != nil {
+			return nil, err
+		}
+		req.pcrs = pcrs
+	}
+
+	if v, ok := options["hash_alg"]; ok {
+		if _, ok := tokens.TPMHashAlgs[v]; !ok {
+			return nil, fmt.Errorf("hash_alg %s is not supported", v)
+		}
+		req.hashAlg = v
+	}
+
+	if v, ok := options["event_log"]; ok {
+		eventLog, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("event_log %s is not a boolean", v)
+		}
+		req.eventLog = eventLog
+	}
+
+	return req, nil
+}
+
+// parsePCRs parses a comma-separated list of PCR numbers
+func parsePCRs(s string) ([]uint, error) {
+	var pcrs []uint
+
+	for _, f := range strings.Split(s, ",") {
+		pcr, err := strconv.ParseUint(strings.TrimSpace(f), 10, 8)
+		if err != nil || pcr > maxPCR {
+			return nil, fmt.Errorf("pcrs %s is invalid", s)
+		}
+		if !slices.Contains(pcrs, uint(pcr)) {
+			pcrs = append(pcrs, uint(pcr))
+		}
+	}
+
+	slices.Sort(pcrs)
+	return pcrs, nil
+}
+
+func (t *TPMPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the TPM attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in tpm plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	req, err := parseOptions(in.Options)
+	if err != nil {
+		return getEvidenceError(err, http.StatusBadRequest)
+	}
+
+	tpm, err := t.openTPM()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to open TPM: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+	defer tpm.Close() //nolint:errcheck
+
+	out, err := quote(tpm, in.Nonce, req)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to get TPM quote: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	if t.cfg.AKCertNV != 0 {
+		out.AKCert, err = readNV(tpm, t.cfg.AKCertNV)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to read AK certificate: %v", err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+	}
+
+	if req.eventLog {
+		out.EventLog, err = os.ReadFile(t.cfg.EventLog)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to read event log: %v", err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.TPMQuoteMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode TPM quote as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// quote creates the AK and quotes the requested PCRs with nonce as the
+// qualifying data. The PCRs are read before they are quoted, and both are
+// repeated if a PCR was extended in between, so that the returned values
+// match the quoted PCR digest.
+func quote(tpm transport.TPM, nonce []byte, req *quoteRequest) (*tokens.TPMQuote, error) {
+	hashAlg := tokens.TPMHashAlgs[req.hashAlg]
+
+	ak, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHEndorsement,
+		InPublic:      tpm2.New2B(akTemplate),
+	}.Execute(tpm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AK: %w", err)
+	}
+	defer tpm2.FlushContext{FlushHandle: ak.ObjectHandle}.Execute(tpm) //nolint:errcheck
+
+	for attempt := 1; ; attempt++ {
+		pcrs, err := readPCRs(tpm, req.hashAlg, req.pcrs)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := tpm2.Quote{
+			SignHandle: tpm2.AuthHandle{
+				Handle: ak.ObjectHandle,
+				Name:   ak.Name,
+				Auth:   tpm2.PasswordAuth(nil),
+			},
+			QualifyingData: tpm2.TPM2BData{Buffer: nonce},
+			InScheme:       tpm2.TPMTSigScheme{Scheme: tpm2.TPMAlgNull},
+			PCRSelect:      pcrSelection(hashAlg, req.pcrs),
+		}.Execute(tpm)
+		if err != nil {
+			return nil, fmt.Errorf("quote failed: %w", err)
+		}
+
+		out := &tokens.TPMQuote{
+			Quote:     q.Quoted.Bytes(),
+			Signature: tpm2.Marshal(q.Signature),
+			HashAlg:   req.hashAlg,
+			PCRs:      pcrs,
+			AKPublic:  ak.OutPublic.Bytes(),
+		}
+
+		err = out.CheckPCRDigest()
+		if err == nil {
+			return out, nil
+		}
+
+		if attempt == maxQuoteAttempts {
+			return nil, fmt.Errorf("PCRs changed during %d quote attempts: %w", attempt, err)
+		}
+	}
+}
+
+func pcrSelection(hashAlg tpm2.TPMAlgID, pcrs []uint) tpm2.TPMLPCRSelection {
+	return tpm2.TPMLPCRSelection{
+		PCRSelections: []tpm2.TPMSPCRSelection{
+			{
+				Hash:      hashAlg,
+				PCRSelect: tpm2.PCClientCompatible.PCRs(pcrs...),
+			},
+		},
+	}
+}
+
+// readPCRs reads the values of pcrs from the given PCR bank. The TPM returns
+// at most maxPCRRead values per command, and none if the bank is not
+// allocated.
+func readPCRs(tpm transport.TPM, bank string, pcrs []uint) (map[uint32]tokens.BinaryString, error) {
+	hashAlg := tokens.TPMHashAlgs[bank]
+	values := make(map[uint32]tokens.BinaryString, len(pcrs))
+
+	for remaining := pcrs; len(remaining) > 0; {
+		rsp, err := tpm2.PCRRead{
+			PCRSelectionIn: pcrSelection(hashAlg, remaining[:min(len(remaining), maxPCRRead)]),
+		}.Execute(tpm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PCRs: %w", err)
+		}
+
+		var read []uint32
+		for _, sel := range rsp.PCRSelectionOut.PCRSelections {
+			read = append(read, tokens.PCRIndices(sel.PCRSelect)...)
+		}
+
+		if len(read) == 0 || len(read) != len(rsp.PCRValues.Digests) {
+			return nil, fmt.Errorf("PCR bank %s is not allocated", bank)
+		}
+
+		for i, pcr := range read {
+			values[pcr] = rsp.PCRValues.Digests[i].Buffer
+		}
+
+		remaining = slices.DeleteFunc(slices.Clone(remaining), func(pcr uint) bool {
+			return slices.Contains(read, uint32(pcr))
+		})
+	}
+
+	return values, nil
+}
+
+// readNV reads the contents of an NV index using the index authorization
+func readNV(tpm transport.TPM, index uint32) ([]byte, error) {
+	pub, err := tpm2.NVReadPublic{NVIndex: tpm2.TPMHandle(index)}.Execute(tpm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public area of NV index %#x: %w", index, err)
+	}
+
+	nvPublic, err := pub.NVPublic.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	handle := tpm2.AuthHandle{
+		Handle: tpm2.TPMHandle(index),
+		Name:   pub.NVName,
+		Auth:   tpm2.PasswordAuth(nil),
+	}
+
+	var data []byte
+	for offset := uint16(0); offset < nvPublic.DataSize; {
+		size := min(nvPublic.DataSize-offset, maxNVRead)
+
+		rsp, err := tpm2.NVRead{
+			AuthHandle: handle,
+			NVIndex: tpm2.NamedHandle{
+				Handle: tpm2.TPMHandle(index),
+				Name:   pub.NVName,
+			},
+			Size:   size,
+			Offset: offset,
+		}.Execute(tpm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read NV index %#x: %w", index, err)
+		}
+
+		data = append(data, rsp.Data.Buffer...)
+		offset += size
+	}
+
+	return data, nil
+}
+
+// GetPlugin returns the TPM attester configured from the environment
+func GetPlugin() (*TPMPlugin, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlugin(cfg), nil
+}
@@ -0,0 +1,483 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tpm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	validNonceStr = "abcdefghijklmnopqrstuvwxyz123456"
+
+	// akCertNV is the NV index of the ECC AK certificate of the TCG EK
+	// credential profile
+	akCertNV = 0x01c101d0
+)
+
+// nopCloser keeps the simulator running when the plugin closes the TPM
+type nopCloser struct {
+	transport.TPM
+}
+
+func (nopCloser) Close() error { return nil }
+
+// newSimulatorPlugin returns a TPM attester backed by a freshly manufactured
+// TPM simulator, which is shared by the requests of the test
+func newSimulatorPlugin(t *testing.T, cfg Config) (*TPMPlugin, transport.TPM) {
+	sim, err := simulator.OpenSimulator()
+	require.NoError(t, err)
+	t.Cleanup(func() { sim.Close() })
+
+	p := NewPlugin(cfg)
+	p.open = func() (transport.TPMCloser, error) {
+		return nopCloser{sim}, nil
+	}
+
+	return p, sim
+}
+
+func getQuote(t *testing.T, p *TPMPlugin, contentType, options string) *tokens.TPMQuote {
+	in := &compositor.EvidenceIn{
+		ContentType: contentType,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(options),
+	}
+
+	out := p.GetEvidence(in)
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	q := &tokens.TPMQuote{}
+	if contentType == tokens.TPMQuoteMediaTypeCBOR {
+		require.NoError(t, q.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, q.FromJSON(out.Evidence))
+	}
+
+	require.NoError(t, q.Verify())
+
+	extraData, err := q.ExtraData()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(validNonceStr), extraData)
+
+	return q
+}
+
+func pcrNumbers(q *tokens.TPMQuote) []uint32 {
+	var pcrs []uint32
+	for pcr := range q.PCRs {
+		pcrs = append(pcrs, pcr)
+	}
+	return pcrs
+}
+
+func Test_ConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvDevice, "/dev/tpm0")
+	t.Setenv(EnvEventLog, "/tmp/eventlog")
+	t.Setenv(EnvAKCertNV, "0x01c101d0")
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Device:   "/dev/tpm0",
+		EventLog: "/tmp/eventlog",
+		AKCertNV: akCertNV,
+	}, cfg)
+}
+
+func Test_ConfigFromEnv_Default(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), cfg)
+}
+
+func Test_ConfigFromEnv_Fail(t *testing.T) {
+	t.Setenv(EnvAKCertNV, "akcert")
+
+	_, err := ConfigFromEnv()
+	assert.EqualError(t, err, "RATSD_TPM_AK_CERT_NV: NV index akcert is invalid")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{
+			&compositor.Option{Name: "pcrs", Type: "string"},
+			&compositor.Option{Name: "hash_alg", Type: "string"},
+			&compositor.Option{Name: "event_log", Type: "string"},
+		},
+		Status: statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(DefaultConfig()).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, NewPlugin(DefaultConfig()).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	p, _ := newSimulatorPlugin(t, DefaultConfig())
+
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, p.GetSupportedFormats())
+}
+
+func Test_GetSupportedFormats_no_TPM(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Device = filepath.Join(t.TempDir(), "tpmrm0")
+
+	out := NewPlugin(cfg).GetSupportedFormats()
+	assert.False(t, out.Status.Result)
+	assert.Contains(t, out.Status.Error, "TPM is not available: ")
+	assert.Empty(t, out.Formats)
+}
+
+func Test_GetEvidence_JSON(t *testing.T) {
+	p, _ := newSimulatorPlugin(t, DefaultConfig())
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, "")
+	assert.Equal(t, "sha256", q.HashAlg)
+	assert.ElementsMatch(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, pcrNumbers(q))
+	assert.Empty(t, q.AKCert)
+	assert.Empty(t, q.EventLog)
+}
+
+func Test_GetEvidence_CBOR(t *testing.T) {
+	p, sim := newSimulatorPlugin(t, DefaultConfig())
+
+	digest := sha256.Sum256([]byte("measurement"))
+	_, err := tpm2.PCRExtend{
+		PCRHandle: tpm2.AuthHandle{
+			Handle: tpm2.TPMHandle(16),
+			Auth:   tpm2.PasswordAuth(nil),
+		},
+		Digests: tpm2.TPMLDigestValues{
+			Digests: []tpm2.TPMTHA{{HashAlg: tpm2.TPMAlgSHA256, Digest: digest[:]}},
+		},
+	}.Execute(sim)
+	require.NoError(t, err)
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeCBOR, `{"pcrs": "16, 0"}`)
+	assert.ElementsMatch(t, []uint32{0, 16}, pcrNumbers(q))
+
+	expected := sha256.Sum256(append(make([]byte, sha256.Size), digest[:]...))
+	assert.Equal(t, tokens.BinaryString(expected[:]), q.PCRs[16])
+}
+
+func Test_GetEvidence_all_PCRs(t *testing.T) {
+	p, _ := newSimulatorPlugin(t, DefaultConfig())
+
+	var pcrs []string
+	var expected []uint32
+	for pcr := uint32(0); pcr <= maxPCR; pcr++ {
+		pcrs = append(pcrs, strconv.Itoa(int(pcr)))
+		expected = append(expected, pcr)
+	}
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON,
+		`{"pcrs": "`+strings.Join(pcrs, ",")+`"}`)
+	assert.ElementsMatch(t, expected, pcrNumbers(q))
+}
+
+func Test_GetEvidence_SHA1_bank(t *testing.T) {
+	p, _ := newSimulatorPlugin(t, DefaultConfig())
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, `{"hash_alg": "sha1"}`)
+	assert.Equal(t, "sha1", q.HashAlg)
+	for _, v := range q.PCRs {
+		assert.Len(t, v, 20)
+	}
+}
+
+func Test_GetEvidence_deterministic_AK(t *testing.T) {
+	p, _ := newSimulatorPlugin(t, DefaultConfig())
+
+	q1 := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, "")
+	q2 := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, "")
+	assert.Equal(t, q1.AKPublic, q2.AKPublic)
+}
+
+func Test_GetEvidence_event_log(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EventLog = filepath.Join(t.TempDir(), "binary_bios_measurements")
+	require.NoError(t, os.WriteFile(cfg.EventLog, []byte("event log"), 0o600))
+
+	p, _ := newSimulatorPlugin(t, cfg)
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, `{"event_log": "true"}`)
+	assert.Equal(t, tokens.BinaryString("event log"), q.EventLog)
+
+	q = getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, `{"event_log": "false"}`)
+	assert.Empty(t, q.EventLog)
+}
+
+func Test_GetEvidence_event_log_missing(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EventLog = filepath.Join(t.TempDir(), "binary_bios_measurements")
+
+	p, _ := newSimulatorPlugin(t, cfg)
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TPMQuoteMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"event_log": "true"}`),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Contains(t, out.Status.Error, "failed to read event log: ")
+}
+
+// provisionAKCert writes a certificate of the AK, with a subject long enough
+// to need several NV reads, to the NV index of the AK certificate
+func provisionAKCert(t *testing.T, sim transport.TPM, akPublic []byte) []byte {
+	public, err := tpm2.Unmarshal[tpm2.TPMTPublic](akPublic)
+	require.NoError(t, err)
+	ak, err := tpm2.Pub(*public)
+	require.NoError(t, err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:         "TPM simulator AK",
+			OrganizationalUnit: []string{strings.Repeat("ratsd ", 50)},
+		},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, ak, caKey)
+	require.NoError(t, err)
+	require.Greater(t, len(cert), maxNVRead)
+
+	owner := tpm2.AuthHandle{Handle: tpm2.TPMRHOwner, Auth: tpm2.PasswordAuth(nil)}
+
+	_, err = tpm2.NVDefineSpace{
+		AuthHandle: owner,
+		PublicInfo: tpm2.New2B(tpm2.TPMSNVPublic{
+			NVIndex: tpm2.TPMHandle(akCertNV),
+			NameAlg: tpm2.TPMAlgSHA256,
+			Attributes: tpm2.TPMANV{
+				OwnerWrite: true,
+				AuthRead:   true,
+				NoDA:       true,
+				NT:         tpm2.TPMNTOrdinary,
+			},
+			DataSize: uint16(len(cert)),
+		}),
+	}.Execute(sim)
+	require.NoError(t, err)
+
+	pub, err := tpm2.NVReadPublic{NVIndex: tpm2.TPMHandle(akCertNV)}.Execute(sim)
+	require.NoError(t, err)
+
+	for offset := 0; offset < len(cert); offset += maxNVRead {
+		_, err = tpm2.NVWrite{
+			AuthHandle: owner,
+			NVIndex:    tpm2.NamedHandle{Handle: tpm2.TPMHandle(akCertNV), Name: pub.NVName},
+			Data:       tpm2.TPM2BMaxNVBuffer{Buffer: cert[offset:min(len(cert), offset+maxNVRead)]},
+			Offset:     uint16(offset),
+		}.Execute(sim)
+		require.NoError(t, err)
+	}
+
+	return cert
+}
+
+func Test_GetEvidence_AK_cert(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AKCertNV = akCertNV
+
+	p, sim := newSimulatorPlugin(t, cfg)
+
+	// the AK is the same with or without the certificate
+	unprovisioned := NewPlugin(DefaultConfig())
+	unprovisioned.open = p.open
+
+	q := getQuote(t, unprovisioned, tokens.TPMQuoteMediaTypeJSON, "")
+	cert := provisionAKCert(t, sim, q.AKPublic)
+
+	q = getQuote(t, p, tokens.TPMQuoteMediaTypeCBOR, "")
+	assert.Equal(t, tokens.BinaryString(cert), q.AKCert)
+}
+
+func Test_GetEvidence_AK_cert_missing(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AKCertNV = akCertNV
+
+	p, _ := newSimulatorPlugin(t, cfg)
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TPMQuoteMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Contains(t, out.Status.Error, "failed to read AK certificate: ")
+}
+
+// extendingTPM extends PCR 16 before the first extensions TPM2_Quote
+// commands, as if it were measured between the PCR read and the quote
+type extendingTPM struct {
+	transport.TPM
+	extensions int
+}
+
+func (e *extendingTPM) Send(cmd []byte) ([]byte, error) {
+	if len(cmd) >= 10 && tpm2.TPMCC(binary.BigEndian.Uint32(cmd[6:])) == tpm2.TPMCCQuote &&
+		e.extensions > 0 {
+		e.extensions--
+
+		digest := sha256.Sum256([]byte("measurement"))
+		_, err := tpm2.PCRExtend{
+			PCRHandle: tpm2.AuthHandle{
+				Handle: tpm2.TPMHandle(16),
+				Auth:   tpm2.PasswordAuth(nil),
+			},
+			Digests: tpm2.TPMLDigestValues{
+				Digests: []tpm2.TPMTHA{{HashAlg: tpm2.TPMAlgSHA256, Digest: digest[:]}},
+			},
+		}.Execute(e.TPM)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return e.TPM.Send(cmd)
+}
+
+func Test_GetEvidence_PCRs_extended_during_quote(t *testing.T) {
+	p, sim := newSimulatorPlugin(t, DefaultConfig())
+	tpm := &extendingTPM{TPM: sim, extensions: maxQuoteAttempts - 1}
+	p.open = func() (transport.TPMCloser, error) {
+		return nopCloser{tpm}, nil
+	}
+
+	q := getQuote(t, p, tokens.TPMQuoteMediaTypeJSON, `{"pcrs": "16"}`)
+	assert.Zero(t, tpm.extensions)
+	assert.NoError(t, q.CheckPCRDigest())
+}
+
+func Test_GetEvidence_PCRs_keep_changing(t *testing.T) {
+	p, sim := newSimulatorPlugin(t, DefaultConfig())
+	p.open = func() (transport.TPMCloser, error) {
+		return nopCloser{&extendingTPM{TPM: sim, extensions: maxQuoteAttempts}}, nil
+	}
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TPMQuoteMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+		Options:     []byte(`{"pcrs": "16"}`),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to get TPM quote: PCRs changed during 3 quote attempts: "+
+		"PCR values do not match the quoted PCR digest", out.Status.Error)
+}
+
+func Test_GetEvidence_open_failure(t *testing.T) {
+	p := NewPlugin(DefaultConfig())
+	p.open = func() (transport.TPMCloser, error) {
+		return nil, errors.New("no TPM")
+	}
+
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TPMQuoteMediaTypeJSON,
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := p.GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to open TPM: no TPM", out.Status.Error)
+}
+
+func Test_GetEvidence_wrong_nonce_size(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: tokens.TPMQuoteMediaTypeJSON,
+		Nonce:       []byte("abcdefghijklmnop"),
+	}
+
+	out := NewPlugin(DefaultConfig()).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the TPM attester should be 32, got 16", out.Status.Error)
+}
+
+func Test_GetEvidence_invalid_format(t *testing.T) {
+	in := &compositor.EvidenceIn{
+		ContentType: "invalid-format",
+		Nonce:       []byte(validNonceStr),
+	}
+
+	out := NewPlugin(DefaultConfig()).GetEvidence(in)
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in tpm plugin matches the requested format", out.Status.Error)
+}
+
+func Test_GetEvidence_Invalid_Options(t *testing.T) {
+	tvs := []struct {
+		options  string
+		expected string
+	}{
+		{`{"pcrs": "0,24"}`, "pcrs 0,24 is invalid"},
+		{`{"pcrs": ""}`, "pcrs  is invalid"},
+		{`{"pcrs": "0-7"}`, "pcrs 0-7 is invalid"},
+		{`{"hash_alg": "md5"}`, "hash_alg md5 is not supported"},
+		{`{"event_log": "yes please"}`, "event_log yes please is not a boolean"},
+		{`{"pcrs"`, `failed to parse {"pcrs": unexpected end of JSON input`},
+	}
+
+	for _, tv := range tvs {
+		in := &compositor.EvidenceIn{
+			ContentType: tokens.TPMQuoteMediaTypeJSON,
+			Nonce:       []byte(validNonceStr),
+			Options:     []byte(tv.options),
+		}
+
+		out := NewPlugin(DefaultConfig()).GetEvidence(in)
+		assert.False(t, out.Status.Result, tv.options)
+		assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode, tv.options)
+		assert.Equal(t, tv.expected, out.Status.Error, tv.options)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tsm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/report"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+	"go.uber.org/multierr"
+)
+
+// ServiceOptions lists the attester options selecting a service provider
+var ServiceOptions = []*compositor.Option{
+	&compositor.Option{Name: "service_provider", Type: "string"},
+	&compositor.Option{Name: "service_guid", Type: "string"},
+	&compositor.Option{Name: "service_manifest_version", Type: "string"},
+}
+
+// ServiceRequest asks configfs-TSM for a report generated by a service
+// provider, such as the SVSM, instead of the TSM itself
+type ServiceRequest struct {
+	Provider        string
+	GUID            string
+	ManifestVersion string
+}
+
+// ParseServiceRequest extracts the service provider options from the attester
+// options. It returns nil if no service provider is selected.
+func ParseServiceRequest(options map[string]string) (*ServiceRequest, error) {
+	sr := &ServiceRequest{
+		Provider:        options["service_provider"],
+		GUID:            options["service_guid"],
+		ManifestVersion: options["service_manifest_version"],
+	}
+
+	if sr.Provider == "" {
+		if sr.GUID != "" {
+			return nil, errors.New("service_guid requires service_provider")
+		}
+		if sr.ManifestVersion != "" {
+			return nil, errors.New("service_manifest_version requires service_provider")
+		}
+		return nil, nil
+	}
+
+	if sr.ManifestVersion != "" {
+		if _, err := strconv.ParseUint(sr.ManifestVersion, 10, 32); err != nil {
+			return nil, fmt.Errorf("service_manifest_version %s is invalid", sr.ManifestVersion)
+		}
+	}
+
+	return sr, nil
+}
+
+// GetReport generates a TSM report. If sr is not nil, the report is generated
+// by the selected service provider, and the service provider and its manifest
+// are recorded in the returned report.
+func GetReport(client configfsi.Client, req *report.Request, sr *ServiceRequest) (*tokens.TSMReport, error) {
+	r, err := report.Create(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := getReport(r, sr)
+	return out, multierr.Combine(r.Destroy(), err)
+}
+
+func getReport(r *report.OpenReport, sr *ServiceRequest) (*tokens.TSMReport, error) {
+	if sr != nil {
+		attrs := []struct{ name, value string }{
+			{"service_provider", sr.Provider},
+			{"service_guid", sr.GUID},
+			{"service_manifest_version", sr.ManifestVersion},
+		}
+		for _, a := range attrs {
+			if a.value == "" {
+				continue
+			}
+			if err := r.WriteOption(a.name, []byte(a.value)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp, err := r.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	out := &tokens.TSMReport{
+		Provider: resp.Provider,
+		OutBlob:  resp.OutBlob,
+		AuxBlob:  resp.AuxBlob,
+	}
+
+	if sr != nil {
+		manifest, err := r.ReadOption("manifestblob")
+		if err != nil {
+			return nil, fmt.Errorf("could not read report manifestblob: %w", err)
+		}
+
+		provider := sr.Provider
+		out.ServiceProvider = &provider
+		out.ManifestBlob = manifest
+	}
+
+	return out, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tsm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseServiceRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected *ServiceRequest
+	}{
+		{"no service provider", map[string]string{"privilege_level": "1"}, nil},
+		{
+			"service provider only",
+			map[string]string{"service_provider": "svsm"},
+			&ServiceRequest{Provider: "svsm"},
+		},
+		{
+			"all service options",
+			map[string]string{
+				"service_provider":         "svsm",
+				"service_guid":             "c476f1eb-0123-45a5-9641-b4e7dde5bfe3",
+				"service_manifest_version": "2",
+			},
+			&ServiceRequest{
+				Provider:        "svsm",
+				GUID:            "c476f1eb-0123-45a5-9641-b4e7dde5bfe3",
+				ManifestVersion: "2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr, err := ParseServiceRequest(tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sr)
+		})
+	}
+}
+
+func Test_ParseServiceRequest_Fail(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		msg     string
+	}{
+		{
+			"service guid without provider",
+			map[string]string{"service_guid": "c476f1eb-0123-45a5-9641-b4e7dde5bfe3"},
+			"service_guid requires service_provider",
+		},
+		{
+			"manifest version without provider",
+			map[string]string{"service_manifest_version": "1"},
+			"service_manifest_version requires service_provider",
+		},
+		{
+			"negative manifest version",
+			map[string]string{"service_provider": "svsm", "service_manifest_version": "-1"},
+			"service_manifest_version -1 is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr, err := ParseServiceRequest(tt.options)
+			assert.EqualError(t, err, tt.msg)
+			assert.Nil(t, sr)
+		})
+	}
+}
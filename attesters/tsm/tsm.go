@@ -6,17 +6,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
 	"github.com/google/go-configfs-tsm/configfs/linuxtsm"
 	"github.com/google/go-configfs-tsm/report"
+	"github.com/veraison/cmw"
 	"github.com/veraison/ratsd/proto/compositor"
 	"github.com/veraison/ratsd/tokens"
 )
 
 const (
 	tsmNonceSize = 64
+
+	// collection type of the records returned for native media types
+	nativeCollectionType = "tag:github.com,2026:veraison/ratsd/cmw/tsm-report"
 )
 
 var (
@@ -36,10 +43,85 @@ var (
 		},
 	}
 
+	// nativeMediaTypes maps the TSM providers to the media type of their
+	// outblob
+	nativeMediaTypes = map[string]string{
+		tokens.TSMProviderSEVSNP: tokens.SEVSNPReportMediaType,
+		tokens.TSMProviderTDX:    tokens.TDXQuoteMediaType,
+		tokens.TSMProviderCCA:    tokens.CCATokenMediaType,
+	}
+
 	statusSucceeded = &compositor.Status{Result: true, Error: ""}
 )
 
-type TSMPlugin struct{}
+type TSMPlugin struct {
+	// client overrides the configfs-TSM client, for testing
+	client configfsi.Client
+
+	// the provider is read until it is known, as reading it creates a
+	// report entry
+	providerMu sync.Mutex
+	provider   string
+}
+
+func (t *TSMPlugin) makeClient() (configfsi.Client, error) {
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	return linuxtsm.MakeClient()
+}
+
+// getProvider returns the name of the TSM provider, which is read again on
+// each call until it is known, so that transient failures are not cached
+func (t *TSMPlugin) getProvider(client configfsi.Client) (string, error) {
+	t.providerMu.Lock()
+	defer t.providerMu.Unlock()
+
+	if t.provider == "" {
+		provider, err := readProvider(client)
+		if err != nil {
+			return "", err
+		}
+		t.provider = provider
+	}
+
+	return t.provider, nil
+}
+
+// readProvider returns the name of the TSM provider without generating a
+// report
+func readProvider(client configfsi.Client) (string, error) {
+	r, err := report.CreateOpenReport(client)
+	if err != nil {
+		return "", err
+	}
+	defer r.Destroy() //nolint:errcheck
+
+	// reading outblob generates the report, so writing inblob is harmless,
+	// and some configfs-TSM implementations only expose the attributes of an
+	// entry once it has been written to
+	if err := r.WriteOption("inblob", make([]byte, tsmNonceSize)); err != nil {
+		return "", err
+	}
+
+	provider, err := r.ReadOption("provider")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(provider)), nil
+}
+
+func isNativeMediaType(mediaType string) bool {
+	for _, mt := range nativeMediaTypes {
+		if mt == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
 
 func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
 	return &compositor.EvidenceOut{
@@ -54,6 +136,7 @@ func (t *TSMPlugin) GetOptions() *compositor.OptionsOut {
 	options := []*compositor.Option{
 		&compositor.Option{Name: "privilege_level", Type: "string"},
 	}
+	options = append(options, ServiceOptions...)
 
 	return &compositor.OptionsOut{
 		Options: options,
@@ -68,8 +151,11 @@ func (t *TSMPlugin) GetSubAttesterID() *compositor.SubAttesterIDOut {
 	}
 }
 
+// GetSupportedFormats returns the Veraison TSM report formats, followed by the
+// native format of the detected TSM provider, if any.
 func (t *TSMPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
-	if _, err := linuxtsm.MakeClient(); err != nil {
+	client, err := t.makeClient()
+	if err != nil {
 		return &compositor.SupportedFormatsOut{
 			Status: &compositor.Status{
 				Result: false,
@@ -78,9 +164,21 @@ func (t *TSMPlugin) GetSupportedFormats() *compositor.SupportedFormatsOut {
 		}
 	}
 
+	formats := supportedFormats
+
+	// the native format is only advertised if the provider can be detected
+	if provider, err := t.getProvider(client); err == nil {
+		if mt, ok := nativeMediaTypes[provider]; ok {
+			formats = append(slices.Clone(supportedFormats), &compositor.Format{
+				ContentType: mt,
+				NonceSize:   tsmNonceSize,
+			})
+		}
+	}
+
 	return &compositor.SupportedFormatsOut{
 		Status:  statusSucceeded,
-		Formats: supportedFormats,
+		Formats: formats,
 	}
 }
 
@@ -92,86 +190,162 @@ func (t *TSMPlugin) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceO
 		return getEvidenceError(errMsg, http.StatusBadRequest)
 	}
 
-	for _, format := range supportedFormats {
-		if in.ContentType == format.ContentType {
-			req := &report.Request{
-				InBlob:     in.Nonce,
-				GetAuxBlob: false,
-			}
+	native := isNativeMediaType(in.ContentType)
+	if !native && !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in tsm plugin matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
 
-			options := make(map[string]string)
-			if len(in.Options) > 0 {
-				if err := json.Unmarshal(in.Options, &options); err != nil {
-					errMsg := fmt.Errorf(
-						"failed to parse %s: %v", in.Options, err)
-					return getEvidenceError(errMsg, http.StatusBadRequest)
-				}
-			}
+	req := &report.Request{
+		InBlob:     in.Nonce,
+		GetAuxBlob: false,
+	}
 
-			if privlevel, ok := options["privilege_level"]; ok {
-				level, err := strconv.Atoi(privlevel)
-				if err != nil || level < 0 {
-					errMsg := fmt.Errorf("privilege_level %s is invalid",
-						privlevel)
-					return getEvidenceError(errMsg, http.StatusBadRequest)
-				}
-				req.Privilege = &report.Privilege{Level: uint(level)}
-			}
+	options := make(map[string]string)
+	if len(in.Options) > 0 {
+		if err := json.Unmarshal(in.Options, &options); err != nil {
+			errMsg := fmt.Errorf(
+				"failed to parse %s: %v", in.Options, err)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+	}
 
-			client, err := linuxtsm.MakeClient()
-			if err != nil {
-				errMsg := fmt.Errorf("failed to create config TSM client: %v", err)
-				return getEvidenceError(errMsg, http.StatusInternalServerError)
-			}
+	if privlevel, ok := options["privilege_level"]; ok {
+		level, err := strconv.Atoi(privlevel)
+		if err != nil || level < 0 {
+			errMsg := fmt.Errorf("privilege_level %s is invalid",
+				privlevel)
+			return getEvidenceError(errMsg, http.StatusBadRequest)
+		}
+		req.Privilege = &report.Privilege{Level: uint(level)}
+	}
 
-			resp, err := report.Get(client, req)
-			if err != nil {
-				errMsg := fmt.Errorf("failed to get TSM report: %v", err)
-				return getEvidenceError(errMsg, http.StatusInternalServerError)
-			}
+	sr, err := ParseServiceRequest(options)
+	if err != nil {
+		return getEvidenceError(err, http.StatusBadRequest)
+	}
 
-			out := &tokens.TSMReport{
-				Provider: resp.Provider,
-				OutBlob:  resp.OutBlob,
-				AuxBlob:  resp.AuxBlob,
-			}
+	client, err := t.makeClient()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to create config TSM client: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
 
-			// SEV-SNP stores cert table in auxblob. Get the report one more time to fetch the auxblob
-			// resp.Provider might contain newlines
-			if strings.TrimSpace(resp.Provider) == "sev_guest" {
-				req.GetAuxBlob = true
-				resp, err := report.Get(client, req)
-				if err != nil {
-					errMsg := fmt.Errorf("failed to get TSM report: %v", err)
-					return getEvidenceError(errMsg, http.StatusInternalServerError)
-				}
-				out.AuxBlob = resp.AuxBlob
-			}
+	out, err := GetReport(client, req, sr)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to get TSM report: %v", err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
 
-			var encodeOp func() ([]byte, error)
-			encodeAs := "JSON"
+	if native && nativeMediaTypes[out.ProviderName()] != in.ContentType {
+		errMsg := fmt.Errorf("%s is not supported by TSM provider %q",
+			in.ContentType, out.ProviderName())
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
 
-			if in.ContentType == tokens.TSMReportMediaTypeCBOR {
-				encodeOp = out.ToCBOR
-				encodeAs = "CBOR"
-			} else {
-				encodeOp = out.ToJSON
-			}
+	// SEV-SNP stores cert table in auxblob. Get the report one more time to fetch the auxblob
+	// resp.Provider might contain newlines
+	if out.ProviderName() == tokens.TSMProviderSEVSNP {
+		req.GetAuxBlob = true
+		out, err = GetReport(client, req, sr)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to get TSM report: %v", err)
+			return getEvidenceError(errMsg, http.StatusInternalServerError)
+		}
+	}
 
-			outEncoded, err := encodeOp()
+	if native {
+		return nativeEvidence(in.ContentType, out)
+	}
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.TSMReportMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode TSM report as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// nativeEvidence returns the raw outblob as a record of the native media type
+// of the provider. The auxiliary material is returned in separate records: the
+// certificates of the SEV-SNP certificate table as endorsements, the auxblob
+// of the other providers as is, and the manifest of the service provider.
+func nativeEvidence(mediaType string, out *tokens.TSMReport) *compositor.EvidenceOut {
+	records := []*compositor.Record{
+		{
+			Key:         "outblob",
+			ContentType: mediaType,
+			Value:       out.OutBlob,
+			Indicator:   uint32(cmw.Evidence),
+		},
+	}
+
+	if len(out.AuxBlob) > 0 {
+		if out.ProviderName() == tokens.TSMProviderSEVSNP {
+			certs, err := tokens.ParseSEVSNPCertTable(out.AuxBlob)
 			if err != nil {
-				errMsg := fmt.Errorf("failed to encode TSM report as %s: %v", encodeAs, err)
+				errMsg := fmt.Errorf("failed to parse SEV-SNP certificate table: %v", err)
 				return getEvidenceError(errMsg, http.StatusInternalServerError)
 			}
 
-			return &compositor.EvidenceOut{
-				Status:     statusSucceeded,
-				Evidence:   outEncoded,
-				StatusCode: http.StatusOK,
+			// the table may hold several certificates of the same kind
+			seen := make(map[string]int)
+			for _, c := range certs {
+				key := strings.ToLower(c.Kind)
+				if key == "" {
+					key = c.GUID
+				}
+				n := seen[key]
+				seen[key]++
+				if n > 0 {
+					key = fmt.Sprintf("%s-%d", key, n)
+				}
+				records = append(records, &compositor.Record{
+					Key:         key,
+					ContentType: tokens.PKIXCertMediaType,
+					Value:       c.DER,
+					Indicator:   uint32(cmw.Endorsements),
+				})
 			}
+		} else {
+			records = append(records, &compositor.Record{
+				Key:         "auxblob",
+				ContentType: "application/octet-stream",
+				Value:       out.AuxBlob,
+			})
 		}
 	}
 
-	errMsg := fmt.Errorf("no supported format in tsm plugin matches the requested format")
-	return getEvidenceError(errMsg, http.StatusBadRequest)
+	if len(out.ManifestBlob) > 0 {
+		records = append(records, &compositor.Record{
+			Key:         "manifestblob",
+			ContentType: "application/octet-stream",
+			Value:       out.ManifestBlob,
+			Indicator:   uint32(cmw.Evidence),
+		})
+	}
+
+	return &compositor.EvidenceOut{
+		Status:         statusSucceeded,
+		StatusCode:     http.StatusOK,
+		CollectionType: nativeCollectionType,
+		Records:        records,
+	}
 }
@@ -3,12 +3,20 @@
 package tsm
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/configfs/faketsm"
 	"github.com/google/go-configfs-tsm/configfs/linuxtsm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
 	"github.com/veraison/ratsd/proto/compositor"
 	"github.com/veraison/ratsd/tokens"
 )
@@ -40,6 +48,9 @@ func Test_getEvidenceError(t *testing.T) {
 func Test_GetOptions(t *testing.T) {
 	options := []*compositor.Option{
 		&compositor.Option{Name: "privilege_level", Type: "string"},
+		&compositor.Option{Name: "service_provider", Type: "string"},
+		&compositor.Option{Name: "service_guid", Type: "string"},
+		&compositor.Option{Name: "service_manifest_version", Type: "string"},
 	}
 
 	expected := &compositor.OptionsOut{
@@ -148,3 +159,237 @@ func TestGetEvidence_With_Invalid_Options(t *testing.T) {
 		})
 	}
 }
+
+// fakeProviderClient returns a configfs-TSM client emulating provider, whose
+// outblob is rendered from the inblob by render
+func fakeProviderClient(provider string, render func(inblob []byte) []byte, auxblob []byte) configfsi.Client {
+	sub := faketsm.ReportV7(0)
+	sub.ReadAttr = func(e *faketsm.ReportEntry, attr string) ([]byte, error) {
+		switch attr {
+		case "provider":
+			return []byte(provider + "\n"), nil
+		case "auxblob":
+			return auxblob, nil
+		case "outblob":
+			return render(e.InAttrs["inblob"].Value), nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	return &faketsm.Client{Subsystems: map[string]configfsi.Client{"report": sub}}
+}
+
+func renderSEVSNPReport(inblob []byte) []byte {
+	r := make([]byte, 0x4a0)
+	r[0] = 2
+	copy(r[0x50:], inblob)
+	return r
+}
+
+func renderTDXQuote(inblob []byte) []byte {
+	q := make([]byte, 48+584+4)
+	q[0], q[4] = 4, 0x81
+	copy(q[48+520:], inblob)
+	return q
+}
+
+func sevSNPCertTable(t *testing.T, certs ...tokens.SEVSNPCertificate) []byte {
+	t.Helper()
+
+	// the entries are followed by a zero entry and the certificates
+	table := make([]byte, 24*(len(certs)+1))
+	for i, c := range certs {
+		id, err := hex.DecodeString(strings.ReplaceAll(c.GUID, "-", ""))
+		require.NoError(t, err)
+
+		entry := table[24*i:]
+		copy(entry, id)
+		binary.LittleEndian.PutUint32(entry[16:], uint32(len(table)))
+		binary.LittleEndian.PutUint32(entry[20:], uint32(len(c.DER)))
+		table = append(table, c.DER...)
+	}
+
+	return table
+}
+
+func Test_GetSupportedFormats_native(t *testing.T) {
+	tests := []struct {
+		provider string
+		native   string
+	}{
+		{tokens.TSMProviderSEVSNP, tokens.SEVSNPReportMediaType},
+		{tokens.TSMProviderTDX, tokens.TDXQuoteMediaType},
+		{tokens.TSMProviderCCA, tokens.CCATokenMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			p := &TSMPlugin{client: fakeProviderClient(tt.provider, renderSEVSNPReport, nil)}
+
+			expected := append(append([]*compositor.Format{}, supportedFormats...),
+				&compositor.Format{ContentType: tt.native, NonceSize: tsmNonceSize})
+
+			out := p.GetSupportedFormats()
+			assert.Equal(t, statusSucceeded, out.Status)
+			assert.Equal(t, expected, out.Formats)
+		})
+	}
+}
+
+func Test_GetSupportedFormats_provider_read_once(t *testing.T) {
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderTDX, renderTDXQuote, nil)}
+	expected := p.GetSupportedFormats().Formats
+	require.Len(t, expected, len(supportedFormats)+1)
+
+	// a client without provider is not queried again
+	p.client = &faketsm.Client{
+		Subsystems: map[string]configfsi.Client{"report": faketsm.ReportV7(0)},
+	}
+	assert.Equal(t, expected, p.GetSupportedFormats().Formats)
+}
+
+func Test_GetSupportedFormats_provider_read_retried(t *testing.T) {
+	// a failure to read the provider does not prevent later detection
+	p := &TSMPlugin{client: &faketsm.Client{}}
+	assert.Equal(t, supportedFormats, p.GetSupportedFormats().Formats)
+
+	p.client = fakeProviderClient(tokens.TSMProviderTDX, renderTDXQuote, nil)
+	assert.Len(t, p.GetSupportedFormats().Formats, len(supportedFormats)+1)
+}
+
+func Test_GetSupportedFormats_no_native_format(t *testing.T) {
+	p := &TSMPlugin{client: &faketsm.Client{
+		Subsystems: map[string]configfsi.Client{"report": faketsm.ReportV7(0)},
+	}}
+
+	out := p.GetSupportedFormats()
+	assert.Equal(t, statusSucceeded, out.Status)
+	assert.Equal(t, supportedFormats, out.Formats)
+}
+
+func Test_GetEvidence_native_SEVSNP(t *testing.T) {
+	vcek := []byte{0x30, 0x01, 0x02}
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderSEVSNP, renderSEVSNPReport,
+		sevSNPCertTable(t, tokens.SEVSNPCertificate{GUID: tokens.SEVSNPCertGUIDVCEK, DER: vcek}))}
+	nonce := []byte(validNonceStr)
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.SEVSNPReportMediaType,
+		Nonce:       nonce,
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	assert.Nil(t, out.Evidence)
+	assert.Equal(t, nativeCollectionType, out.CollectionType)
+	assert.Equal(t, []*compositor.Record{
+		{
+			Key:         "outblob",
+			ContentType: tokens.SEVSNPReportMediaType,
+			Value:       renderSEVSNPReport(nonce),
+			Indicator:   uint32(cmw.Evidence),
+		},
+		{
+			Key:         "vcek",
+			ContentType: tokens.PKIXCertMediaType,
+			Value:       vcek,
+			Indicator:   uint32(cmw.Endorsements),
+		},
+	}, out.Records)
+}
+
+func Test_GetEvidence_native_SEVSNP_duplicate_certs(t *testing.T) {
+	other := "11111111-2222-3333-4444-555555555555"
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderSEVSNP, renderSEVSNPReport,
+		sevSNPCertTable(t,
+			tokens.SEVSNPCertificate{GUID: tokens.SEVSNPCertGUIDVCEK, DER: []byte{0x30, 0x01}},
+			tokens.SEVSNPCertificate{GUID: tokens.SEVSNPCertGUIDVCEK, DER: []byte{0x30, 0x02}},
+			tokens.SEVSNPCertificate{GUID: other, DER: []byte{0x30, 0x03}},
+			tokens.SEVSNPCertificate{GUID: other, DER: []byte{0x30, 0x04}},
+		))}
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.SEVSNPReportMediaType,
+		Nonce:       []byte(validNonceStr),
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	var keys []string
+	for _, r := range out.Records {
+		keys = append(keys, r.Key)
+	}
+	assert.Equal(t, []string{"outblob", "vcek", "vcek-1", other, other + "-1"}, keys)
+
+	// the records can be assembled into a collection
+	c := cmw.NewCollection(nativeCollectionType)
+	for _, r := range out.Records {
+		require.NoError(t, c.AddCollectionItem(r.Key,
+			cmw.NewMonad(r.ContentType, r.Value, cmw.Indicator(r.Indicator))))
+	}
+	_, err := c.MarshalJSON()
+	assert.NoError(t, err)
+}
+
+func Test_GetEvidence_native_TDX(t *testing.T) {
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderTDX, renderTDXQuote, nil)}
+	nonce := []byte(validNonceStr)
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.TDXQuoteMediaType,
+		Nonce:       nonce,
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	require.Len(t, out.Records, 1)
+	assert.Equal(t, tokens.TDXQuoteMediaType, out.Records[0].ContentType)
+
+	quote, err := tokens.ParseTDXQuote(out.Records[0].Value)
+	require.NoError(t, err)
+	assert.Equal(t, nonce, quote.Body.ReportData)
+}
+
+func Test_GetEvidence_native_wrong_provider(t *testing.T) {
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderTDX, renderTDXQuote, nil)}
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.CCATokenMediaType,
+		Nonce:       []byte(validNonceStr),
+	})
+
+	expected := getEvidenceError(fmt.Errorf("%s is not supported by TSM provider %q",
+		tokens.CCATokenMediaType, tokens.TSMProviderTDX), http.StatusBadRequest)
+	assert.Equal(t, expected, out)
+}
+
+func Test_GetEvidence_native_invalid_cert_table(t *testing.T) {
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderSEVSNP, renderSEVSNPReport,
+		[]byte("not a certificate table"))}
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.SEVSNPReportMediaType,
+		Nonce:       []byte(validNonceStr),
+	})
+
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to parse SEV-SNP certificate table: SEV-SNP certificate table not terminated",
+		out.Status.Error)
+}
+
+func Test_GetEvidence_tsm_report(t *testing.T) {
+	auxblob := sevSNPCertTable(t, tokens.SEVSNPCertificate{GUID: tokens.SEVSNPCertGUIDARK, DER: []byte{0x30}})
+	p := &TSMPlugin{client: fakeProviderClient(tokens.TSMProviderSEVSNP, renderSEVSNPReport, auxblob)}
+	nonce := []byte(validNonceStr)
+
+	out := p.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.TSMReportMediaTypeCBOR,
+		Nonce:       nonce,
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Empty(t, out.Records)
+
+	report := &tokens.TSMReport{}
+	require.NoError(t, report.FromCBOR(out.Evidence))
+	assert.Equal(t, tokens.TSMProviderSEVSNP, report.ProviderName())
+	assert.Equal(t, renderSEVSNPReport(nonce), []byte(report.OutBlob))
+	assert.Equal(t, auxblob, []byte(report.AuxBlob))
+}
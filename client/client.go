@@ -0,0 +1,374 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
+	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
+)
+
+const (
+	charesRequestMediaType                 = "application/vnd.veraison.chares+json"
+	legacyCharesResponseMediaType          = `application/eat-ucs+json; eat_profile="tag:github.com,2024:veraison/ratsd"`
+	v2CharesResponseMediaType              = `application/cmw+cbor; cmwct="tag:github.com,2026:veraison/ratsd/v2"`
+	charesPath                             = "/ratsd/chares"
+	subattestersPath                       = "/ratsd/subattesters"
+	subattestersResponseMediaType          = "application/json"
+	rejectedPluginsPath                    = "/ratsd/rejected-plugins"
+	rejectedPluginsResponseMediaType       = "application/json"
+	maxResponseSize                  int64 = 16 << 20
+)
+
+// TokenFormat selects the token format requested from POST /ratsd/chares.
+type TokenFormat int
+
+const (
+	// FormatLegacy requests the JSON EAT token with an embedded CMW
+	// collection.
+	FormatLegacy TokenFormat = iota
+	// FormatV2 requests the COSE_Sign1 token over a CBOR CMW collection.
+	FormatV2
+)
+
+func (f TokenFormat) String() string {
+	switch f {
+	case FormatLegacy:
+		return "legacy"
+	case FormatV2:
+		return "v2"
+	default:
+		return fmt.Sprintf("TokenFormat(%d)", int(f))
+	}
+}
+
+func (f TokenFormat) mediaType() (string, error) {
+	switch f {
+	case FormatLegacy:
+		return legacyCharesResponseMediaType, nil
+	case FormatV2:
+		return v2CharesResponseMediaType, nil
+	default:
+		return "", fmt.Errorf("unsupported format %s", f)
+	}
+}
+
+// BasicAuth holds the credentials used for HTTP basic authentication.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Config configures a Client.
+type Config struct {
+	// BaseURL is the URL of the ratsd instance, e.g.,
+	// "https://localhost:8895".
+	BaseURL string
+	// HTTPClient is used to send requests. If nil, a client is created
+	// using TLSConfig.
+	HTTPClient *http.Client
+	// TLSConfig configures server authentication and, if it contains a
+	// client certificate, mutual TLS. It is ignored if HTTPClient is set.
+	TLSConfig *tls.Config
+	// BasicAuth enables HTTP basic authentication.
+	BasicAuth *BasicAuth
+	// BearerToken enables HTTP bearer authentication.
+	BearerToken string
+}
+
+// Client is a client for the ratsd REST API.
+type Client struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	basicAuth   *BasicAuth
+	bearerToken string
+}
+
+// New creates a Client from the supplied configuration.
+func New(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, errors.New("no base URL")
+	}
+
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL: unsupported scheme %q", baseURL.Scheme)
+	}
+
+	if cfg.BasicAuth != nil && cfg.BearerToken != "" {
+		return nil, errors.New("basic and bearer authentication are mutually exclusive")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = cfg.TLSConfig
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	return &Client{
+		baseURL:     baseURL,
+		httpClient:  httpClient,
+		basicAuth:   cfg.BasicAuth,
+		bearerToken: cfg.BearerToken,
+	}, nil
+}
+
+// NewTLSConfig creates a TLS configuration that trusts the CA certificates in
+// the PEM file caFile. If certFile and keyFile are not empty, the key pair they
+// contain is presented to the server for mutual TLS.
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificates: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both client certificate and key must be specified")
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// CharesOptions holds the optional parameters of a Chares call.
+type CharesOptions struct {
+	// Format is the requested token format.
+	Format TokenFormat
+	// AttesterSelection limits the response to the named sub-attesters.
+	AttesterSelection []string
+	// AttesterOptions maps sub-attester names to their options.
+	AttesterOptions map[string]map[string]string
+}
+
+// CharesResult is the decoded response to a Chares call. Exactly one of
+// Legacy and V2 is set, according to the requested format.
+type CharesResult struct {
+	// ContentType is the media type of the response.
+	ContentType string
+	// Raw is the response body as received.
+	Raw []byte
+	// Legacy is the decoded legacy token.
+	Legacy *ratsdtoken.Evidence
+	// V2 is the decoded v2 token. Its signature is not verified.
+	V2 *ratsdtokenv2.Evidence
+}
+
+// Chares requests a conceptual message collection for nonce from ratsd.
+// A nil opts requests a legacy token from all sub-attesters.
+func (c *Client) Chares(ctx context.Context, nonce []byte, opts *CharesOptions) (*CharesResult, error) {
+	if len(nonce) == 0 {
+		return nil, errors.New("empty nonce")
+	}
+
+	if opts == nil {
+		opts = &CharesOptions{}
+	}
+
+	accept, err := opts.Format.mediaType()
+	if err != nil {
+		return nil, err
+	}
+
+	request := ChaResRequest{
+		Nonce:                base64.RawURLEncoding.EncodeToString(nonce),
+		AdditionalProperties: opts.AttesterOptions,
+	}
+	if opts.AttesterSelection != nil {
+		selection := append([]string(nil), opts.AttesterSelection...)
+		request.AttesterSelection = &selection
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, charesPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", charesRequestMediaType)
+	req.Header.Set("Accept", accept)
+
+	ct, data, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameMediaType(ct, accept) {
+		return nil, fmt.Errorf("unexpected response content type %q", ct)
+	}
+
+	result := &CharesResult{ContentType: ct, Raw: data}
+
+	switch opts.Format {
+	case FormatLegacy:
+		e := ratsdtoken.NewEvidence()
+		if err := e.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("decoding legacy token: %w", err)
+		}
+		result.Legacy = e
+	case FormatV2:
+		e := ratsdtokenv2.NewEvidence()
+		if err := e.FromCBOR(data); err != nil {
+			return nil, fmt.Errorf("decoding v2 token: %w", err)
+		}
+		result.V2 = e
+	}
+
+	return result, nil
+}
+
+// Subattesters returns the sub-attesters available on the ratsd host.
+func (c *Client) Subattesters(ctx context.Context) ([]SubAttester, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, subattestersPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", subattestersResponseMediaType)
+
+	ct, data, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameMediaType(ct, subattestersResponseMediaType) {
+		return nil, fmt.Errorf("unexpected response content type %q", ct)
+	}
+
+	var subattesters []SubAttester
+	if err := json.Unmarshal(data, &subattesters); err != nil {
+		return nil, fmt.Errorf("decoding sub-attesters: %w", err)
+	}
+
+	return subattesters, nil
+}
+
+// RejectedPlugins returns the plugins that the secure loader of the ratsd host
+// rejected, and that were therefore not launched.
+func (c *Client) RejectedPlugins(ctx context.Context) ([]RejectedPlugin, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, rejectedPluginsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", rejectedPluginsResponseMediaType)
+
+	ct, data, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameMediaType(ct, rejectedPluginsResponseMediaType) {
+		return nil, fmt.Errorf("unexpected response content type %q", ct)
+	}
+
+	var rejected []RejectedPlugin
+	if err := json.Unmarshal(data, &rejected); err != nil {
+		return nil, fmt.Errorf("decoding rejected plugins: %w", err)
+	}
+
+	return rejected, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	u := c.baseURL.JoinPath(path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	switch {
+	case c.basicAuth != nil:
+		req.SetBasicAuth(c.basicAuth.Username, c.basicAuth.Password)
+	case c.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	return req, nil
+}
+
+// do sends req and returns the content type and body of a successful
+// response. Error responses are converted into problem errors.
+func (c *Client) do(req *http.Request) (string, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	ct := resp.Header.Get("Content-Type")
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, newProblemError(resp.StatusCode, ct, data)
+	}
+
+	return ct, data, nil
+}
+
+// sameMediaType reports whether media types a and b have the same type and
+// parameters.
+func sameMediaType(a, b string) bool {
+	at, ap, err := mime.ParseMediaType(a)
+	if err != nil {
+		return false
+	}
+
+	bt, bp, err := mime.ParseMediaType(b)
+	if err != nil {
+		return false
+	}
+
+	if at != bt || len(ap) != len(bp) {
+		return false
+	}
+
+	for k, v := range ap {
+		if w, ok := bp[k]; !ok || !strings.EqualFold(v, w) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/api"
+	mock_deps "github.com/veraison/ratsd/api/mocks"
+	"github.com/veraison/ratsd/attesters/mocktsm"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/services/log"
+)
+
+var testNonce = []byte("MIDBNH28iioisjPyxxxxxxxxxxxxxxxxMIDBNH28iioisjPyxxxxxxxxxxxxxxxx")
+
+func newRatsdServer(t *testing.T) *httptest.Server {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{"mock-tsm"}).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+	dm.EXPECT().GetRejectedPlugins().Return([]plugin.RejectedPlugin{
+		{Name: "tsm", Path: "/usr/lib/ratsd/tsm.plugin", Reason: "the signature of plugin tsm is not verified by any publisher key"},
+	}).AnyTimes()
+
+	s := api.NewServer(log.Named("test"), dm, "all")
+	ts := httptest.NewServer(api.HandlerWithOptions(s, api.StdHTTPServerOptions{
+		BaseRouter: http.NewServeMux(),
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func newTestClient(t *testing.T, cfg Config) *Client {
+	c, err := New(cfg)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCharesLegacy(t *testing.T) {
+	ts := newRatsdServer(t)
+	c := newTestClient(t, Config{BaseURL: ts.URL})
+
+	res, err := c.Chares(context.Background(), testNonce, &CharesOptions{
+		AttesterSelection: []string{"mock-tsm"},
+		AttesterOptions: map[string]map[string]string{
+			"mock-tsm": {"privilege_level": "1"},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Legacy)
+	assert.Nil(t, res.V2)
+	assert.Equal(t, legacyCharesResponseMediaType, res.ContentType)
+
+	claims, err := res.Legacy.GetClaims()
+	require.NoError(t, err)
+	assert.Equal(t, testNonce, claims.GetEatNonce().GetI(0))
+
+	item, err := claims.GetCMW().GetCollectionItem("mock-tsm")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.TSMReportMediaTypeJSON, item.GetMonadType())
+
+	report := &tokens.TSMReport{}
+	require.NoError(t, report.FromJSON(item.GetMonadValue()))
+	assert.True(t, strings.HasPrefix(string(report.OutBlob), "privlevel: 1\n"))
+}
+
+func TestCharesV2(t *testing.T) {
+	ts := newRatsdServer(t)
+	c := newTestClient(t, Config{BaseURL: ts.URL})
+
+	res, err := c.Chares(context.Background(), testNonce, &CharesOptions{Format: FormatV2})
+	require.NoError(t, err)
+	require.NotNil(t, res.V2)
+	assert.Nil(t, res.Legacy)
+	assert.Equal(t, v2CharesResponseMediaType, res.ContentType)
+
+	claims, err := res.V2.GetClaims()
+	require.NoError(t, err)
+	assert.Equal(t, testNonce, claims.GetEatNonce())
+
+	collection, err := res.V2.GetCollection()
+	require.NoError(t, err)
+	item, err := collection.GetCollectionItem("mock-tsm")
+	require.NoError(t, err)
+	assert.Equal(t, cmw.KindMonad, item.GetKind())
+}
+
+func TestCharesFail(t *testing.T) {
+	c := newTestClient(t, Config{BaseURL: "http://localhost:8895"})
+
+	_, err := c.Chares(context.Background(), nil, nil)
+	assert.EqualError(t, err, "empty nonce")
+
+	_, err = c.Chares(context.Background(), testNonce, &CharesOptions{Format: TokenFormat(7)})
+	assert.EqualError(t, err, "unsupported format TokenFormat(7)")
+}
+
+func TestCharesUnexpectedContentType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, Config{BaseURL: ts.URL})
+
+	_, err := c.Chares(context.Background(), testNonce, nil)
+	assert.EqualError(t, err, `unexpected response content type "application/json"`)
+}
+
+func TestSubattesters(t *testing.T) {
+	ts := newRatsdServer(t)
+	c := newTestClient(t, Config{BaseURL: ts.URL})
+
+	subattesters, err := c.Subattesters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, subattesters, 1)
+	assert.Equal(t, "mock-tsm", subattesters[0].Name)
+	require.NotNil(t, subattesters[0].Options)
+	assert.Equal(t, []Option{
+		{Name: "privilege_level", DataType: String},
+		{Name: "provider", DataType: String},
+		{Name: "service_provider", DataType: String},
+		{Name: "service_guid", DataType: String},
+		{Name: "service_manifest_version", DataType: String},
+	}, *subattesters[0].Options)
+}
+
+func TestRejectedPlugins(t *testing.T) {
+	ts := newRatsdServer(t)
+	c := newTestClient(t, Config{BaseURL: ts.URL})
+
+	rejected, err := c.RejectedPlugins(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []RejectedPlugin{
+		{
+			Name:   "tsm",
+			Path:   "/usr/lib/ratsd/tsm.plugin",
+			Reason: "the signature of plugin tsm is not verified by any publisher key",
+		},
+	}, rejected)
+}
+
+func TestProblemErrors(t *testing.T) {
+	tests := []struct {
+		name, ct, body string
+		status         int
+		check          func(t *testing.T, err error)
+	}{
+		{
+			"invalid request",
+			problemMediaType,
+			`{"type":"tag:github.com,2024:veraison/ratsd:error:invalidrequest",
+			"title":"invalid request","status":400,"detail":"bad nonce"}`,
+			http.StatusBadRequest,
+			func(t *testing.T, err error) {
+				var e *InvalidRequestProblem
+				require.ErrorAs(t, err, &e)
+				assert.Equal(t, "bad nonce", e.Detail)
+				assert.EqualError(t, err, "ratsd: invalid request (status 400): bad nonce")
+			},
+		},
+		{
+			"unauthorized",
+			problemMediaType,
+			`{"type":"tag:github.com,2024:veraison/ratsd:error:unauthorized",
+			"title":"access unauthorized","status":401,"detail":"no such user: foo"}`,
+			http.StatusUnauthorized,
+			func(t *testing.T, err error) {
+				var e *UnauthorizedProblem
+				require.ErrorAs(t, err, &e)
+				assert.Equal(t, "no such user: foo", e.Detail)
+			},
+		},
+		{
+			"not acceptable",
+			problemMediaType,
+			`{"type":"about:blank","title":"Not Acceptable","status":406}`,
+			http.StatusNotAcceptable,
+			func(t *testing.T, err error) {
+				var e *NotAcceptableProblem
+				require.ErrorAs(t, err, &e)
+				assert.EqualError(t, err, "ratsd: Not Acceptable (status 406)")
+			},
+		},
+		{
+			"server error",
+			problemMediaType,
+			`{"type":"about:blank","title":"Internal Server Error","status":500,
+			"detail":"failed to get attestation report from mock-tsm"}`,
+			http.StatusInternalServerError,
+			func(t *testing.T, err error) {
+				var e *ServerProblem
+				require.ErrorAs(t, err, &e)
+				assert.Equal(t, "failed to get attestation report from mock-tsm", e.Detail)
+			},
+		},
+		{
+			"not a problem document",
+			"text/plain",
+			"404 page not found\n",
+			http.StatusNotFound,
+			func(t *testing.T, err error) {
+				var e *Problem
+				require.ErrorAs(t, err, &e)
+				assert.EqualError(t, err, "ratsd: not found (status 404): 404 page not found")
+			},
+		},
+		{
+			"malformed problem document",
+			problemMediaType,
+			"{",
+			http.StatusBadRequest,
+			func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "decoding problem (status 400)")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.ct)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			c := newTestClient(t, Config{BaseURL: ts.URL})
+
+			_, err := c.Chares(context.Background(), testNonce, nil)
+			tt.check(t, err)
+
+			_, err = c.Subattesters(context.Background())
+			tt.check(t, err)
+
+			_, err = c.RejectedPlugins(context.Background())
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected string
+	}{
+		{"none", Config{}, ""},
+		{
+			"basic",
+			Config{BasicAuth: &BasicAuth{Username: "user", Password: "pass"}},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass")),
+		},
+		{"bearer", Config{BearerToken: "token"}, "Bearer token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.BaseURL = ts.URL
+			c := newTestClient(t, tt.cfg)
+
+			_, err := c.Subattesters(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, authorization)
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	serverCert, err := tls.LoadX509KeyPair("../ratsd.crt", "../ratsd.key")
+	require.NoError(t, err)
+
+	caPEM, err := os.ReadFile("../rootCA.crt")
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(caPEM))
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`[{"name":%q}]`, r.TLS.PeerCertificates[0].Subject.CommonName)))
+	}))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	baseURL := strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)
+
+	tlsConfig, err := NewTLSConfig("../rootCA.crt", "../ratsd.crt", "../ratsd.key")
+	require.NoError(t, err)
+	c := newTestClient(t, Config{BaseURL: baseURL, TLSConfig: tlsConfig})
+
+	subattesters, err := c.Subattesters(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []SubAttester{{Name: "ratsd-ratsd.veraison-net"}}, subattesters)
+
+	tlsConfig, err = NewTLSConfig("../rootCA.crt", "", "")
+	require.NoError(t, err)
+	c = newTestClient(t, Config{BaseURL: baseURL, TLSConfig: tlsConfig})
+
+	_, err = c.Subattesters(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNewFail(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected string
+	}{
+		{"no base URL", Config{}, "no base URL"},
+		{"unsupported scheme", Config{BaseURL: "ftp://localhost"},
+			`invalid base URL: unsupported scheme "ftp"`},
+		{"conflicting authentication", Config{
+			BaseURL:     "http://localhost:8895",
+			BasicAuth:   &BasicAuth{Username: "user"},
+			BearerToken: "token",
+		}, "basic and bearer authentication are mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNewTLSConfigFail(t *testing.T) {
+	_, err := NewTLSConfig("nonexistent.crt", "", "")
+	assert.ErrorContains(t, err, "reading CA certificates")
+
+	_, err = NewTLSConfig("", "../ratsd.crt", "")
+	assert.EqualError(t, err, "both client certificate and key must be specified")
+
+	_, err = NewTLSConfig("../ratsd.key", "", "")
+	assert.EqualError(t, err, "no CA certificates found in ../ratsd.key")
+}
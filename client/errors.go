@@ -0,0 +1,82 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const problemMediaType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document returned by ratsd.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	title := p.Title
+	if title == "" {
+		title = strings.ToLower(http.StatusText(p.Status))
+	}
+
+	if p.Detail == "" {
+		return fmt.Sprintf("ratsd: %s (status %d)", title, p.Status)
+	}
+
+	return fmt.Sprintf("ratsd: %s (status %d): %s", title, p.Status, p.Detail)
+}
+
+// InvalidRequestProblem is returned when ratsd rejects a malformed request.
+type InvalidRequestProblem struct{ Problem }
+
+// UnauthorizedProblem is returned when ratsd rejects the supplied credentials.
+type UnauthorizedProblem struct{ Problem }
+
+// NotAcceptableProblem is returned when ratsd cannot produce the requested
+// token format.
+type NotAcceptableProblem struct{ Problem }
+
+// ServerProblem is returned when ratsd or one of its sub-attesters fails.
+type ServerProblem struct{ Problem }
+
+// newProblemError converts an error response into a problem error. Responses
+// that are not problem documents are reported using the status code and body.
+func newProblemError(status int, ct string, data []byte) error {
+	p := Problem{Status: status}
+
+	if mt, _, err := mime.ParseMediaType(ct); err == nil && mt == problemMediaType {
+		if err := json.Unmarshal(data, &p); err != nil {
+			return fmt.Errorf("decoding problem (status %d): %w", status, err)
+		}
+		if p.Status == 0 {
+			p.Status = status
+		}
+	} else {
+		p.Detail = strings.TrimSpace(string(data))
+	}
+
+	switch {
+	case p.Type == string(TagGithubCom2024VeraisonratsdErrorInvalidrequest):
+		return &InvalidRequestProblem{p}
+	case p.Type == string(TagGithubCom2024VeraisonratsdErrorUnauthorized):
+		return &UnauthorizedProblem{p}
+	case p.Status == http.StatusBadRequest:
+		return &InvalidRequestProblem{p}
+	case p.Status == http.StatusUnauthorized:
+		return &UnauthorizedProblem{p}
+	case p.Status == http.StatusNotAcceptable:
+		return &NotAcceptableProblem{p}
+	case p.Status >= http.StatusInternalServerError:
+		return &ServerProblem{p}
+	default:
+		return &p
+	}
+}
@@ -0,0 +1,249 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for BadRequestErrorStatus.
+const (
+	N400 BadRequestErrorStatus = 400
+)
+
+// Defines values for BadRequestErrorTitle.
+const (
+	InvalidRequest BadRequestErrorTitle = "invalid request"
+)
+
+// Defines values for BadRequestErrorType.
+const (
+	TagGithubCom2024VeraisonratsdErrorInvalidrequest BadRequestErrorType = "tag:github.com,2024:veraison/ratsd:error:invalidrequest"
+)
+
+// Defines values for CMWTyp.
+const (
+	ApplicationvndVeraisonTsmReportCbor CMWTyp = "application/vnd.veraison.tsm-report+cbor"
+	ApplicationvndVeraisonTsmReportJson CMWTyp = "application/vnd.veraison.tsm-report+json"
+)
+
+// Defines values for EATEatProfile.
+const (
+	TagGithubCom2024Veraisonratsd EATEatProfile = "tag:github.com,2024:veraison/ratsd"
+)
+
+// Defines values for OptionDataType.
+const (
+	Array   OptionDataType = "array"
+	Boolean OptionDataType = "boolean"
+	Integer OptionDataType = "integer"
+	Number  OptionDataType = "number"
+	Object  OptionDataType = "object"
+	String  OptionDataType = "string"
+)
+
+// Defines values for UnauthorizedErrorStatus.
+const (
+	N401 UnauthorizedErrorStatus = 401
+)
+
+// Defines values for UnauthorizedErrorTitle.
+const (
+	AccessUnauthorized UnauthorizedErrorTitle = "access unauthorized"
+)
+
+// Defines values for UnauthorizedErrorType.
+const (
+	TagGithubCom2024VeraisonratsdErrorUnauthorized UnauthorizedErrorType = "tag:github.com,2024:veraison/ratsd:error:unauthorized"
+)
+
+// BadRequestError defines model for BadRequestError.
+type BadRequestError struct {
+	Detail   *string               `json:"detail,omitempty"`
+	Instance *string               `json:"instance,omitempty"`
+	Status   BadRequestErrorStatus `json:"status"`
+	Title    BadRequestErrorTitle  `json:"title"`
+	Type     BadRequestErrorType   `json:"type"`
+}
+
+// BadRequestErrorStatus defines model for BadRequestError.Status.
+type BadRequestErrorStatus float32
+
+// BadRequestErrorTitle defines model for BadRequestError.Title.
+type BadRequestErrorTitle string
+
+// BadRequestErrorType defines model for BadRequestError.Type.
+type BadRequestErrorType string
+
+// CMW defines model for CMW.
+type CMW struct {
+	Typ CMWTyp `json:"typ"`
+	Val string `json:"val"`
+}
+
+// CMWTyp defines model for CMW.Typ.
+type CMWTyp string
+
+// ChaResRequest defines model for ChaResRequest.
+type ChaResRequest struct {
+	AttesterSelection    *[]string                    `json:"attester-selection,omitempty"`
+	Nonce                string                       `json:"nonce"`
+	AdditionalProperties map[string]map[string]string `json:"-"`
+}
+
+// EAT defines model for EAT.
+type EAT struct {
+	EatProfile  EATEatProfile `json:"eat_profile"`
+	NestedToken CMW           `json:"nested-token"`
+}
+
+// EATEatProfile defines model for EAT.EatProfile.
+type EATEatProfile string
+
+// Format defines model for Format.
+type Format struct {
+	ContentType string `json:"content-type"`
+	NonceSize   uint32 `json:"nonce-size"`
+
+	// Transcoded The format is derived by ratsd from another format supported by the sub-attester.
+	Transcoded *bool `json:"transcoded,omitempty"`
+}
+
+// Option defines model for Option.
+type Option struct {
+	DataType OptionDataType `json:"data-type"`
+	Name     string         `json:"name"`
+}
+
+// OptionDataType defines model for Option.DataType.
+type OptionDataType string
+
+// RejectedPlugin defines model for RejectedPlugin.
+type RejectedPlugin struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// SubAttester defines model for SubAttester.
+type SubAttester struct {
+	Formats *[]Format `json:"formats,omitempty"`
+	Name    string    `json:"name"`
+	Options *[]Option `json:"options,omitempty"`
+}
+
+// UnauthorizedError defines model for UnauthorizedError.
+type UnauthorizedError struct {
+	Detail   *string                 `json:"detail,omitempty"`
+	Instance *string                 `json:"instance,omitempty"`
+	Status   UnauthorizedErrorStatus `json:"status"`
+	Title    UnauthorizedErrorTitle  `json:"title"`
+	Type     UnauthorizedErrorType   `json:"type"`
+}
+
+// UnauthorizedErrorStatus defines model for UnauthorizedError.Status.
+type UnauthorizedErrorStatus float32
+
+// UnauthorizedErrorTitle defines model for UnauthorizedError.Title.
+type UnauthorizedErrorTitle string
+
+// UnauthorizedErrorType defines model for UnauthorizedError.Type.
+type UnauthorizedErrorType string
+
+// ChaResRequestParametersAccept defines model for ChaResRequestParameters.accept.
+type ChaResRequestParametersAccept = string
+
+// RatsdCharesParams defines parameters for RatsdChares.
+type RatsdCharesParams struct {
+	Accept *ChaResRequestParametersAccept `json:"accept,omitempty"`
+}
+
+// RatsdCharesApplicationVndVeraisonCharesPlusJSONRequestBody defines body for RatsdChares for application/vnd.veraison.chares+json ContentType.
+type RatsdCharesApplicationVndVeraisonCharesPlusJSONRequestBody = ChaResRequest
+
+// Getter for additional properties for ChaResRequest. Returns the specified
+// element and whether it was found
+func (a ChaResRequest) Get(fieldName string) (value map[string]string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for ChaResRequest
+func (a *ChaResRequest) Set(fieldName string, value map[string]string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for ChaResRequest to handle AdditionalProperties
+func (a *ChaResRequest) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["attester-selection"]; found {
+		err = json.Unmarshal(raw, &a.AttesterSelection)
+		if err != nil {
+			return fmt.Errorf("error reading 'attester-selection': %w", err)
+		}
+		delete(object, "attester-selection")
+	}
+
+	if raw, found := object["nonce"]; found {
+		err = json.Unmarshal(raw, &a.Nonce)
+		if err != nil {
+			return fmt.Errorf("error reading 'nonce': %w", err)
+		}
+		delete(object, "nonce")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal map[string]string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for ChaResRequest to handle AdditionalProperties
+func (a ChaResRequest) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.AttesterSelection != nil {
+		object["attester-selection"], err = json.Marshal(a.AttesterSelection)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'attester-selection': %w", err)
+		}
+	}
+
+	object["nonce"], err = json.Marshal(a.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'nonce': %w", err)
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
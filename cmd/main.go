@@ -5,10 +5,13 @@ package main
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/veraison/ratsd/api"
 	"github.com/veraison/ratsd/auth"
+	"github.com/veraison/ratsd/measure"
 	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/self"
 	"github.com/veraison/services/config"
 	"github.com/veraison/services/log"
 )
@@ -18,13 +21,19 @@ var (
 )
 
 type cfg struct {
-	ListenAddr  string `mapstructure:"listen-addr" valid:"dialstring"`
-	Protocol    string `mapstructure:"protocol" valid:"in(http|https)"`
-	Cert        string `mapstructure:"cert" config:"zerodefault"`
-	CertKey     string `mapstructure:"cert-key" config:"zerodefault"`
-	PluginDir   string `mapstructure:"plugin-dir" config:"zerodefault"`
-	ListOptions string `mapstructure:"list-options" valid:"in(all|selected)"`
-	SecureLoader  bool   `mapstructure:"secure-loader" config:"zerodefault"`
+	ListenAddr       string `mapstructure:"listen-addr" valid:"dialstring"`
+	Protocol         string `mapstructure:"protocol" valid:"in(http|https)"`
+	Cert             string `mapstructure:"cert" config:"zerodefault"`
+	CertKey          string `mapstructure:"cert-key" config:"zerodefault"`
+	PluginDir        string `mapstructure:"plugin-dir" config:"zerodefault"`
+	ListOptions      string `mapstructure:"list-options" valid:"in(all|selected)"`
+	SecureLoader     bool   `mapstructure:"secure-loader" config:"zerodefault"`
+	SkipBadChecksums bool   `mapstructure:"skip-bad-checksums" config:"zerodefault"`
+	SignedPlugins    bool   `mapstructure:"signed-plugins" config:"zerodefault"`
+	SelfAttester     bool   `mapstructure:"self-attester" config:"zerodefault"`
+	SelfAnchor       string `mapstructure:"self-anchor" config:"zerodefault" valid:"in(tsm)"`
+	MeasurePlugins   string `mapstructure:"measure-plugins" config:"zerodefault" valid:"in(rtmr|pcr)"`
+	MeasureIndex     uint8  `mapstructure:"measure-index" config:"zerodefault"`
 }
 
 func (o cfg) Validate() error {
@@ -32,10 +41,23 @@ func (o cfg) Validate() error {
 		return errors.New(`both cert and cert-key must be specified when protocol is "https"`)
 	}
 
+	if o.SkipBadChecksums && !o.SecureLoader {
+		return errors.New(`skip-bad-checksums requires secure-loader to be enabled`)
+	}
+
+	if o.SelfAnchor != "" && !o.SelfAttester {
+		return errors.New(`self-anchor requires self-attester to be enabled`)
+	}
+
+	if o.MeasureIndex != 0 && o.MeasurePlugins == "" {
+		return errors.New(`measure-index requires measure-plugins to be set`)
+	}
+
 	return nil
 }
 
 func main() {
+	startTime := time.Now()
 	config.CmdLine()
 
 	v, err := config.ReadRawConfig(*config.File, false)
@@ -89,6 +111,35 @@ func main() {
 		if err := pluginLoader.SetChecksum(subs["plugins"]); err != nil {
 			log.Fatalf("secure loader failed to set plugin checksum: %v", err)
 		}
+		pluginLoader.SetSkipBadChecksums(cfg.SkipBadChecksums)
+	}
+	if cfg.SignedPlugins {
+		subs, err := config.GetSubs(v, "publishers")
+		if err != nil {
+			log.Fatalf("failed to enable plugin signature verification: %v", err)
+		}
+		if len(subs["publishers"].AllKeys()) == 0 {
+			log.Fatal("signed-plugins requires at least one key in the publishers section")
+		}
+		if err := pluginLoader.SetPublisherKeys(subs["publishers"]); err != nil {
+			log.Fatalf("secure loader failed to set publisher keys: %v", err)
+		}
+	}
+
+	var pluginLog *measure.Log
+	if cfg.MeasurePlugins != "" {
+		index := cfg.MeasureIndex
+		if index == 0 {
+			index = measure.DefaultIndex(cfg.MeasurePlugins)
+		}
+
+		register, err := measure.NewRegister(cfg.MeasurePlugins, index)
+		if err != nil {
+			log.Fatalf("could not create the plugin measurement register: %v", err)
+		}
+
+		pluginLog = measure.New(register)
+		pluginLoader.SetMeasurer(pluginLog)
 	}
 
 	pluginManager, err := plugin.CreateGoPluginManagerWithLoader(
@@ -98,9 +149,40 @@ func main() {
 		log.Fatalf("could not create the plugin manager: %v", err)
 	}
 
-	log.Info("Loaded sub-attesters:", pluginManager.GetPluginList())
+	builtinManager := plugin.NewBuiltinManager(pluginManager)
+	if cfg.SelfAttester {
+		selfCfg := self.Config{
+			ConfigFile: *config.File,
+			Plugins:    pluginManager.GetPluginInfo(),
+			StartTime:  startTime,
+		}
+
+		if cfg.SelfAnchor != "" {
+			selfCfg.Anchor, err = self.NewAnchor(cfg.SelfAnchor)
+			if err != nil {
+				log.Fatalf("could not create the ratsd-self anchor: %v", err)
+			}
+		}
+
+		selfAttester, err := self.New(selfCfg)
+		if err != nil {
+			log.Fatalf("could not create the ratsd-self attester: %v", err)
+		}
+
+		if err := builtinManager.Register(self.Name, selfAttester); err != nil {
+			log.Fatalf("could not register the ratsd-self attester: %v", err)
+		}
+	}
+
+	if pluginLog != nil {
+		if err := builtinManager.Register(measure.Name, pluginLog); err != nil {
+			log.Fatalf("could not register the plugin-log attester: %v", err)
+		}
+	}
+
+	log.Info("Loaded sub-attesters:", builtinManager.GetPluginList())
 
-	svr := api.NewServer(log.Named("api"), pluginManager, cfg.ListOptions)
+	svr := api.NewServer(log.Named("api"), builtinManager, cfg.ListOptions)
 	r := http.NewServeMux()
 	options := api.StdHTTPServerOptions{
 		BaseRouter:  r,
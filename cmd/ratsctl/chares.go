@@ -0,0 +1,120 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/veraison/ratsd/client"
+)
+
+const defaultNonceSize = 64
+
+func runChares(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var (
+		conn      connFlags
+		selection listFlag
+		nonceB64  string
+		nonceSize int
+		format    string
+		output    string
+	)
+	options := optionsFlag{}
+
+	fs := newFlagSet("chares", "", stderr)
+	conn.register(fs)
+	fs.StringVar(&nonceB64, "nonce", "", "base64url-encoded nonce (default: random nonce of -nonce-size bytes)")
+	fs.IntVar(&nonceSize, "nonce-size", defaultNonceSize, "size in bytes of the generated nonce")
+	fs.Var(&selection, "select", "comma-separated sub-attesters to query (repeatable)")
+	fs.Var(options, "option", "sub-attester option as attester.name=value (repeatable)")
+	fs.StringVar(&format, "format", "legacy", "token format: legacy or v2")
+	fs.StringVar(&output, "o", "", "write the raw token to this file instead of printing it decoded")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	nonce, err := getNonce(nonceB64, nonceSize)
+	if err != nil {
+		return err
+	}
+
+	opts := &client.CharesOptions{
+		AttesterSelection: selection,
+		AttesterOptions:   options,
+	}
+
+	switch format {
+	case "legacy":
+		opts.Format = client.FormatLegacy
+	case "v2":
+		opts.Format = client.FormatV2
+	default:
+		return fmt.Errorf("unsupported format %q, want legacy or v2", format)
+	}
+
+	c, err := conn.newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), conn.timeout)
+	defer cancel()
+
+	res, err := c.Chares(ctx, nonce, opts)
+	if err != nil {
+		return err
+	}
+
+	if output != "" {
+		return os.WriteFile(output, res.Raw, 0o644)
+	}
+
+	var view *tokenView
+	if res.V2 != nil {
+		view, err = viewV2(res.V2)
+	} else {
+		view, err = viewLegacy(res.Legacy)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printJSON(stdout, view)
+}
+
+func getNonce(nonceB64 string, size int) ([]byte, error) {
+	if nonceB64 != "" {
+		nonce, err := base64.RawURLEncoding.DecodeString(nonceB64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce: %w", err)
+		}
+		return nonce, nil
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid nonce size %d", size)
+	}
+
+	nonce := make([]byte, size)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/veraison/cmw"
+	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
+	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// tokenView is the printable form of a decoded RATSD token.
+type tokenView struct {
+	Format              string              `json:"format"`
+	EatProfile          string              `json:"eat_profile"`
+	EatNonce            tokens.BinaryString `json:"eat_nonce"`
+	OEMID               int64               `json:"oemid,omitempty"`
+	SWName              string              `json:"swname,omitempty"`
+	SWVersion           string              `json:"swversion,omitempty"`
+	NonceAdjustFunction string              `json:"nonce_adjust_function,omitempty"`
+	NonceAdjustMap      map[string]uint     `json:"nonce_adjust_map,omitempty"`
+	Signed              *bool               `json:"signed,omitempty"`
+	Signer              string              `json:"signer,omitempty"`
+	CMW                 *cmwView            `json:"cmw"`
+}
+
+// cmwView is the printable form of a CMW record or collection. Known
+// evidence formats are decoded, other record values are shown as is.
+type cmwView struct {
+	Type           string              `json:"type,omitempty"`
+	Indicators     string              `json:"indicators,omitempty"`
+	Value          tokens.BinaryString `json:"value,omitempty"`
+	TSMReport      *tsmReportView      `json:"tsm-report,omitempty"`
+	CollectionType string              `json:"collection-type,omitempty"`
+	Items          map[string]*cmwView `json:"items,omitempty"`
+}
+
+// tsmReportView is the printable form of a TSM report, with the binary
+// fields hex encoded.
+type tsmReportView struct {
+	Provider        string `json:"provider"`
+	ServiceProvider string `json:"service_provider,omitempty"`
+	OutBlob         string `json:"outblob"`
+	AuxBlob         string `json:"auxblob,omitempty"`
+	ManifestBlob    string `json:"manifestblob,omitempty"`
+}
+
+func runDecode(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var format string
+
+	fs := newFlagSet("decode", " [token-file]", stderr)
+	fs.StringVar(&format, "format", "auto", "token format: auto, legacy or v2")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args()[1:])
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if fs.NArg() == 0 || fs.Arg(0) == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return fmt.Errorf("reading token: %w", err)
+	}
+
+	view, err := decodeToken(data, format)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(stdout, view)
+}
+
+// decodeToken decodes a legacy or v2 RATSD token. With format "auto", JSON
+// input is decoded as a legacy token and anything else as a v2 token.
+func decodeToken(data []byte, format string) (*tokenView, error) {
+	if format == "auto" {
+		format = "v2"
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			format = "legacy"
+		}
+	}
+
+	switch format {
+	case "legacy":
+		e := ratsdtoken.NewEvidence()
+		if err := e.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("decoding legacy token: %w", err)
+		}
+		return viewLegacy(e)
+	case "v2":
+		e := ratsdtokenv2.NewEvidence()
+		if err := e.FromCBOR(data); err != nil {
+			return nil, fmt.Errorf("decoding v2 token: %w", err)
+		}
+		return viewV2(e)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, want auto, legacy or v2", format)
+	}
+}
+
+func viewLegacy(e *ratsdtoken.Evidence) (*tokenView, error) {
+	claims, err := e.GetClaims()
+	if err != nil {
+		return nil, err
+	}
+
+	view := &tokenView{
+		Format:              "legacy",
+		NonceAdjustFunction: claims.GetNonceAdjustFn(),
+		NonceAdjustMap:      claims.GetNonceAdjustMap(),
+	}
+
+	if profile := claims.GetEatProfile(); profile != nil {
+		if view.EatProfile, err = profile.Get(); err != nil {
+			return nil, fmt.Errorf("eat_profile: %w", err)
+		}
+	}
+
+	if nonce := claims.GetEatNonce(); nonce != nil && nonce.Len() > 0 {
+		view.EatNonce = nonce.GetI(0)
+	}
+
+	collection := claims.GetCMW()
+	if collection == nil {
+		return nil, errors.New("missing CMW collection")
+	}
+
+	if view.CMW, err = viewCMW(collection); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func viewV2(e *ratsdtokenv2.Evidence) (*tokenView, error) {
+	claims, err := e.GetClaims()
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := e.GetCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	signed := len(e.GetSignature()) > 0
+	view := &tokenView{
+		Format:              "v2",
+		EatProfile:          claims.GetEatProfile(),
+		EatNonce:            claims.GetEatNonce(),
+		OEMID:               claims.GetOEMID(),
+		SWName:              claims.GetSWName(),
+		SWVersion:           claims.GetSWVersion(),
+		NonceAdjustFunction: claims.GetNonceAdjustFn(),
+		NonceAdjustMap:      claims.GetNonceAdjustMap(),
+		Signed:              &signed,
+	}
+
+	if e.SigningCert != nil {
+		view.Signer = e.SigningCert.Subject.String()
+	}
+
+	if view.CMW, err = viewCMW(&collection); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func viewCMW(c *cmw.CMW) (*cmwView, error) {
+	switch c.GetKind() {
+	case cmw.KindMonad:
+		view := &cmwView{
+			Type:       c.GetMonadType(),
+			Indicators: c.GetMonadIndicator().String(),
+		}
+
+		report, err := decodeTSMReport(c.GetMonadType(), c.GetMonadValue())
+		if err != nil {
+			return nil, err
+		}
+
+		if report != nil {
+			view.TSMReport = report
+		} else {
+			view.Value = c.GetMonadValue()
+		}
+
+		return view, nil
+	case cmw.KindCollection:
+		ctyp, err := c.GetCollectionType()
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := c.GetCollectionMeta()
+		if err != nil {
+			return nil, err
+		}
+
+		view := &cmwView{
+			CollectionType: ctyp,
+			Items:          make(map[string]*cmwView, len(meta)),
+		}
+
+		for _, m := range meta {
+			item, err := c.GetCollectionItem(m.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			key := fmt.Sprint(m.Key)
+			if view.Items[key], err = viewCMW(item); err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+		}
+
+		return view, nil
+	default:
+		return nil, fmt.Errorf("unsupported CMW kind %s", c.GetKind())
+	}
+}
+
+// decodeTSMReport decodes value if mediaType is one of the TSM report media
+// types, and returns nil otherwise.
+func decodeTSMReport(mediaType string, value []byte) (*tsmReportView, error) {
+	report := &tokens.TSMReport{}
+
+	switch mediaType {
+	case tokens.TSMReportMediaTypeJSON:
+		if err := report.FromJSON(value); err != nil {
+			return nil, fmt.Errorf("decoding TSM report: %w", err)
+		}
+	case tokens.TSMReportMediaTypeCBOR:
+		if err := report.FromCBOR(value); err != nil {
+			return nil, fmt.Errorf("decoding TSM report: %w", err)
+		}
+	default:
+		return nil, nil
+	}
+
+	view := &tsmReportView{
+		Provider:     report.Provider,
+		OutBlob:      hex.EncodeToString(report.OutBlob),
+		AuxBlob:      hex.EncodeToString(report.AuxBlob),
+		ManifestBlob: hex.EncodeToString(report.ManifestBlob),
+	}
+	if report.ServiceProvider != nil {
+		view.ServiceProvider = *report.ServiceProvider
+	}
+
+	return view, nil
+}
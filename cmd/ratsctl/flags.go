@@ -0,0 +1,130 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/veraison/ratsd/client"
+)
+
+const defaultURL = "https://localhost:8895"
+
+// connFlags holds the flags shared by the commands that talk to ratsd.
+type connFlags struct {
+	url      string
+	caFile   string
+	certFile string
+	keyFile  string
+	user     string
+	password string
+	token    string
+	timeout  time.Duration
+}
+
+func (o *connFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.url, "url", defaultURL, "base URL of ratsd")
+	fs.StringVar(&o.caFile, "ca", "", "PEM file with the CA certificates trusted for the ratsd server")
+	fs.StringVar(&o.certFile, "cert", "", "PEM file with the client certificate for mutual TLS")
+	fs.StringVar(&o.keyFile, "key", "", "PEM file with the client key for mutual TLS")
+	fs.StringVar(&o.user, "user", "", "user name for basic authentication")
+	fs.StringVar(&o.password, "password", "", "password for basic authentication (default $RATSCTL_PASSWORD)")
+	fs.StringVar(&o.token, "token", "", "token for bearer authentication (default $RATSCTL_TOKEN)")
+	fs.DurationVar(&o.timeout, "timeout", 30*time.Second, "request timeout")
+}
+
+func (o *connFlags) newClient() (*client.Client, error) {
+	cfg := client.Config{BaseURL: o.url}
+
+	if o.caFile != "" || o.certFile != "" || o.keyFile != "" {
+		tlsConfig, err := client.NewTLSConfig(o.caFile, o.certFile, o.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.TLSConfig = tlsConfig
+	}
+
+	if o.user != "" {
+		password := o.password
+		if password == "" {
+			password = os.Getenv("RATSCTL_PASSWORD")
+		}
+		cfg.BasicAuth = &client.BasicAuth{Username: o.user, Password: password}
+	}
+
+	cfg.BearerToken = o.token
+	if cfg.BearerToken == "" && cfg.BasicAuth == nil {
+		cfg.BearerToken = os.Getenv("RATSCTL_TOKEN")
+	}
+
+	return client.New(cfg)
+}
+
+// listFlag collects a comma-separated list from one or more occurrences of a
+// flag.
+type listFlag []string
+
+func (o *listFlag) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *listFlag) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return errors.New("empty list item")
+		}
+		*o = append(*o, item)
+	}
+	return nil
+}
+
+// optionsFlag collects sub-attester options given as attester.name=value.
+type optionsFlag map[string]map[string]string
+
+func (o optionsFlag) String() string {
+	var options []string
+	for attester, kv := range o {
+		for k, v := range kv {
+			options = append(options, fmt.Sprintf("%s.%s=%s", attester, k, v))
+		}
+	}
+	return strings.Join(options, ",")
+}
+
+func (o optionsFlag) Set(v string) error {
+	kv, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("%q: want attester.name=value", v)
+	}
+
+	attester, name, ok := strings.Cut(kv, ".")
+	if !ok || attester == "" || name == "" {
+		return fmt.Errorf("%q: want attester.name=value", v)
+	}
+
+	if o[attester] == nil {
+		o[attester] = make(map[string]string)
+	}
+	o[attester][name] = value
+
+	return nil
+}
+
+// newFlagSet returns a flag set for the named command that reports errors
+// to output instead of exiting.
+func newFlagSet(name, args string, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ratsctl %s [flags]%s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
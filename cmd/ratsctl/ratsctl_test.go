@@ -0,0 +1,131 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/api"
+	mock_deps "github.com/veraison/ratsd/api/mocks"
+	"github.com/veraison/ratsd/attesters/mocktsm"
+	"github.com/veraison/ratsd/tokens"
+	"github.com/veraison/services/log"
+)
+
+const testNonce = "TUlEQk5IMjhpaW9pc2pQeXh4eHh4eHh4eHh4eHh4eHhNSURCTkgyOGlpb2lzalB5eHh4eHh4eHh4eHh4eHh4eA"
+
+func newRatsdServer(t *testing.T) *httptest.Server {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	dm := mock_deps.NewMockIManager(ctrl)
+	dm.EXPECT().GetPluginList().Return([]string{"mock-tsm"}).AnyTimes()
+	dm.EXPECT().LookupByName("mock-tsm").Return(mocktsm.GetPlugin(), nil).AnyTimes()
+
+	s := api.NewServer(log.Named("test"), dm, "all")
+	ts := httptest.NewServer(api.HandlerWithOptions(s, api.StdHTTPServerOptions{
+		BaseRouter: http.NewServeMux(),
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func runCommand(t *testing.T, stdin []byte, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, bytes.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCharesAndDecode(t *testing.T) {
+	ts := newRatsdServer(t)
+
+	for _, format := range []string{"legacy", "v2"} {
+		t.Run(format, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, nil, "chares", "-url", ts.URL,
+				"-nonce", testNonce, "-format", format,
+				"-select", "mock-tsm", "-option", "mock-tsm.privilege_level=2")
+			require.Equal(t, 0, code, stderr)
+
+			var view tokenView
+			require.NoError(t, json.Unmarshal([]byte(stdout), &view))
+			assert.Equal(t, format, view.Format)
+			assert.Equal(t, map[string]uint{"mock-tsm": 64}, view.NonceAdjustMap)
+
+			item := view.CMW.Items["mock-tsm"]
+			require.NotNil(t, item)
+			assert.Equal(t, tokens.TSMReportMediaTypeJSON, item.Type)
+			require.NotNil(t, item.TSMReport)
+			assert.Equal(t, "fake\n", item.TSMReport.Provider)
+
+			outblob, err := hex.DecodeString(item.TSMReport.OutBlob)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(outblob), "privlevel: 2\n"))
+
+			out := filepath.Join(t.TempDir(), "token")
+			code, _, stderr = runCommand(t, nil, "chares", "-url", ts.URL,
+				"-nonce", testNonce, "-format", format,
+				"-select", "mock-tsm", "-option", "mock-tsm.privilege_level=2", "-o", out)
+			require.Equal(t, 0, code, stderr)
+
+			code, decoded, stderr := runCommand(t, nil, "decode", out)
+			require.Equal(t, 0, code, stderr)
+			assert.JSONEq(t, stdout, decoded)
+		})
+	}
+}
+
+func TestSubattesters(t *testing.T) {
+	ts := newRatsdServer(t)
+
+	code, stdout, stderr := runCommand(t, nil, "subattesters", "-url", ts.URL)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"name": "mock-tsm"`)
+}
+
+func TestDecodeStdin(t *testing.T) {
+	code, _, stderr := runCommand(t, []byte("{}"), "decode")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "ratsctl decode: decoding legacy token")
+
+	code, _, stderr = runCommand(t, []byte{0xa0}, "decode", "-")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "ratsctl decode: decoding v2 token")
+}
+
+func TestUsageErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"no command", nil, 2, "Usage: ratsctl <command> [flags]"},
+		{"unknown command", []string{"attest"}, 2, `unknown command "attest"`},
+		{"invalid format", []string{"chares", "-format", "v3"}, 1,
+			`unsupported format "v3", want legacy or v2`},
+		{"invalid nonce", []string{"chares", "-nonce", "!"}, 1, "invalid nonce"},
+		{"invalid option", []string{"chares", "-option", "privilege_level=1"}, 1,
+			`"privilege_level=1": want attester.name=value`},
+		{"stray argument", []string{"subattesters", "mock-tsm"}, 1,
+			"unexpected arguments: [mock-tsm]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCommand(t, nil, tt.args...)
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, stderr, tt.stderr)
+		})
+	}
+}
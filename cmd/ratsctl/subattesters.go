@@ -0,0 +1,38 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+func runSubattesters(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var conn connFlags
+
+	fs := newFlagSet("subattesters", "", stderr)
+	conn.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	c, err := conn.newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), conn.timeout)
+	defer cancel()
+
+	subattesters, err := c.Subattesters(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(stdout, subattesters)
+}
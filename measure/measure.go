@@ -0,0 +1,190 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package measure extends the digests of the plugins loaded by ratsd into a
+// runtime measurement register, e.g., a TDX RTMR or a TPM PCR, and keeps the
+// Canonical Event Log (CEL) of the extensions. The log is returned by the
+// built-in plugin-log sub-attester, so that verifiers replay it against the
+// register reported by the TEE evidence.
+package measure
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	// Name is the name of the plugin-log sub-attester
+	Name = "plugin-log"
+
+	nonceSize = 32
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    Name,
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.CELMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.CELMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Log measures plugins into a register and keeps the CEL of the
+// measurements. It implements plugin.Measurer, and plugin.IPluggable as the
+// plugin-log sub-attester, running in the ratsd process.
+type Log struct {
+	register Register
+
+	mu      sync.Mutex
+	raw     []byte
+	records []tokens.CELRecord
+}
+
+// New returns a log of the measurements extended into register
+func New(register Register) *Log {
+	return &Log{register: register}
+}
+
+// Measure extends the register with the digests of the CEL content
+// describing the plugin executable at path, whose SHA-256 digest is digest,
+// and records the extension in the log
+func (o *Log) Measure(path string, digest []byte) error {
+	content, err := (&tokens.CELPluginContent{Path: path, Digest: digest}).ToTLV()
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	record := tokens.CELRecord{
+		RecNum:      uint64(len(o.records)),
+		IndexType:   o.register.IndexType(),
+		Index:       o.register.Index(),
+		ContentType: tokens.CELContentRatsdPlugin,
+		Content:     content,
+	}
+
+	for _, alg := range o.register.Algs() {
+		h, err := alg.Hash()
+		if err != nil {
+			return err
+		}
+
+		d := h.New()
+		d.Write(content)
+		eventDigest := d.Sum(nil)
+
+		if err := o.register.Extend(alg, eventDigest); err != nil {
+			return fmt.Errorf("failed to extend register %d: %w", o.register.Index(), err)
+		}
+
+		record.Digests = append(record.Digests, tokens.EventDigest{
+			AlgID:  uint16(alg),
+			Digest: eventDigest,
+		})
+	}
+
+	encoded, err := record.ToTLV()
+	if err != nil {
+		return err
+	}
+
+	o.raw = append(o.raw, encoded...)
+	o.records = append(o.records, record)
+
+	return nil
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (o *Log) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (o *Log) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (o *Log) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+// GetEvidence returns the CEL of the measurements, echoing the nonce
+func (o *Log) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the plugin-log attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in plugin-log attester matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	o.mu.Lock()
+	out := &tokens.CEL{
+		Nonce:   in.Nonce,
+		Raw:     slices.Clone(o.raw),
+		Records: slices.Clone(o.records),
+	}
+	o.mu.Unlock()
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.CELMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode the plugin log as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	return &compositor.EvidenceOut{
+		Status:     statusSucceeded,
+		Evidence:   outEncoded,
+		StatusCode: http.StatusOK,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package measure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var testNonce = bytes.Repeat([]byte{0xab}, nonceSize)
+
+// failingRegister is a register whose extension fails
+type failingRegister struct {
+	*MockRegister
+}
+
+func (failingRegister) Extend(tpm2.TPMAlgID, []byte) error {
+	return errors.New("no TEE")
+}
+
+func newTestLog(t *testing.T) (*Log, *MockRegister) {
+	t.Helper()
+
+	r := NewMockRegister(tokens.CELIndexCCMR, DefaultRTMR, tpm2.TPMAlgSHA384)
+	l := New(r)
+
+	for _, path := range []string{"/usr/lib/ratsd/tsm.plugin", "/usr/lib/ratsd/tpm.plugin"} {
+		digest := sha256.Sum256([]byte(path))
+		require.NoError(t, l.Measure(path, digest[:]))
+	}
+
+	return l, r
+}
+
+func getLog(t *testing.T, l *Log, contentType string) *tokens.CEL {
+	t.Helper()
+
+	out := l.GetEvidence(&compositor.EvidenceIn{ContentType: contentType, Nonce: testNonce})
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+
+	cel := &tokens.CEL{}
+	if contentType == tokens.CELMediaTypeCBOR {
+		require.NoError(t, cel.FromCBOR(out.Evidence))
+	} else {
+		require.NoError(t, cel.FromJSON(out.Evidence))
+	}
+	assert.Equal(t, testNonce, []byte(cel.Nonce))
+
+	return cel
+}
+
+func Test_Measure(t *testing.T) {
+	l, r := newTestLog(t)
+
+	for _, ct := range []string{tokens.CELMediaTypeJSON, tokens.CELMediaTypeCBOR} {
+		cel := getLog(t, l, ct)
+
+		records, err := tokens.ParseCEL(cel.Raw)
+		require.NoError(t, err)
+		assert.Equal(t, cel.Records, records)
+		require.Len(t, records, 2)
+
+		content := &tokens.CELPluginContent{}
+		require.NoError(t, content.FromTLV(records[1].Content))
+		assert.Equal(t, "/usr/lib/ratsd/tpm.plugin", content.Path)
+		assert.Equal(t, uint64(1), records[1].RecNum)
+		assert.Equal(t, uint8(tokens.CELContentRatsdPlugin), records[1].ContentType)
+
+		// the log replays to the value of the register
+		registers, err := cel.Replay(tpm2.TPMAlgSHA384)
+		require.NoError(t, err)
+		assert.Equal(t, map[uint32][]byte{DefaultRTMR: r.Value(tpm2.TPMAlgSHA384)}, registers)
+	}
+}
+
+func Test_Measure_empty(t *testing.T) {
+	cel := getLog(t, New(NewMockRegister(tokens.CELIndexPCR, DefaultPCR, tpm2.TPMAlgSHA256)), tokens.CELMediaTypeJSON)
+	assert.Empty(t, cel.Raw)
+	assert.Empty(t, cel.Records)
+}
+
+func Test_Measure_Fail(t *testing.T) {
+	l := New(failingRegister{NewMockRegister(tokens.CELIndexCCMR, DefaultRTMR, tpm2.TPMAlgSHA384)})
+
+	err := l.Measure("/usr/lib/ratsd/tsm.plugin", make([]byte, sha256.Size))
+	assert.EqualError(t, err, "failed to extend register 3: no TEE")
+
+	// failed extensions are not recorded
+	assert.Empty(t, getLog(t, l, tokens.CELMediaTypeJSON).Records)
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	l, _ := newTestLog(t)
+	assert.Equal(t, expected, l.GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	l, _ := newTestLog(t)
+	assert.Equal(t, expected, l.GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	l, _ := newTestLog(t)
+	assert.Equal(t, expected, l.GetSupportedFormats())
+}
+
+func Test_GetEvidence_Fail(t *testing.T) {
+	l, _ := newTestLog(t)
+
+	out := l.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.CELMediaTypeJSON, Nonce: []byte("nonce")})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the plugin-log attester should be 32, got 5", out.Status.Error)
+
+	out = l.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.EventLogMediaTypeJSON, Nonce: testNonce})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in plugin-log attester matches the requested format", out.Status.Error)
+}
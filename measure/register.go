@@ -0,0 +1,234 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package measure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/linuxtpm"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// Names of the registers, see NewRegister
+const (
+	// RegisterRTMR is a TDX RTMR
+	RegisterRTMR = "rtmr"
+	// RegisterPCR is a TPM PCR
+	RegisterPCR = "pcr"
+)
+
+const (
+	// DefaultRTMR is the RTMR left to applications by the TDX guest
+	// firmware and kernel
+	DefaultRTMR = 3
+	// DefaultPCR is the PCR left to applications by the PC Client platform
+	// TPM profile
+	DefaultPCR = 23
+
+	maxRTMR = 3
+	maxPCR  = 23
+
+	defaultMeasurements = "/sys/class/misc/tdx_guest/measurements"
+	defaultDevice       = "/dev/tpmrm0"
+)
+
+// Register is a measurement register
+type Register interface {
+	// IndexType returns the type of the register, tokens.CELIndexPCR or
+	// tokens.CELIndexCCMR
+	IndexType() uint8
+	// Index returns the index of the register
+	Index() uint8
+	// Algs returns the algorithms of the banks of the register
+	Algs() []tpm2.TPMAlgID
+	// Extend extends the bank of the given algorithm with digest
+	Extend(alg tpm2.TPMAlgID, digest []byte) error
+}
+
+// DefaultIndex returns the default index of the register with the given
+// name, DefaultRTMR or DefaultPCR
+func DefaultIndex(name string) uint8 {
+	if name == RegisterPCR {
+		return DefaultPCR
+	}
+
+	return DefaultRTMR
+}
+
+// NewRegister returns the register with the given name, RegisterRTMR or
+// RegisterPCR, and index
+func NewRegister(name string, index uint8) (Register, error) {
+	switch name {
+	case RegisterRTMR:
+		if index > maxRTMR {
+			return nil, fmt.Errorf("RTMR index should be at most %d, got %d", maxRTMR, index)
+		}
+		return &RTMR{index: index, dir: defaultMeasurements}, nil
+	case RegisterPCR:
+		if index > maxPCR {
+			return nil, fmt.Errorf("PCR index should be at most %d, got %d", maxPCR, index)
+		}
+		return &PCR{index: index, device: defaultDevice}, nil
+	default:
+		return nil, fmt.Errorf("register %s is not supported", name)
+	}
+}
+
+// RTMR is a TDX RTMR, extended through the measurement attributes of the
+// TSM, e.g., /sys/class/misc/tdx_guest/measurements/rtmr3:sha384, as the
+// configfs-TSM interface only reports RTMRs
+type RTMR struct {
+	index uint8
+	dir   string
+}
+
+func (o *RTMR) IndexType() uint8 { return tokens.CELIndexCCMR }
+
+func (o *RTMR) Index() uint8 { return o.index }
+
+func (o *RTMR) Algs() []tpm2.TPMAlgID { return []tpm2.TPMAlgID{tpm2.TPMAlgSHA384} }
+
+func (o *RTMR) Extend(alg tpm2.TPMAlgID, digest []byte) error {
+	if alg != tpm2.TPMAlgSHA384 || len(digest) != sha512.Size384 {
+		return fmt.Errorf("RTMRs are only extended with SHA-384 digests")
+	}
+
+	path := filepath.Join(o.dir, fmt.Sprintf("rtmr%d:sha384", o.index))
+
+	// the attribute, which exists only where the RTMR is supported, takes
+	// the digest in binary form, in a single write
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(digest); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// PCR is a TPM PCR, extended in its SHA-256 bank
+type PCR struct {
+	index  uint8
+	device string
+	// open overrides the opening of the TPM device, for testing
+	open func() (transport.TPMCloser, error)
+}
+
+func (o *PCR) IndexType() uint8 { return tokens.CELIndexPCR }
+
+func (o *PCR) Index() uint8 { return o.index }
+
+func (o *PCR) Algs() []tpm2.TPMAlgID { return []tpm2.TPMAlgID{tpm2.TPMAlgSHA256} }
+
+func (o *PCR) Extend(alg tpm2.TPMAlgID, digest []byte) error {
+	if alg != tpm2.TPMAlgSHA256 || len(digest) != sha256.Size {
+		return fmt.Errorf("PCRs are only extended with SHA-256 digests")
+	}
+
+	var (
+		tpm transport.TPMCloser
+		err error
+	)
+	if o.open != nil {
+		tpm, err = o.open()
+	} else {
+		tpm, err = linuxtpm.Open(o.device)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open TPM: %w", err)
+	}
+	defer tpm.Close()
+
+	_, err = tpm2.PCRExtend{
+		PCRHandle: tpm2.AuthHandle{
+			Handle: tpm2.TPMHandle(o.index),
+			Auth:   tpm2.PasswordAuth(nil),
+		},
+		Digests: tpm2.TPMLDigestValues{
+			Digests: []tpm2.TPMTHA{{HashAlg: tpm2.TPMAlgSHA256, Digest: digest}},
+		},
+	}.Execute(tpm)
+
+	return err
+}
+
+// MockRegister is a register held in memory, which starts zeroed, for testing
+type MockRegister struct {
+	indexType uint8
+	index     uint8
+	algs      []tpm2.TPMAlgID
+
+	mu     sync.Mutex
+	values map[tpm2.TPMAlgID][]byte
+}
+
+// NewMockRegister returns a register held in memory, with the given type,
+// index and banks
+func NewMockRegister(indexType, index uint8, algs ...tpm2.TPMAlgID) *MockRegister {
+	return &MockRegister{
+		indexType: indexType,
+		index:     index,
+		algs:      algs,
+		values:    make(map[tpm2.TPMAlgID][]byte),
+	}
+}
+
+func (o *MockRegister) IndexType() uint8 { return o.indexType }
+
+func (o *MockRegister) Index() uint8 { return o.index }
+
+func (o *MockRegister) Algs() []tpm2.TPMAlgID { return o.algs }
+
+func (o *MockRegister) Extend(alg tpm2.TPMAlgID, digest []byte) error {
+	h, err := alg.Hash()
+	if err != nil {
+		return err
+	}
+	if len(digest) != h.Size() {
+		return fmt.Errorf("digest size should be %d, got %d", h.Size(), len(digest))
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	v, ok := o.values[alg]
+	if !ok {
+		v = make([]byte, h.Size())
+	}
+
+	d := h.New()
+	d.Write(v)
+	d.Write(digest)
+	o.values[alg] = d.Sum(nil)
+
+	return nil
+}
+
+// Value returns the value of the bank of the given algorithm
+func (o *MockRegister) Value(alg tpm2.TPMAlgID) []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if v, ok := o.values[alg]; ok {
+		return bytes.Clone(v)
+	}
+
+	h, err := alg.Hash()
+	if err != nil {
+		return nil
+	}
+
+	return make([]byte, h.Size())
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package measure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ratsd/tokens"
+)
+
+// nopCloser keeps the simulator running when the register closes the TPM
+type nopCloser struct {
+	transport.TPM
+}
+
+func (nopCloser) Close() error { return nil }
+
+func Test_NewRegister(t *testing.T) {
+	r, err := NewRegister(RegisterRTMR, DefaultRTMR)
+	require.NoError(t, err)
+	assert.Equal(t, &RTMR{index: 3, dir: defaultMeasurements}, r)
+
+	r, err = NewRegister(RegisterPCR, DefaultPCR)
+	require.NoError(t, err)
+	assert.Equal(t, &PCR{index: 23, device: defaultDevice}, r)
+}
+
+func Test_NewRegister_Fail(t *testing.T) {
+	_, err := NewRegister(RegisterRTMR, 4)
+	assert.EqualError(t, err, "RTMR index should be at most 3, got 4")
+
+	_, err = NewRegister(RegisterPCR, 24)
+	assert.EqualError(t, err, "PCR index should be at most 23, got 24")
+
+	_, err = NewRegister("nv", 0)
+	assert.EqualError(t, err, "register nv is not supported")
+}
+
+func Test_RTMR_Extend(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rtmr2:sha384")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	r := &RTMR{index: 2, dir: dir}
+	assert.Equal(t, uint8(tokens.CELIndexCCMR), r.IndexType())
+	assert.Equal(t, []tpm2.TPMAlgID{tpm2.TPMAlgSHA384}, r.Algs())
+
+	digest := bytes.Repeat([]byte{0xab}, sha512.Size384)
+	require.NoError(t, r.Extend(tpm2.TPMAlgSHA384, digest))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, digest, written)
+
+	assert.EqualError(t, r.Extend(tpm2.TPMAlgSHA256, digest[:sha256.Size]),
+		"RTMRs are only extended with SHA-384 digests")
+
+	r = &RTMR{index: 3, dir: dir}
+	assert.ErrorContains(t, r.Extend(tpm2.TPMAlgSHA384, digest), "rtmr3:sha384")
+}
+
+func Test_PCR_Extend(t *testing.T) {
+	sim, err := simulator.OpenSimulator()
+	require.NoError(t, err)
+	t.Cleanup(func() { sim.Close() })
+
+	r := &PCR{index: 16, open: func() (transport.TPMCloser, error) {
+		return nopCloser{sim}, nil
+	}}
+	assert.Equal(t, uint8(tokens.CELIndexPCR), r.IndexType())
+	assert.Equal(t, []tpm2.TPMAlgID{tpm2.TPMAlgSHA256}, r.Algs())
+
+	digest := bytes.Repeat([]byte{0xab}, sha256.Size)
+	require.NoError(t, r.Extend(tpm2.TPMAlgSHA256, digest))
+
+	pcrs, err := tpm2.PCRRead{
+		PCRSelectionIn: tpm2.TPMLPCRSelection{
+			PCRSelections: []tpm2.TPMSPCRSelection{{
+				Hash:      tpm2.TPMAlgSHA256,
+				PCRSelect: tpm2.PCClientCompatible.PCRs(16),
+			}},
+		},
+	}.Execute(sim)
+	require.NoError(t, err)
+	require.Len(t, pcrs.PCRValues.Digests, 1)
+
+	expected := sha256.Sum256(append(make([]byte, sha256.Size), digest...))
+	assert.Equal(t, expected[:], pcrs.PCRValues.Digests[0].Buffer)
+
+	assert.EqualError(t, r.Extend(tpm2.TPMAlgSHA384, bytes.Repeat([]byte{0xab}, sha512.Size384)),
+		"PCRs are only extended with SHA-256 digests")
+
+	r = &PCR{index: 16, device: filepath.Join(t.TempDir(), "tpm")}
+	assert.ErrorContains(t, r.Extend(tpm2.TPMAlgSHA256, digest), "failed to open TPM: ")
+}
+
+func Test_MockRegister(t *testing.T) {
+	r := NewMockRegister(tokens.CELIndexPCR, 16, tpm2.TPMAlgSHA256)
+	assert.Equal(t, make([]byte, sha256.Size), r.Value(tpm2.TPMAlgSHA256))
+
+	digest := bytes.Repeat([]byte{0xab}, sha256.Size)
+	require.NoError(t, r.Extend(tpm2.TPMAlgSHA256, digest))
+
+	expected := sha256.Sum256(append(make([]byte, sha256.Size), digest...))
+	assert.Equal(t, expected[:], r.Value(tpm2.TPMAlgSHA256))
+
+	assert.EqualError(t, r.Extend(tpm2.TPMAlgSHA256, digest[:4]), "digest size should be 32, got 4")
+}
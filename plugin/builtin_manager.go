@@ -0,0 +1,53 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package plugin
+
+import (
+	"fmt"
+	"slices"
+)
+
+// BuiltinManager adds built-in sub-attesters, which run in the ratsd process,
+// to the plugins of another manager
+type BuiltinManager struct {
+	IManager
+
+	builtins map[string]IPluggable
+}
+
+func NewBuiltinManager(manager IManager) *BuiltinManager {
+	return &BuiltinManager{
+		IManager: manager,
+		builtins: make(map[string]IPluggable),
+	}
+}
+
+// Register adds a built-in sub-attester, which must not share its name with
+// another sub-attester
+func (o *BuiltinManager) Register(name string, p IPluggable) error {
+	if _, ok := o.builtins[name]; ok || slices.Contains(o.IManager.GetPluginList(), name) {
+		return fmt.Errorf("sub-attester %q is already registered", name)
+	}
+
+	o.builtins[name] = p
+
+	return nil
+}
+
+func (o *BuiltinManager) LookupByName(name string) (IPluggable, error) {
+	if p, ok := o.builtins[name]; ok {
+		return p, nil
+	}
+
+	return o.IManager.LookupByName(name)
+}
+
+func (o *BuiltinManager) GetPluginList() []string {
+	registeredPlugin := o.IManager.GetPluginList()
+
+	for name := range o.builtins {
+		registeredPlugin = append(registeredPlugin, name)
+	}
+
+	return registeredPlugin
+}
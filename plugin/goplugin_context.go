@@ -6,7 +6,6 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/go-plugin"
@@ -26,6 +25,14 @@ type PluginContext struct {
 	// Version of this plugin
 	Version string
 
+	// Digest is the SHA-256 digest of the executable binary, computed
+	// when the plugin is loaded
+	Digest []byte
+
+	// Publisher is the name of the publisher whose key verified the
+	// signature of the executable binary, if any
+	Publisher string
+
 	// Handle is actual RPC interface to the plugin implementation.
 	Handle IPluggable
 
@@ -42,6 +49,7 @@ func (o PluginContext) Close() {
 func createPluginContext(
 	loader *GoPluginLoader,
 	path string,
+	digest []byte,
 	logger *zap.SugaredLogger,
 ) (*PluginContext, error) {
 	cfg := &plugin.ClientConfig{
@@ -52,18 +60,12 @@ func createPluginContext(
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 	}
 
-	if len(loader.pluginChecksum) > 0 {
-		basename := filepath.Base(path)
-		pluginName := strings.TrimSuffix(basename, filepath.Ext(basename))
-
-		checksum, ok := loader.pluginChecksum[pluginName]
-		if !ok {
-			return nil, fmt.Errorf("the checksum for plugin %s is missing", pluginName)
-		}
-
+	// The digest of a verified plugin is checked again when it is launched,
+	// so that it cannot be replaced in between.
+	if len(loader.pluginChecksum) > 0 || len(loader.publisherKeys) > 0 {
 		secureConfig := &plugin.SecureConfig{
-			Checksum:[]byte(checksum),
-			Hash: sha256.New(),
+			Checksum: digest,
+			Hash:     sha256.New(),
 		}
 		cfg.SecureConfig = secureConfig
 	}
@@ -106,6 +108,7 @@ func createPluginContext(
 		Path:    path,
 		Name:    blob.SubAttesterID.Name,
 		Version: blob.SubAttesterID.Version,
+		Digest:  digest,
 		Handle:  handle,
 		client:  client,
 	}, nil
@@ -3,6 +3,7 @@
 package plugin
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -20,12 +21,25 @@ func (o unknownPluginErr) Error() string {
 	return fmt.Sprintf("unknown plugin: %s", o.Name)
 }
 
+// Measurer measures the plugins before they are launched, e.g., by extending
+// their digests into a measurement register
+type Measurer interface {
+	// Measure records the SHA-256 digest of the plugin executable at path
+	Measure(path string, digest []byte) error
+}
+
 type GoPluginLoader struct {
 	Location string
 
 	logger       *zap.SugaredLogger
 	loadedByName map[string]*PluginContext
 	pluginChecksum map[string][]byte
+	measurer       Measurer
+	publisherKeys  map[string]ed25519.PublicKey
+	rejected       []RejectedPlugin
+	// skipBadChecksums rejects the plugins failing their checksum, instead
+	// of failing the discovery
+	skipBadChecksums bool
 
 	// This gets specified as Plugins when creating a new go-plugin client.
 	pluginMap map[string]plugin.Plugin
@@ -48,6 +62,9 @@ func (o *GoPluginLoader) Init(dir string) error {
 	o.pluginMap = make(map[string]plugin.Plugin)
 	o.loadedByName = make(map[string]*PluginContext)
 	o.pluginChecksum = make(map[string][]byte)
+	o.publisherKeys = make(map[string]ed25519.PublicKey)
+	o.rejected = nil
+	o.skipBadChecksums = false
 	o.Location = dir
 
 	return nil
@@ -89,6 +106,11 @@ func (o *GoPluginLoader) SetChecksum(v *viper.Viper) error {
 	return nil
 }
 
+// SetMeasurer sets the measurer of the plugins discovered next
+func (o *GoPluginLoader) SetMeasurer(m Measurer) {
+	o.measurer = m
+}
+
 func RegisterGoPluginUsing(loader *GoPluginLoader, name string) error {
 	if _, ok := loader.pluginMap[name]; ok {
 		return fmt.Errorf("plugin for %q is already registred", name)
@@ -110,7 +132,28 @@ func DiscoverGoPluginUsing(o *GoPluginLoader) error {
 	}
 
 	for _, path := range pluginPaths {
-		pluginContext, err := createPluginContext(o, path, o.logger)
+		digest, publisher, err := o.verifyPlugin(path)
+		if err != nil {
+			var rpErr rejectedPluginErr
+			if errors.As(err, &rpErr) {
+				o.logger.Warnw("rejected plugin", "path", path, "reason", rpErr.Reason)
+				o.rejected = append(o.rejected, RejectedPlugin{
+					Name:   pluginFileName(path),
+					Path:   path,
+					Reason: rpErr.Reason,
+				})
+				continue
+			}
+			return err
+		}
+
+		if o.measurer != nil {
+			if err := o.measurer.Measure(path, digest); err != nil {
+				return fmt.Errorf("unable to record the measurement of %s: %w", path, err)
+			}
+		}
+
+		pluginContext, err := createPluginContext(o, path, digest, o.logger)
 		if err != nil {
 			var upErr unknownPluginErr
 			if errors.As(err, &upErr) {
@@ -121,6 +164,8 @@ func DiscoverGoPluginUsing(o *GoPluginLoader) error {
 			}
 		}
 
+		pluginContext.Publisher = publisher
+
 		pluginName := pluginContext.Name
 		if existing, ok := o.loadedByName[pluginName]; ok {
 			return fmt.Errorf(
@@ -4,6 +4,7 @@ package plugin
 
 import (
 	"errors"
+	"sort"
 
 	"go.uber.org/zap"
 )
@@ -66,3 +67,40 @@ func (o *GoPluginManager) GetPluginList() []string {
 
 	return registeredPlugin
 }
+
+// PluginInfo describes a loaded plugin
+type PluginInfo struct {
+	Name    string
+	Version string
+	Path    string
+	// Digest is the SHA-256 digest of the plugin executable
+	Digest []byte
+	// Publisher is the name of the publisher that signed the plugin, if
+	// signatures are verified
+	Publisher string
+}
+
+// GetPluginInfo returns the description of the loaded plugins, sorted by
+// name
+func (o *GoPluginManager) GetPluginInfo() []PluginInfo {
+	var info []PluginInfo
+
+	for _, pc := range o.loader.loadedByName {
+		info = append(info, PluginInfo{
+			Name:      pc.Name,
+			Version:   pc.Version,
+			Path:      pc.Path,
+			Digest:    pc.Digest,
+			Publisher: pc.Publisher,
+		})
+	}
+
+	sort.Slice(info, func(i, j int) bool { return info[i].Name < info[j].Name })
+
+	return info
+}
+
+// GetRejectedPlugins returns the plugins rejected by the secure loader
+func (o *GoPluginManager) GetRejectedPlugins() []RejectedPlugin {
+	return o.loader.rejected
+}
@@ -4,6 +4,8 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
@@ -50,3 +52,17 @@ func Serve() {
 		GRPCServer:      plugin.DefaultGRPCServer,
 	})
 }
+
+// ServeConfigured serves the implementation returned by getPlugin, for the
+// attesters configured when they start. If the configuration is invalid, the
+// error is reported on stderr and the plugin exits.
+func ServeConfigured[T IPluggable](name string, getPlugin func() (T, error)) {
+	i, err := getPlugin()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s plugin: %v\n", name, err)
+		os.Exit(1)
+	}
+
+	RegisterImplementation(i)
+	Serve()
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package plugin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// SignatureExt is the extension of the detached signature of a plugin, which
+// is stored next to the plugin executable, e.g., tsm.plugin.sig
+const SignatureExt = ".sig"
+
+// RejectedPlugin describes a plugin that failed the verification of the
+// secure loader, and was not launched
+type RejectedPlugin struct {
+	// Name is the name of the plugin executable, without extension
+	Name   string
+	Path   string
+	Reason string
+}
+
+type rejectedPluginErr struct {
+	Reason string
+}
+
+func (o rejectedPluginErr) Error() string {
+	return o.Reason
+}
+
+func rejectPlugin(format string, a ...any) rejectedPluginErr {
+	return rejectedPluginErr{Reason: fmt.Sprintf(format, a...)}
+}
+
+// pluginFileName returns the name of the plugin executable at path, without
+// extension
+func pluginFileName(path string) string {
+	basename := filepath.Base(path)
+	return strings.TrimSuffix(basename, filepath.Ext(basename))
+}
+
+// SetSkipBadChecksums sets whether a plugin that fails its checksum is
+// rejected, while the other plugins are still loaded. By default, the
+// discovery of the plugins fails instead.
+func (o *GoPluginLoader) SetSkipBadChecksums(skip bool) {
+	o.skipBadChecksums = skip
+}
+
+// checksumFailure returns the error of a plugin failing its checksum, which
+// is only a rejection if bad checksums are skipped
+func (o *GoPluginLoader) checksumFailure(format string, a ...any) error {
+	if o.skipBadChecksums {
+		return rejectPlugin(format, a...)
+	}
+
+	return fmt.Errorf(format, a...)
+}
+
+// SetPublisherKeys sets the Ed25519 keys of the plugin publishers, mapping
+// the name of each publisher to the path of its PEM encoded public key. Once
+// set, plugins are only launched if their detached signature is verified by
+// one of the keys.
+func (o *GoPluginLoader) SetPublisherKeys(v *viper.Viper) error {
+	for _, name := range v.AllKeys() {
+		path, ok := v.Get(name).(string)
+		if !ok {
+			return fmt.Errorf(
+				"invalid key for publisher %q: expected string, got %T",
+				name, v.Get(name),
+			)
+		}
+
+		key, err := loadPublisherKey(path)
+		if err != nil {
+			return fmt.Errorf("failed to load the key of publisher %s: %w", name, err)
+		}
+
+		o.logger.Debugw("registered publisher key", "name", name, "path", path)
+
+		o.publisherKeys[name] = key
+	}
+
+	return nil
+}
+
+func loadPublisherKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PEM encoded public key in %s", path)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 key, got %T", pub)
+	}
+
+	return key, nil
+}
+
+// readSignature reads the detached signature at path, either raw or base64
+// encoded
+func readSignature(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == ed25519.SignatureSize {
+		return data, nil
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("expected a %d-byte signature, raw or base64 encoded",
+			ed25519.SignatureSize)
+	}
+
+	return sig, nil
+}
+
+// verifyPlugin checks the plugin executable at path against the checksums
+// and the publisher keys, if any. It returns the SHA-256 digest of the
+// verified executable, and the name of the publisher whose key verified its
+// signature, if any. A plugin failing its signature is rejected with a
+// rejectedPluginErr, as is a plugin failing its checksum if bad checksums are
+// skipped.
+func (o *GoPluginLoader) verifyPlugin(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to measure %s: %w", path, err)
+	}
+
+	digest := sha256.Sum256(data)
+	name := pluginFileName(path)
+
+	if len(o.pluginChecksum) > 0 {
+		checksum, ok := o.pluginChecksum[name]
+		if !ok {
+			return nil, "", o.checksumFailure("the checksum for plugin %s is missing", name)
+		}
+
+		if !bytes.Equal(checksum, digest[:]) {
+			return nil, "", o.checksumFailure("the checksum of plugin %s does not match", name)
+		}
+	}
+
+	if len(o.publisherKeys) == 0 {
+		return digest[:], "", nil
+	}
+
+	sig, err := readSignature(path + SignatureExt)
+	if err != nil {
+		return nil, "", rejectPlugin("unable to read the signature of plugin %s: %v", name, err)
+	}
+
+	// publishers are tried in order, so that the reported one is stable
+	publishers := make([]string, 0, len(o.publisherKeys))
+	for publisher := range o.publisherKeys {
+		publishers = append(publishers, publisher)
+	}
+	sort.Strings(publishers)
+
+	for _, publisher := range publishers {
+		if ed25519.Verify(o.publisherKeys[publisher], data, sig) {
+			return digest[:], publisher, nil
+		}
+	}
+
+	return nil, "", rejectPlugin("the signature of plugin %s is not verified by any publisher key", name)
+}
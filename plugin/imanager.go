@@ -21,4 +21,8 @@ type IManager interface {
 	// that have been registered with the manager by discovered
 	// plugins.
 	GetPluginList() []string
+
+	// GetRejectedPlugins returns the plugins that were discovered but
+	// rejected by the secure loader, and thus not launched.
+	GetRejectedPlugins() []RejectedPlugin
 }
@@ -12,7 +12,8 @@ type IPluggable interface {
 	// GetEvidence takes *compositor.EvidenceIn as the input, which contains the nonce
 	// and one of the content type returned by GetSupportedFormats. It returns a
 	// *compositor.EvidenceOut that contains the raw evidence for this subattester as
-	// the output.
+	// the output. A subattester producing more than one typed record, e.g.,
+	// evidence and endorsements, returns them in the Records field instead.
 	GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut
 
 	// GetOptions returns a list of attester-specific options user may specify in /chares
@@ -165,6 +165,33 @@ func (e *Evidence) SetToken(key string, mediaType string, token []byte, indicato
 	return nil
 }
 
+// SetCollectionItem stores a caller-supplied CMW record or nested CMW
+// collection in the Evidence collection.
+func (e *Evidence) SetCollectionItem(key string, item cmw.CMW) error {
+	if e == nil {
+		return errNilEvidence
+	}
+
+	if err := validateCollectionKey(key); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := validateCMWRecord(key, item); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	clone, err := cloneCMW(item)
+	if err != nil {
+		return fmt.Errorf("copying CMW record at key %q: %w", key, err)
+	}
+
+	if err := e.Collection.AddCollectionItem(key, &clone); err != nil {
+		return fmt.Errorf("adding CMW record at key %q: %w", key, err)
+	}
+
+	return nil
+}
+
 // SetSignature stores the COSE_Sign1 signature bytes in the embedded message.
 func (e *Evidence) SetSignature(signature []byte) error {
 	if e == nil {
@@ -786,8 +813,12 @@ func validateCollectionForToken(collection cmw.CMW) error {
 }
 
 func validateCMWRecord(key string, record cmw.CMW) error {
+	if record.GetKind() == cmw.KindCollection {
+		return validateNestedCollection(key, record)
+	}
+
 	if record.GetKind() != cmw.KindMonad {
-		return fmt.Errorf("invalid CMW record at key %q: want CMW record, got %s", key, record.GetKind())
+		return fmt.Errorf("invalid CMW record at key %q: want CMW record or collection, got %s", key, record.GetKind())
 	}
 
 	switch record.GetFormat() {
@@ -807,6 +838,45 @@ func validateCMWRecord(key string, record cmw.CMW) error {
 	return nil
 }
 
+// validateNestedCollection checks a CMW collection embedded in the RATSD
+// collection, for example one holding evidence and endorsements from a single
+// sub-attester.
+func validateNestedCollection(key string, collection cmw.CMW) error {
+	switch collection.GetFormat() {
+	case cmw.FormatUnknown, cmw.FormatCBORCollection:
+	default:
+		return fmt.Errorf("invalid CMW collection at key %q: want CBOR collection, got %s", key, collection.GetFormat())
+	}
+
+	meta, err := collection.GetCollectionMeta()
+	if err != nil {
+		return err
+	}
+	if len(meta) == 0 {
+		return fmt.Errorf("invalid CMW collection at key %q: %w", key, errMissingCollectionRecord)
+	}
+
+	for _, itemMeta := range meta {
+		itemKey, ok := itemMeta.Key.(string)
+		if !ok {
+			return fmt.Errorf("invalid CMW collection key in %q: want text, got %T", key, itemMeta.Key)
+		}
+		if itemKey == "" {
+			return fmt.Errorf("invalid CMW collection at key %q: %w", key, errEmptyCollectionKey)
+		}
+
+		item, err := collection.GetCollectionItem(itemKey)
+		if err != nil {
+			return err
+		}
+		if err := validateCMWRecord(key+"/"+itemKey, *item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func intLabel(v any) (int, bool) {
 	label, ok := int64Label(v)
 	if !ok || label < math.MinInt || label > math.MaxInt {
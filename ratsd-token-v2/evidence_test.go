@@ -299,6 +299,66 @@ func TestEvidenceSetTokenFail(t *testing.T) {
 	)
 }
 
+func TestEvidenceSetCollectionItemStoresNestedCollection(t *testing.T) {
+	nested := cmw.NewCollection("tag:example.com,2026:sub-attester")
+	require.NotNil(t, nested)
+	require.NoError(t, nested.AddCollectionItem(
+		"report",
+		cmw.NewMonad("application/octet-stream", []byte{0x01}, cmw.Evidence),
+	))
+	require.NoError(t, nested.AddCollectionItem(
+		"certs",
+		cmw.NewMonad("application/pkix-cert", []byte{0x02}, cmw.Endorsements),
+	))
+
+	evidence := validEvidence()
+	require.NoError(t, evidence.SetCollectionItem("mock-tsm", *nested))
+
+	encoded, err := evidence.ToCBOR()
+	require.NoError(t, err)
+
+	var decoded Evidence
+	require.NoError(t, decoded.FromCBOR(encoded))
+
+	item, err := decoded.Collection.GetCollectionItem("mock-tsm")
+	require.NoError(t, err)
+	assert.Equal(t, cmw.KindCollection, item.GetKind())
+
+	collectionType, err := item.GetCollectionType()
+	require.NoError(t, err)
+	assert.Equal(t, "tag:example.com,2026:sub-attester", collectionType)
+
+	record, err := item.GetCollectionItem("certs")
+	require.NoError(t, err)
+	assert.Equal(t, "application/pkix-cert", record.GetMonadType())
+	assert.Equal(t, []byte{0x02}, record.GetMonadValue())
+	assert.Equal(t, cmw.Indicator(cmw.Endorsements), record.GetMonadIndicator())
+}
+
+func TestEvidenceSetCollectionItemFail(t *testing.T) {
+	evidence := NewEvidence()
+
+	assert.EqualError(t,
+		evidence.SetCollectionItem("__ratsd", *cmw.NewMonad("application/octet-stream", []byte{0x01})),
+		`validation failed: invalid CMW collection key "__ratsd": reserved`,
+	)
+
+	nested := cmw.NewCollection("tag:example.com,2026:sub-attester")
+	require.NotNil(t, nested)
+	require.NoError(t, nested.AddCollectionItem("report", cmw.NewMonad("", []byte{0x01})))
+	assert.EqualError(t,
+		evidence.SetCollectionItem("mock-tsm", *nested),
+		`validation failed: invalid CMW record at key "mock-tsm/report": missing mandatory CMW record type`,
+	)
+
+	empty := cmw.NewCollection("tag:example.com,2026:sub-attester")
+	require.NotNil(t, empty)
+	assert.EqualError(t,
+		evidence.SetCollectionItem("mock-tsm", *empty),
+		`validation failed: invalid CMW collection at key "mock-tsm": missing mandatory CMW collection record`,
+	)
+}
+
 func TestEvidenceSetCollectionFailReservedKey(t *testing.T) {
 	collection := cmw.NewCollection(CMWCollectionType)
 	require.NotNil(t, collection)
@@ -0,0 +1,181 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ratsdtokenv2
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	cose "github.com/veraison/go-cose"
+)
+
+var (
+	// ErrNoTrustAnchors is returned by VerifyWithRoots when no roots are
+	// supplied.
+	ErrNoTrustAnchors = errors.New("no trust anchors configured")
+	// ErrNoSigningCert is returned by VerifyWithRoots for a token without
+	// x5chain.
+	ErrNoSigningCert = errors.New("no signing certificate in x5chain")
+	// ErrCertificateExpired is returned when a certificate in the x5chain is
+	// expired or not yet valid.
+	ErrCertificateExpired = errors.New("certificate expired or not yet valid")
+	// ErrUntrustedChain is returned when the x5chain does not lead to one of
+	// the supplied roots.
+	ErrUntrustedChain = errors.New("certificate chain not trusted")
+	// ErrInvalidKeyUsage is returned when a certificate in the x5chain may
+	// not be used to sign the token.
+	ErrInvalidKeyUsage = errors.New("invalid key usage")
+	// ErrAlgorithmMismatch is returned when the "alg" protected header does
+	// not match the key of the signing certificate.
+	ErrAlgorithmMismatch = errors.New("algorithm does not match signing key")
+)
+
+// VerifyOptions holds the parameters of the x5chain validation performed by
+// VerifyWithRoots.
+type VerifyOptions struct {
+	// CurrentTime is the time at which the certificates must be valid. The
+	// zero value means the current time.
+	CurrentTime time.Time
+	// KeyUsages lists the acceptable extended key usages of the signing
+	// certificate. An empty list accepts any usage.
+	KeyUsages []x509.ExtKeyUsage
+}
+
+// VerifyWithRoots validates the x5chain against roots and verifies the
+// COSE_Sign1 signature with the public key of the signing certificate. The
+// signing certificate must permit digital signatures, and the "alg" protected
+// header, if present, must match its key. The verified chain, from the signing
+// certificate to the trust anchor, is returned.
+func (e Evidence) VerifyWithRoots(roots *x509.CertPool, opts VerifyOptions) ([]*x509.Certificate, error) {
+	if roots == nil {
+		return nil, ErrNoTrustAnchors
+	}
+	if e.SigningCert == nil {
+		return nil, ErrNoSigningCert
+	}
+
+	if ku := e.SigningCert.KeyUsage; ku != 0 && ku&x509.KeyUsageDigitalSignature == 0 {
+		return nil, fmt.Errorf("%w: signing certificate does not permit digital signatures", ErrInvalidKeyUsage)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range e.IntermediateCerts {
+		intermediates.AddCert(cert)
+	}
+
+	keyUsages := opts.KeyUsages
+	if len(keyUsages) == 0 {
+		keyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+
+	chains, err := e.SigningCert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   opts.CurrentTime,
+		KeyUsages:     keyUsages,
+	})
+	if err != nil {
+		return nil, x5ChainError(err)
+	}
+
+	alg, err := e.verificationAlgorithm()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := cose.NewVerifier(alg, e.SigningCert.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("COSE Sign1 verification failed: %w", err)
+	}
+
+	if err := e.Verify(verifier); err != nil {
+		return nil, err
+	}
+
+	return chains[0], nil
+}
+
+// AlgorithmForKey returns the COSE algorithm used with the given public key.
+// ECDSA keys map to the algorithm of their curve, Ed25519 keys to EdDSA, and
+// RSA keys to PS256.
+func AlgorithmForKey(key crypto.PublicKey) (cose.Algorithm, error) {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return cose.AlgorithmES256, nil
+		case elliptic.P384():
+			return cose.AlgorithmES384, nil
+		case elliptic.P521():
+			return cose.AlgorithmES512, nil
+		default:
+			return 0, fmt.Errorf("unsupported ECDSA curve %s", k.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return cose.AlgorithmEdDSA, nil
+	case *rsa.PublicKey:
+		return cose.AlgorithmPS256, nil
+	default:
+		return 0, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// verificationAlgorithm returns the algorithm to verify the signature with:
+// the one in the "alg" protected header if it is compatible with the key of
+// the signing certificate, or the one derived from the key otherwise.
+func (e Evidence) verificationAlgorithm() (cose.Algorithm, error) {
+	expected, err := AlgorithmForKey(e.SigningCert.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	alg, ok, err := e.sign1Algorithm()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return expected, nil
+	}
+
+	compatible := []cose.Algorithm{expected}
+	if expected == cose.AlgorithmPS256 {
+		compatible = append(compatible, cose.AlgorithmPS384, cose.AlgorithmPS512)
+	}
+
+	if !slices.Contains(compatible, alg) {
+		return 0, fmt.Errorf("%w: %s in protected header, %s expected for the signing certificate key",
+			ErrAlgorithmMismatch, alg, expected)
+	}
+
+	return alg, nil
+}
+
+// x5ChainError classifies a chain building error returned by crypto/x509.
+func x5ChainError(err error) error {
+	var (
+		invalid   x509.CertificateInvalidError
+		unknownCA x509.UnknownAuthorityError
+		sentinel  error
+	)
+
+	switch {
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		sentinel = ErrCertificateExpired
+	case errors.As(err, &invalid) && invalid.Reason == x509.IncompatibleUsage:
+		sentinel = ErrInvalidKeyUsage
+	case errors.As(err, &unknownCA):
+		sentinel = ErrUntrustedChain
+	default:
+		return fmt.Errorf("x5chain validation failed: %w", err)
+	}
+
+	return fmt.Errorf("x5chain validation failed: %w: %w", sentinel, err)
+}
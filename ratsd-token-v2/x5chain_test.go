@@ -0,0 +1,302 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ratsdtokenv2
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+type testIssuer struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func mustIssueCertificate(
+	t *testing.T, template *x509.Certificate, key crypto.Signer, issuer *testIssuer,
+) *testIssuer {
+	t.Helper()
+
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testIssuer{cert: cert, key: key}
+}
+
+func testCATemplate(serial int64, name string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+}
+
+func testLeafTemplate(serial int64) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "ratsd-token-v2-signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+func mustECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// signedTestEvidence returns a token signed by leaf, decoded from its CBOR
+// encoding.
+func signedTestEvidence(
+	t *testing.T, leaf *testIssuer, intermediates []*x509.Certificate, alg cose.Algorithm,
+) *Evidence {
+	t.Helper()
+
+	evidence := validEvidence()
+	evidence.SigningCert = leaf.cert
+	evidence.IntermediateCerts = intermediates
+
+	signer, err := cose.NewSigner(alg, leaf.key)
+	require.NoError(t, err)
+
+	encoded, err := evidence.Sign(signer)
+	require.NoError(t, err)
+
+	decoded := NewEvidence()
+	require.NoError(t, decoded.FromCBOR(encoded))
+	return decoded
+}
+
+func TestEvidenceVerifyWithRoots(t *testing.T) {
+	root := mustIssueCertificate(t, testCATemplate(1, "root"), mustECDSAKey(t), nil)
+	intermediate := mustIssueCertificate(t, testCATemplate(2, "intermediate"), mustECDSAKey(t), root)
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+
+	t.Run("ECDSA leaf with intermediate", func(t *testing.T) {
+		leaf := mustIssueCertificate(t, testLeafTemplate(3), mustECDSAKey(t), intermediate)
+		evidence := signedTestEvidence(t, leaf, []*x509.Certificate{intermediate.cert}, cose.AlgorithmES256)
+
+		chain, err := evidence.VerifyWithRoots(roots, VerifyOptions{})
+		require.NoError(t, err)
+		require.Len(t, chain, 3)
+		assert.Equal(t, leaf.cert.Raw, chain[0].Raw)
+		assert.Equal(t, root.cert.Raw, chain[2].Raw)
+	})
+
+	t.Run("Ed25519 leaf", func(t *testing.T) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		leaf := mustIssueCertificate(t, testLeafTemplate(4), key, root)
+		evidence := signedTestEvidence(t, leaf, nil, cose.AlgorithmEdDSA)
+
+		chain, err := evidence.VerifyWithRoots(roots, VerifyOptions{})
+		require.NoError(t, err)
+		assert.Len(t, chain, 2)
+	})
+
+	t.Run("RSA leaf signing with PS384", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		leaf := mustIssueCertificate(t, testLeafTemplate(5), key, root)
+		evidence := signedTestEvidence(t, leaf, nil, cose.AlgorithmPS384)
+
+		_, err = evidence.VerifyWithRoots(roots, VerifyOptions{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestEvidenceVerifyWithRootsFail(t *testing.T) {
+	root := mustIssueCertificate(t, testCATemplate(1, "root"), mustECDSAKey(t), nil)
+	other := mustIssueCertificate(t, testCATemplate(2, "other root"), mustECDSAKey(t), nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(other.cert)
+
+	leaf := mustIssueCertificate(t, testLeafTemplate(3), mustECDSAKey(t), root)
+
+	certSignOnly := testLeafTemplate(4)
+	certSignOnly.KeyUsage = x509.KeyUsageCertSign
+	certSignOnlyLeaf := mustIssueCertificate(t, certSignOnly, mustECDSAKey(t), root)
+
+	serverAuthOnly := testLeafTemplate(5)
+	serverAuthOnly.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	serverAuthOnlyLeaf := mustIssueCertificate(t, serverAuthOnly, mustECDSAKey(t), root)
+
+	tests := []struct {
+		name     string
+		evidence func(t *testing.T) *Evidence
+		roots    *x509.CertPool
+		opts     VerifyOptions
+		expected error
+	}{
+		{
+			"no trust anchors",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, leaf, nil, cose.AlgorithmES256)
+			},
+			nil,
+			VerifyOptions{},
+			ErrNoTrustAnchors,
+		},
+		{
+			"no signing certificate",
+			func(t *testing.T) *Evidence {
+				evidence := validEvidence()
+				evidence.SigningCert = nil
+				return evidence
+			},
+			roots,
+			VerifyOptions{},
+			ErrNoSigningCert,
+		},
+		{
+			"untrusted chain",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, leaf, nil, cose.AlgorithmES256)
+			},
+			otherRoots,
+			VerifyOptions{},
+			ErrUntrustedChain,
+		},
+		{
+			"expired certificate",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, leaf, nil, cose.AlgorithmES256)
+			},
+			roots,
+			VerifyOptions{CurrentTime: time.Now().Add(2 * time.Hour)},
+			ErrCertificateExpired,
+		},
+		{
+			"not yet valid certificate",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, leaf, nil, cose.AlgorithmES256)
+			},
+			roots,
+			VerifyOptions{CurrentTime: time.Now().Add(-2 * time.Hour)},
+			ErrCertificateExpired,
+		},
+		{
+			"no digital signature key usage",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, certSignOnlyLeaf, nil, cose.AlgorithmES256)
+			},
+			roots,
+			VerifyOptions{},
+			ErrInvalidKeyUsage,
+		},
+		{
+			"incompatible extended key usage",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, serverAuthOnlyLeaf, nil, cose.AlgorithmES256)
+			},
+			roots,
+			VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}},
+			ErrInvalidKeyUsage,
+		},
+		{
+			"algorithm mismatch",
+			func(t *testing.T) *Evidence {
+				return signedTestEvidence(t, leaf, nil, cose.AlgorithmES384)
+			},
+			roots,
+			VerifyOptions{},
+			ErrAlgorithmMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := tt.evidence(t).VerifyWithRoots(tt.roots, tt.opts)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, chain)
+		})
+	}
+}
+
+func TestEvidenceVerifyWithRootsFailTampered(t *testing.T) {
+	root := mustIssueCertificate(t, testCATemplate(1, "root"), mustECDSAKey(t), nil)
+	leaf := mustIssueCertificate(t, testLeafTemplate(2), mustECDSAKey(t), root)
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+
+	evidence := signedTestEvidence(t, leaf, nil, cose.AlgorithmES256)
+	evidence.Claims.EatNonce[0] = 'x'
+
+	chain, err := evidence.VerifyWithRoots(roots, VerifyOptions{})
+	assert.ErrorContains(t, err, "COSE Sign1 verification failed")
+	assert.Nil(t, chain)
+}
+
+func TestAlgorithmForKey(t *testing.T) {
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		key      crypto.PublicKey
+		expected cose.Algorithm
+	}{
+		{"P-256", mustECDSAKeyOnCurve(t, elliptic.P256()), cose.AlgorithmES256},
+		{"P-384", mustECDSAKeyOnCurve(t, elliptic.P384()), cose.AlgorithmES384},
+		{"P-521", mustECDSAKeyOnCurve(t, elliptic.P521()), cose.AlgorithmES512},
+		{"Ed25519", ed25519Key, cose.AlgorithmEdDSA},
+		{"RSA", &rsaKey.PublicKey, cose.AlgorithmPS256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg, err := AlgorithmForKey(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, alg)
+		})
+	}
+}
+
+func TestAlgorithmForKeyFail(t *testing.T) {
+	alg, err := AlgorithmForKey("not a key")
+
+	assert.EqualError(t, err, "unsupported signing key type string")
+	assert.Zero(t, alg)
+}
+
+func mustECDSAKeyOnCurve(t *testing.T, curve elliptic.Curve) crypto.PublicKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key.Public()
+}
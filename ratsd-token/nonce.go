@@ -0,0 +1,39 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ratsdtoken
+
+import (
+	"crypto/sha3"
+	"errors"
+	"fmt"
+)
+
+// AdjustNonce derives the nonce of the given size that is passed to a
+// sub-attester from the nonce supplied by the relying party, using the named
+// nonce adjustment function.
+func AdjustNonce(nonce []byte, size uint32, function string) ([]byte, error) {
+	if size == 0 {
+		return nil, errors.New("nonce size must be greater than zero")
+	}
+
+	var h *sha3.SHAKE
+	switch function {
+	case NonceAdjustFunctionShake128:
+		h = sha3.NewSHAKE128()
+	case NonceAdjustFunctionShake256:
+		h = sha3.NewSHAKE256()
+	default:
+		return nil, fmt.Errorf("unsupported nonce adjustment function %q", function)
+	}
+
+	if _, err := h.Write(nonce); err != nil {
+		return nil, err
+	}
+
+	adjusted := make([]byte, int(size))
+	if _, err := h.Read(adjusted); err != nil {
+		return nil, err
+	}
+
+	return adjusted, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package ratsdtoken
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustNonce(t *testing.T) {
+	tests := []struct {
+		function string
+		size     uint32
+		expected string
+	}{
+		{NonceAdjustFunctionShake128, 16, "7f9c2ba4e88f827d616045507605853e"},
+		{NonceAdjustFunctionShake256, 16, "46b9dd2b0ba88d13233b3feb743eeb24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			adjusted, err := AdjustNonce(nil, tt.size, tt.function)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, hex.EncodeToString(adjusted))
+		})
+	}
+}
+
+func TestAdjustNonceFail(t *testing.T) {
+	_, err := AdjustNonce([]byte("nonce"), 0, NonceAdjustFunctionShake256)
+	assert.EqualError(t, err, "nonce size must be greater than zero")
+
+	_, err = AdjustNonce([]byte("nonce"), 32, "sha-256")
+	assert.EqualError(t, err, `unsupported nonce adjustment function "sha-256"`)
+}
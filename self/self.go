@@ -0,0 +1,282 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package self implements ratsd-self, the built-in sub-attester describing
+// the ratsd daemon itself: the digests of its executable, of its
+// configuration and of the plugins it loaded, and its start time. Where a TEE
+// is available, the evidence is anchored in TEE evidence, e.g., a TSM report,
+// whose report data is the digest of the ratsd-self evidence.
+package self
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/attesters/tsm"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+const (
+	// Name is the name of the ratsd-self sub-attester
+	Name = "ratsd-self"
+
+	nonceSize = tokens.RatsdSelfNonceSize
+)
+
+// Names of the anchors, see NewAnchor
+const (
+	// AnchorTSM anchors the evidence in a configfs-TSM report
+	AnchorTSM = "tsm"
+)
+
+var (
+	sid = &compositor.SubAttesterID{
+		Name:    Name,
+		Version: "1.0.0",
+	}
+
+	supportedFormats = []*compositor.Format{
+		&compositor.Format{
+			ContentType: tokens.RatsdSelfMediaTypeJSON,
+			NonceSize:   nonceSize,
+		},
+		&compositor.Format{
+			ContentType: tokens.RatsdSelfMediaTypeCBOR,
+			NonceSize:   nonceSize,
+		},
+	}
+
+	statusSucceeded = &compositor.Status{Result: true, Error: ""}
+)
+
+// Config describes the ratsd daemon to attest
+type Config struct {
+	// Executable is the path of the ratsd executable, that of the running
+	// process if empty
+	Executable string
+	// ConfigFile is the path of the configuration file, if any
+	ConfigFile string
+	// Plugins are the plugins loaded by ratsd
+	Plugins []plugin.PluginInfo
+	// StartTime is the time ratsd started
+	StartTime time.Time
+	// Anchor is the sub-attester producing the TEE evidence anchoring the
+	// ratsd-self evidence, if any. Its nonce is the digest of the ratsd-self
+	// evidence, see tokens.RatsdSelfAnchorData.
+	Anchor plugin.IPluggable
+}
+
+// Attester is the ratsd-self sub-attester. It implements plugin.IPluggable,
+// running in the ratsd process.
+type Attester struct {
+	evidence     tokens.RatsdSelfEvidence
+	anchor       plugin.IPluggable
+	anchorFormat *compositor.Format
+}
+
+// New returns the ratsd-self attester of the daemon described by cfg. The
+// executable and the configuration file are measured on creation.
+func New(cfg Config) (*Attester, error) {
+	path := cfg.Executable
+	if path == "" {
+		var err error
+		if path, err = os.Executable(); err != nil {
+			return nil, fmt.Errorf("failed to locate the ratsd executable: %w", err)
+		}
+	}
+
+	executable, err := measure(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure the ratsd executable: %w", err)
+	}
+
+	a := &Attester{
+		evidence: tokens.RatsdSelfEvidence{
+			Executable: *executable,
+			StartTime:  cfg.StartTime.UTC().Format(time.RFC3339),
+		},
+		anchor: cfg.Anchor,
+	}
+
+	if cfg.ConfigFile != "" {
+		a.evidence.Config, err = measure(cfg.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure the ratsd configuration: %w", err)
+		}
+	}
+
+	for _, p := range cfg.Plugins {
+		a.evidence.Plugins = append(a.evidence.Plugins, tokens.RatsdSelfPlugin{
+			Name:      p.Name,
+			Version:   p.Version,
+			Path:      p.Path,
+			Digest:    p.Digest,
+			Publisher: p.Publisher,
+		})
+	}
+
+	if a.anchor != nil {
+		formatOut := a.anchor.GetSupportedFormats()
+		if !formatOut.Status.Result {
+			return nil, fmt.Errorf("anchor is not available: %s", formatOut.Status.Error)
+		}
+
+		i := slices.IndexFunc(formatOut.Formats, func(f *compositor.Format) bool {
+			return f.NonceSize == uint32(len(tokens.RatsdSelfAnchorData(nil)))
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("anchor has no format with a nonce size of %d",
+				len(tokens.RatsdSelfAnchorData(nil)))
+		}
+		a.anchorFormat = formatOut.Formats[i]
+	}
+
+	return a, nil
+}
+
+// NewAnchor returns the anchor with the given name, AnchorTSM
+func NewAnchor(name string) (plugin.IPluggable, error) {
+	switch name {
+	case AnchorTSM:
+		return &tsm.TSMPlugin{}, nil
+	default:
+		return nil, fmt.Errorf("anchor %s is not supported", name)
+	}
+}
+
+// measure returns the SHA-256 digest of the file at path
+func measure(path string) (*tokens.RatsdSelfComponent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+
+	return &tokens.RatsdSelfComponent{Path: path, Digest: h.Sum(nil)}, nil
+}
+
+func getEvidenceError(e error, statusCode uint32) *compositor.EvidenceOut {
+	return &compositor.EvidenceOut{
+		Status: &compositor.Status{
+			Result: false, Error: e.Error(),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+func (a *Attester) GetOptions() *compositor.OptionsOut {
+	return &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+}
+
+func (a *Attester) GetSubAttesterID() *compositor.SubAttesterIDOut {
+	return &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+}
+
+func (a *Attester) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	return &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+}
+
+// GetEvidence returns the ratsd-self evidence. With an anchor, the evidence
+// and the anchoring TEE evidence are returned as records.
+func (a *Attester) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	if uint32(len(in.Nonce)) != nonceSize {
+		errMsg := fmt.Errorf(
+			"nonce size of the ratsd-self attester should be %d, got %d",
+			nonceSize, uint32(len(in.Nonce)))
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	if !slices.ContainsFunc(supportedFormats, func(f *compositor.Format) bool {
+		return f.ContentType == in.ContentType
+	}) {
+		errMsg := fmt.Errorf("no supported format in ratsd-self attester matches the requested format")
+		return getEvidenceError(errMsg, http.StatusBadRequest)
+	}
+
+	out := a.evidence
+	out.Nonce = in.Nonce
+
+	var encodeOp func() ([]byte, error)
+	encodeAs := "JSON"
+
+	if in.ContentType == tokens.RatsdSelfMediaTypeCBOR {
+		encodeOp = out.ToCBOR
+		encodeAs = "CBOR"
+	} else {
+		encodeOp = out.ToJSON
+	}
+
+	outEncoded, err := encodeOp()
+	if err != nil {
+		errMsg := fmt.Errorf("failed to encode ratsd-self evidence as %s: %v", encodeAs, err)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	if a.anchor == nil {
+		return &compositor.EvidenceOut{
+			Status:     statusSucceeded,
+			Evidence:   outEncoded,
+			StatusCode: http.StatusOK,
+		}
+	}
+
+	anchorOut := a.anchor.GetEvidence(&compositor.EvidenceIn{
+		ContentType: a.anchorFormat.ContentType,
+		Nonce:       tokens.RatsdSelfAnchorData(outEncoded),
+	})
+	if !anchorOut.Status.Result {
+		errMsg := fmt.Errorf("failed to anchor ratsd-self evidence: %s", anchorOut.Status.Error)
+		return getEvidenceError(errMsg, http.StatusInternalServerError)
+	}
+
+	anchorRecord := &compositor.Record{
+		Key:         "anchor",
+		ContentType: a.anchorFormat.ContentType,
+		Value:       anchorOut.Evidence,
+		Indicator:   uint32(cmw.Evidence),
+	}
+	if len(anchorOut.Records) > 0 {
+		anchorRecord = &compositor.Record{
+			Key:            "anchor",
+			CollectionType: anchorOut.CollectionType,
+			Records:        anchorOut.Records,
+		}
+	}
+
+	return &compositor.EvidenceOut{
+		Status:         statusSucceeded,
+		StatusCode:     http.StatusOK,
+		CollectionType: tokens.RatsdSelfCollectionType,
+		Records: []*compositor.Record{
+			{
+				Key:         "evidence",
+				ContentType: in.ContentType,
+				Value:       outEncoded,
+				Indicator:   uint32(cmw.Evidence),
+			},
+			anchorRecord,
+		},
+	}
+}
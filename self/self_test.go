@@ -0,0 +1,279 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package self
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
+	"github.com/veraison/ratsd/attesters/mocktsm"
+	"github.com/veraison/ratsd/attesters/tsm"
+	"github.com/veraison/ratsd/plugin"
+	"github.com/veraison/ratsd/proto/compositor"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var (
+	testNonce     = bytes.Repeat([]byte{0xab}, nonceSize)
+	testStartTime = time.Date(2026, 10, 19, 8, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	testPlugins   = []plugin.PluginInfo{
+		{
+			Name:    "tsm-report",
+			Version: "1.0.0",
+			Path:    "/usr/lib/ratsd/tsm.plugin",
+			Digest:  bytes.Repeat([]byte{0x03}, 32),
+		},
+	}
+)
+
+// fakeAnchor is an anchor returning the formats and evidence of its fields
+type fakeAnchor struct {
+	mocktsm.MockPlugin
+	formats *compositor.SupportedFormatsOut
+	out     *compositor.EvidenceOut
+}
+
+func (a *fakeAnchor) GetSupportedFormats() *compositor.SupportedFormatsOut {
+	return a.formats
+}
+
+func (a *fakeAnchor) GetEvidence(in *compositor.EvidenceIn) *compositor.EvidenceOut {
+	return a.out
+}
+
+// writeFile writes a file in a temporary directory, and returns its path and
+// SHA-256 digest
+func writeFile(t *testing.T, name, contents string) (string, []byte) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+
+	digest := sha256.Sum256([]byte(contents))
+	return path, digest[:]
+}
+
+func newTestAttester(t *testing.T, anchor plugin.IPluggable) *Attester {
+	t.Helper()
+
+	executable, _ := writeFile(t, "ratsd", "ratsd")
+	config, _ := writeFile(t, "config.yaml", "ratsd:\n  self-attester: true\n")
+
+	a, err := New(Config{
+		Executable: executable,
+		ConfigFile: config,
+		Plugins:    testPlugins,
+		StartTime:  testStartTime,
+		Anchor:     anchor,
+	})
+	require.NoError(t, err)
+
+	return a
+}
+
+func Test_New(t *testing.T) {
+	executable, executableDigest := writeFile(t, "ratsd", "ratsd")
+	config, configDigest := writeFile(t, "config.yaml", "ratsd:\n  self-attester: true\n")
+
+	a, err := New(Config{
+		Executable: executable,
+		ConfigFile: config,
+		Plugins:    testPlugins,
+		StartTime:  testStartTime,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, tokens.RatsdSelfEvidence{
+		Executable: tokens.RatsdSelfComponent{Path: executable, Digest: executableDigest},
+		Config:     &tokens.RatsdSelfComponent{Path: config, Digest: configDigest},
+		Plugins: []tokens.RatsdSelfPlugin{
+			{
+				Name:    "tsm-report",
+				Version: "1.0.0",
+				Path:    "/usr/lib/ratsd/tsm.plugin",
+				Digest:  bytes.Repeat([]byte{0x03}, 32),
+			},
+		},
+		StartTime: "2026-10-19T06:00:00Z",
+	}, a.evidence)
+
+	// the executable defaults to that of the process, and the configuration
+	// file is optional
+	a, err = New(Config{StartTime: testStartTime})
+	require.NoError(t, err)
+	path, err := os.Executable()
+	require.NoError(t, err)
+	assert.Equal(t, path, a.evidence.Executable.Path)
+	assert.Nil(t, a.evidence.Config)
+	assert.Nil(t, a.evidence.Plugins)
+}
+
+func Test_New_Fail(t *testing.T) {
+	executable, _ := writeFile(t, "ratsd", "ratsd")
+
+	_, err := New(Config{Executable: "none"})
+	assert.ErrorContains(t, err, "failed to measure the ratsd executable: open none: ")
+
+	_, err = New(Config{Executable: executable, ConfigFile: "none.yaml"})
+	assert.ErrorContains(t, err, "failed to measure the ratsd configuration: open none.yaml: ")
+
+	_, err = New(Config{Executable: executable, Anchor: &fakeAnchor{
+		formats: &compositor.SupportedFormatsOut{
+			Status: &compositor.Status{Result: false, Error: "no TEE"},
+		},
+	}})
+	assert.EqualError(t, err, "anchor is not available: no TEE")
+
+	_, err = New(Config{Executable: executable, Anchor: &fakeAnchor{
+		formats: &compositor.SupportedFormatsOut{
+			Status:  statusSucceeded,
+			Formats: []*compositor.Format{{ContentType: tokens.TPMQuoteMediaTypeCBOR, NonceSize: 32}},
+		},
+	}})
+	assert.EqualError(t, err, "anchor has no format with a nonce size of 64")
+}
+
+func Test_NewAnchor(t *testing.T) {
+	a, err := NewAnchor(AnchorTSM)
+	require.NoError(t, err)
+	assert.Equal(t, &tsm.TSMPlugin{}, a)
+
+	_, err = NewAnchor("rtmr")
+	assert.EqualError(t, err, "anchor rtmr is not supported")
+}
+
+func Test_GetOptions(t *testing.T) {
+	expected := &compositor.OptionsOut{
+		Options: []*compositor.Option{},
+		Status:  statusSucceeded,
+	}
+
+	assert.Equal(t, expected, newTestAttester(t, nil).GetOptions())
+}
+
+func Test_GetSubAttesterID(t *testing.T) {
+	expected := &compositor.SubAttesterIDOut{
+		SubAttesterID: sid,
+		Status:        statusSucceeded,
+	}
+
+	assert.Equal(t, expected, newTestAttester(t, nil).GetSubAttesterID())
+}
+
+func Test_GetSupportedFormats(t *testing.T) {
+	expected := &compositor.SupportedFormatsOut{
+		Status:  statusSucceeded,
+		Formats: supportedFormats,
+	}
+
+	assert.Equal(t, expected, newTestAttester(t, nil).GetSupportedFormats())
+}
+
+func Test_GetEvidence(t *testing.T) {
+	a := newTestAttester(t, nil)
+
+	for _, ct := range []string{tokens.RatsdSelfMediaTypeJSON, tokens.RatsdSelfMediaTypeCBOR} {
+		out := a.GetEvidence(&compositor.EvidenceIn{ContentType: ct, Nonce: testNonce})
+		require.True(t, out.Status.Result, out.Status.Error)
+		assert.Equal(t, uint32(http.StatusOK), out.StatusCode)
+		assert.Empty(t, out.Records)
+
+		e := &tokens.RatsdSelfEvidence{}
+		if ct == tokens.RatsdSelfMediaTypeCBOR {
+			require.NoError(t, e.FromCBOR(out.Evidence))
+		} else {
+			require.NoError(t, e.FromJSON(out.Evidence))
+		}
+
+		expected := a.evidence
+		expected.Nonce = testNonce
+		assert.Equal(t, &expected, e)
+	}
+
+	// the nonce of a request does not leak into the next
+	assert.Empty(t, a.evidence.Nonce)
+}
+
+func Test_GetEvidence_anchored(t *testing.T) {
+	a := newTestAttester(t, mocktsm.GetPlugin())
+
+	out := a.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.RatsdSelfMediaTypeJSON, Nonce: testNonce})
+	require.True(t, out.Status.Result, out.Status.Error)
+	assert.Equal(t, tokens.RatsdSelfCollectionType, out.CollectionType)
+	require.Len(t, out.Records, 2)
+
+	evidence := out.Records[0]
+	assert.Equal(t, "evidence", evidence.Key)
+	assert.Equal(t, tokens.RatsdSelfMediaTypeJSON, evidence.ContentType)
+	assert.Equal(t, uint32(cmw.Evidence), evidence.Indicator)
+
+	anchor := out.Records[1]
+	assert.Equal(t, "anchor", anchor.Key)
+	assert.Equal(t, tokens.TSMReportMediaTypeJSON, anchor.ContentType)
+	assert.Equal(t, uint32(cmw.Evidence), anchor.Indicator)
+
+	// the report data of the fake TSM report is the digest of the evidence
+	report := &tokens.TSMReport{}
+	require.NoError(t, report.FromJSON(anchor.Value))
+	assert.Contains(t, string(report.OutBlob),
+		"inblob: "+hex.EncodeToString(tokens.RatsdSelfAnchorData(evidence.Value)))
+}
+
+func Test_GetEvidence_anchor_records(t *testing.T) {
+	records := []*compositor.Record{
+		{Key: "report", ContentType: tokens.SEVSNPReportMediaType, Value: []byte("report"), Indicator: uint32(cmw.Evidence)},
+	}
+	a := newTestAttester(t, &fakeAnchor{
+		formats: mocktsm.GetPlugin().GetSupportedFormats(),
+		out: &compositor.EvidenceOut{
+			Status:         statusSucceeded,
+			StatusCode:     http.StatusOK,
+			CollectionType: "tag:github.com,2026:veraison/ratsd/cmw/tsm-report",
+			Records:        records,
+		},
+	})
+
+	out := a.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.RatsdSelfMediaTypeCBOR, Nonce: testNonce})
+	require.True(t, out.Status.Result, out.Status.Error)
+	require.Len(t, out.Records, 2)
+	assert.Equal(t, &compositor.Record{
+		Key:            "anchor",
+		CollectionType: "tag:github.com,2026:veraison/ratsd/cmw/tsm-report",
+		Records:        records,
+	}, out.Records[1])
+}
+
+func Test_GetEvidence_Fail(t *testing.T) {
+	a := newTestAttester(t, nil)
+
+	out := a.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.RatsdSelfMediaTypeJSON, Nonce: []byte("nonce")})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "nonce size of the ratsd-self attester should be 64, got 5", out.Status.Error)
+
+	out = a.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.TSMReportMediaTypeJSON, Nonce: testNonce})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusBadRequest), out.StatusCode)
+	assert.Equal(t, "no supported format in ratsd-self attester matches the requested format", out.Status.Error)
+
+	a = newTestAttester(t, &fakeAnchor{
+		formats: mocktsm.GetPlugin().GetSupportedFormats(),
+		out: &compositor.EvidenceOut{
+			Status:     &compositor.Status{Result: false, Error: "TSM busy"},
+			StatusCode: http.StatusInternalServerError,
+		},
+	})
+	out = a.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.RatsdSelfMediaTypeJSON, Nonce: testNonce})
+	assert.False(t, out.Status.Result)
+	assert.Equal(t, uint32(http.StatusInternalServerError), out.StatusCode)
+	assert.Equal(t, "failed to anchor ratsd-self evidence: TSM busy", out.Status.Error)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+)
+
+// CCATokenMediaType is the media type of a CCA attestation token
+const CCATokenMediaType = `application/eat-collection; profile="http://arm.com/CCA-SSD/1.0.0"`
+
+const (
+	ccaTokenTag         = 399
+	ccaPlatformTokenKey = 44234
+	ccaRealmTokenKey    = 44241
+)
+
+// CCASoftwareComponent is a measured software component of the CCA platform
+type CCASoftwareComponent struct {
+	MeasurementType  string `cbor:"1,keyasint,omitempty"`
+	MeasurementValue []byte `cbor:"2,keyasint"`
+	Version          string `cbor:"4,keyasint,omitempty"`
+	SignerID         []byte `cbor:"5,keyasint"`
+	HashAlgID        string `cbor:"6,keyasint,omitempty"`
+}
+
+// CCAPlatformClaims are the claims of the CCA platform token
+type CCAPlatformClaims struct {
+	Profile             string                 `cbor:"265,keyasint"`
+	Challenge           []byte                 `cbor:"10,keyasint"`
+	InstanceID          []byte                 `cbor:"256,keyasint"`
+	ImplementationID    []byte                 `cbor:"2396,keyasint"`
+	SecurityLifeCycle   uint16                 `cbor:"2395,keyasint"`
+	SoftwareComponents  []CCASoftwareComponent `cbor:"2399,keyasint"`
+	VerificationService string                 `cbor:"2400,keyasint,omitempty"`
+	Config              []byte                 `cbor:"2401,keyasint"`
+	HashAlgID           string                 `cbor:"2402,keyasint"`
+}
+
+// CCARealmClaims are the claims of the CCA realm token. Challenge carries the
+// inblob written to configfs-TSM.
+type CCARealmClaims struct {
+	Profile                string   `cbor:"265,keyasint,omitempty"`
+	Challenge              []byte   `cbor:"10,keyasint"`
+	PersonalizationValue   []byte   `cbor:"44235,keyasint"`
+	HashAlgID              string   `cbor:"44236,keyasint"`
+	PublicKey              []byte   `cbor:"44237,keyasint"`
+	InitialMeasurement     []byte   `cbor:"44238,keyasint"`
+	ExtensibleMeasurements [][]byte `cbor:"44239,keyasint"`
+	PublicKeyHashAlgID     string   `cbor:"44240,keyasint"`
+}
+
+// CCAToken is the attestation token returned in the outblob of the
+// arm_cca_guest TSM provider. PlatformToken and RealmToken hold the COSE_Sign1
+// messages the claims were decoded from.
+type CCAToken struct {
+	Platform      CCAPlatformClaims
+	Realm         CCARealmClaims
+	PlatformToken []byte
+	RealmToken    []byte
+}
+
+// ParseCCAToken decodes a CCA attestation token. The signatures of the
+// platform and realm tokens are not verified.
+func ParseCCAToken(data []byte) (*CCAToken, error) {
+	var tag cbor.RawTag
+	if err := cbor.Unmarshal(data, &tag); err != nil {
+		return nil, fmt.Errorf("CCA token decoding failed: %w", err)
+	}
+
+	if tag.Number != ccaTokenTag {
+		return nil, fmt.Errorf("CCA token decoding failed: unexpected tag %d", tag.Number)
+	}
+
+	var collection map[uint64][]byte
+	if err := cbor.Unmarshal(tag.Content, &collection); err != nil {
+		return nil, fmt.Errorf("CCA token decoding failed: %w", err)
+	}
+
+	t := &CCAToken{
+		PlatformToken: collection[ccaPlatformTokenKey],
+		RealmToken:    collection[ccaRealmTokenKey],
+	}
+
+	if err := decodeCCAClaims("platform", t.PlatformToken, &t.Platform); err != nil {
+		return nil, err
+	}
+
+	if err := decodeCCAClaims("realm", t.RealmToken, &t.Realm); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func decodeCCAClaims(name string, token []byte, claims any) error {
+	if len(token) == 0 {
+		return fmt.Errorf("CCA token decoding failed: missing %s token", name)
+	}
+
+	var msg cose.Sign1Message
+	if err := msg.UnmarshalCBOR(token); err != nil {
+		return fmt.Errorf("CCA %s token decoding failed: %w", name, err)
+	}
+
+	if err := cbor.Unmarshal(msg.Payload, claims); err != nil {
+		return fmt.Errorf("CCA %s token decoding failed: %w", name, err)
+	}
+
+	return nil
+}
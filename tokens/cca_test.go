@@ -0,0 +1,73 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCCAReport(t *testing.T) *TSMReport {
+	t.Helper()
+
+	data, err := os.ReadFile("../docs/ex-basic-arm-cca.json")
+	require.NoError(t, err)
+
+	report := &TSMReport{}
+	require.NoError(t, report.FromJSON(data))
+	return report
+}
+
+func Test_ParseCCAToken_Pass(t *testing.T) {
+	token, err := ParseCCAToken(testCCAReport(t).OutBlob)
+	require.NoError(t, err)
+
+	assert.Equal(t, "tag:arm.com,2023:cca_platform#1.0.0", token.Platform.Profile)
+	assert.Len(t, token.Platform.Challenge, 32)
+	assert.Len(t, token.Platform.InstanceID, 33)
+	assert.Len(t, token.Platform.ImplementationID, 32)
+	assert.Equal(t, uint16(0x3003), token.Platform.SecurityLifeCycle)
+	assert.Equal(t, []byte{0xcf, 0xcf, 0xcf, 0xcf}, token.Platform.Config)
+	assert.Equal(t, "sha-256", token.Platform.HashAlgID)
+	assert.Equal(t, "https://veraison.example/.well-known/veraison/verification",
+		token.Platform.VerificationService)
+	require.Len(t, token.Platform.SoftwareComponents, 13)
+	assert.Equal(t, "RSE_BL1_2", token.Platform.SoftwareComponents[0].MeasurementType)
+	assert.Len(t, token.Platform.SoftwareComponents[0].MeasurementValue, 32)
+
+	assert.Equal(t, "tag:arm.com,2023:realm#1.0.0", token.Realm.Profile)
+	assert.Len(t, token.Realm.Challenge, 64)
+	assert.Equal(t, "sha-256", token.Realm.HashAlgID)
+	assert.Len(t, token.Realm.InitialMeasurement, 32)
+	assert.Len(t, token.Realm.ExtensibleMeasurements, 4)
+	assert.NotEmpty(t, token.Realm.PublicKey)
+	assert.NotEmpty(t, token.PlatformToken)
+	assert.NotEmpty(t, token.RealmToken)
+}
+
+func Test_ParseCCAToken_Fail(t *testing.T) {
+	wrongTag, err := cbor.Marshal(cbor.Tag{Number: 400, Content: map[uint64][]byte{}})
+	require.NoError(t, err)
+
+	noRealm, err := cbor.Marshal(cbor.Tag{
+		Number:  ccaTokenTag,
+		Content: map[uint64][]byte{ccaPlatformTokenKey: {0xd2}},
+	})
+	require.NoError(t, err)
+
+	noPlatform, err := cbor.Marshal(cbor.Tag{Number: ccaTokenTag, Content: map[uint64][]byte{}})
+	require.NoError(t, err)
+
+	_, err = ParseCCAToken(wrongTag)
+	assert.EqualError(t, err, "CCA token decoding failed: unexpected tag 400")
+
+	_, err = ParseCCAToken(noPlatform)
+	assert.EqualError(t, err, "CCA token decoding failed: missing platform token")
+
+	_, err = ParseCCAToken(noRealm)
+	assert.ErrorContains(t, err, "CCA platform token decoding failed")
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-tpm/tpm2"
+)
+
+const (
+	CELMediaTypeCBOR = "application/vnd.veraison.cel+cbor"
+	CELMediaTypeJSON = "application/vnd.veraison.cel+json"
+)
+
+// Types of the measurement registers extended by CEL records, which are the
+// types of the index TLV of the records
+const (
+	// CELIndexPCR is a TPM PCR
+	CELIndexPCR = 1
+	// CELIndexCCMR is a measurement register of a confidential computing
+	// platform, e.g., a TDX RTMR, with the same type as in go-eventlog
+	CELIndexCCMR = 108
+)
+
+// CELContentRatsdPlugin is the content type of the records measuring the
+// plugins of ratsd, in the range of the types not assigned by the CEL
+// specification. Its value is a CELPluginContent.
+const CELContentRatsdPlugin = 0x52
+
+const (
+	celRecnumType  = 0
+	celDigestsType = 3
+
+	celPluginPathType   = 0
+	celPluginDigestType = 1
+)
+
+var errCELTruncated = errors.New("CEL truncated")
+
+// CEL represents a Canonical Event Log in its TLV encoding, as specified by
+// the TCG Canonical Event Log Format
+// see docs/cel.cddl for definition
+type CEL struct {
+	// Nonce is the nonce of the request. The plugin measurements precede the
+	// request, and are bound to it only through the register they extend, as
+	// reported by the TEE evidence carrying the same nonce.
+	Nonce BinaryString `json:"nonce"`
+	// Raw is the CEL-TLV encoding of the log, which is empty if no event
+	// was recorded
+	Raw BinaryString `json:"raw"`
+	// Records are the records parsed from Raw
+	Records []CELRecord `json:"records"`
+}
+
+// CELRecord is a record of a Canonical Event Log
+type CELRecord struct {
+	RecNum uint64 `json:"recnum"`
+	// IndexType is the type of the extended register, CELIndexPCR or
+	// CELIndexCCMR
+	IndexType uint8 `json:"index_type"`
+	// Index is the index of the extended register
+	Index       uint8         `json:"index"`
+	Digests     []EventDigest `json:"digests"`
+	ContentType uint8         `json:"content_type"`
+	Content     BinaryString  `json:"content"`
+}
+
+// CELPluginContent is the content of the records of type
+// CELContentRatsdPlugin
+type CELPluginContent struct {
+	// Path is the path of the plugin executable
+	Path string
+	// Digest is the SHA-256 digest of the plugin executable
+	Digest []byte
+}
+
+// Valid checks if the CEL is populated correctly
+func (t *CEL) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	if len(t.Raw) != 0 && len(t.Records) == 0 {
+		return errors.New(`missing mandatory field "records"`)
+	}
+
+	return nil
+}
+
+// ToJSON encodes CEL as JSON
+func (t *CEL) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes CEL from JSON
+func (t *CEL) FromJSON(data []byte) error {
+	err := json.Unmarshal(data, t)
+	if err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes CEL as CBOR
+func (t *CEL) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes CEL from CBOR
+func (t *CEL) FromCBOR(data []byte) error {
+	err := cbor.Unmarshal(data, t)
+	if err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// Replay extends the digests of the given bank into registers that start
+// zeroed, and returns the resulting values, by register index
+func (t *CEL) Replay(alg tpm2.TPMAlgID) (map[uint32][]byte, error) {
+	events := make([]Event, 0, len(t.Records))
+	for _, r := range t.Records {
+		events = append(events, Event{Index: uint32(r.Index), Digests: r.Digests})
+	}
+
+	return (&EventLog{Events: events}).Replay(alg)
+}
+
+// ToTLV returns the CEL-TLV encoding of the record
+func (r *CELRecord) ToTLV() ([]byte, error) {
+	var buf bytes.Buffer
+
+	recnum := binary.BigEndian.AppendUint64(nil, r.RecNum)
+	writeTLV(&buf, celRecnumType, recnum)
+	writeTLV(&buf, r.IndexType, []byte{r.Index})
+
+	var digests bytes.Buffer
+	for _, d := range r.Digests {
+		if d.AlgID > 0xff {
+			return nil, fmt.Errorf("algorithm %#x does not fit a CEL digest", d.AlgID)
+		}
+		writeTLV(&digests, uint8(d.AlgID), d.Digest)
+	}
+	writeTLV(&buf, celDigestsType, digests.Bytes())
+	writeTLV(&buf, r.ContentType, r.Content)
+
+	return buf.Bytes(), nil
+}
+
+// ParseCEL parses the CEL-TLV encoding of a Canonical Event Log
+func ParseCEL(data []byte) ([]CELRecord, error) {
+	var records []CELRecord
+
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		record, err := readCELRecord(r)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", len(records), err)
+		}
+		records = append(records, *record)
+	}
+
+	return records, nil
+}
+
+func readCELRecord(r *bytes.Reader) (*CELRecord, error) {
+	typ, recnum, err := readTLV(r)
+	if err != nil {
+		return nil, err
+	}
+	if typ != celRecnumType || len(recnum) != 8 {
+		return nil, errors.New("malformed record number")
+	}
+
+	indexType, index, err := readTLV(r)
+	if err != nil {
+		return nil, err
+	}
+	if (indexType != CELIndexPCR && indexType != CELIndexCCMR) || len(index) != 1 {
+		return nil, fmt.Errorf("unsupported register index of type %d", indexType)
+	}
+
+	typ, digests, err := readTLV(r)
+	if err != nil {
+		return nil, err
+	}
+	if typ != celDigestsType {
+		return nil, errors.New("malformed digests")
+	}
+
+	record := &CELRecord{
+		RecNum:    binary.BigEndian.Uint64(recnum),
+		IndexType: indexType,
+		Index:     index[0],
+	}
+
+	dr := bytes.NewReader(digests)
+	for dr.Len() > 0 {
+		alg, digest, err := readTLV(dr)
+		if err != nil {
+			return nil, err
+		}
+		record.Digests = append(record.Digests, EventDigest{AlgID: uint16(alg), Digest: digest})
+	}
+
+	record.ContentType, record.Content, err = readTLV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ToTLV returns the TLV encoding of the content
+func (c *CELPluginContent) ToTLV() ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeTLV(&buf, celPluginPathType, []byte(c.Path))
+	writeTLV(&buf, celPluginDigestType, c.Digest)
+
+	return buf.Bytes(), nil
+}
+
+// FromTLV decodes the content from its TLV encoding
+func (c *CELPluginContent) FromTLV(data []byte) error {
+	r := bytes.NewReader(data)
+
+	typ, path, err := readTLV(r)
+	if err != nil {
+		return err
+	}
+	if typ != celPluginPathType {
+		return errors.New("missing plugin path")
+	}
+
+	typ, digest, err := readTLV(r)
+	if err != nil {
+		return err
+	}
+	if typ != celPluginDigestType {
+		return errors.New("missing plugin digest")
+	}
+
+	if r.Len() != 0 {
+		return errors.New("trailing data after plugin digest")
+	}
+
+	c.Path = string(path)
+	c.Digest = digest
+
+	return nil
+}
+
+func writeTLV(buf *bytes.Buffer, typ uint8, value []byte) {
+	buf.WriteByte(typ)
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(value))))
+	buf.Write(value)
+}
+
+func readTLV(r *bytes.Reader) (uint8, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, errCELTruncated
+	}
+
+	length := binary.BigEndian.Uint32(header[1:])
+	if int64(length) > int64(r.Len()) {
+		return 0, nil, errCELTruncated
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return 0, nil, errCELTruncated
+	}
+
+	return header[0], value, nil
+}
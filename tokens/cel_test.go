@@ -0,0 +1,154 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCELRecord returns a record of a plugin measurement extending the given
+// RTMR
+func testCELRecord(t *testing.T, recnum uint64, index uint8, path string) CELRecord {
+	t.Helper()
+
+	digest := sha256.Sum256([]byte(path))
+	content, err := (&CELPluginContent{Path: path, Digest: digest[:]}).ToTLV()
+	require.NoError(t, err)
+	eventDigest := sha512.Sum384(content)
+
+	return CELRecord{
+		RecNum:      recnum,
+		IndexType:   CELIndexCCMR,
+		Index:       index,
+		Digests:     []EventDigest{{AlgID: uint16(tpm2.TPMAlgSHA384), Digest: eventDigest[:]}},
+		ContentType: CELContentRatsdPlugin,
+		Content:     content,
+	}
+}
+
+func testCEL(t *testing.T) ([]CELRecord, []byte) {
+	t.Helper()
+
+	records := []CELRecord{
+		testCELRecord(t, 0, 3, "/usr/lib/ratsd/tsm.plugin"),
+		testCELRecord(t, 1, 3, "/usr/lib/ratsd/tpm.plugin"),
+	}
+
+	var raw []byte
+	for _, r := range records {
+		encoded, err := r.ToTLV()
+		require.NoError(t, err)
+		raw = append(raw, encoded...)
+	}
+
+	return records, raw
+}
+
+func Test_ParseCEL(t *testing.T) {
+	records, raw := testCEL(t)
+
+	parsed, err := ParseCEL(raw)
+	require.NoError(t, err)
+	assert.Equal(t, records, parsed)
+
+	content := &CELPluginContent{}
+	require.NoError(t, content.FromTLV(parsed[1].Content))
+	digest := sha256.Sum256([]byte("/usr/lib/ratsd/tpm.plugin"))
+	assert.Equal(t, &CELPluginContent{Path: "/usr/lib/ratsd/tpm.plugin", Digest: digest[:]}, content)
+
+	parsed, err = ParseCEL(nil)
+	require.NoError(t, err)
+	assert.Empty(t, parsed)
+}
+
+func Test_ParseCEL_Fail(t *testing.T) {
+	_, raw := testCEL(t)
+
+	_, err := ParseCEL(raw[:len(raw)-1])
+	assert.EqualError(t, err, "record 1: CEL truncated")
+
+	// NV index
+	bad := bytes.Clone(raw)
+	bad[13] = 2
+	_, err = ParseCEL(bad)
+	assert.EqualError(t, err, "record 0: unsupported register index of type 2")
+
+	bad = bytes.Clone(raw)
+	bad[0] = 1
+	_, err = ParseCEL(bad)
+	assert.EqualError(t, err, "record 0: malformed record number")
+}
+
+func Test_CELPluginContent_Fail(t *testing.T) {
+	content := &CELPluginContent{}
+
+	assert.EqualError(t, content.FromTLV([]byte{1, 0, 0, 0, 0}), "missing plugin path")
+	assert.EqualError(t, content.FromTLV([]byte{0, 0, 0, 0, 0}), "CEL truncated")
+	assert.EqualError(t, content.FromTLV([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}), "missing plugin digest")
+	assert.EqualError(t, content.FromTLV([]byte{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0}), "trailing data after plugin digest")
+}
+
+func Test_CEL_Valid_Fail(t *testing.T) {
+	_, raw := testCEL(t)
+
+	for _, tc := range []struct {
+		cel      CEL
+		expected string
+	}{
+		{CEL{Raw: raw}, `missing mandatory field "nonce"`},
+		{CEL{Nonce: []byte("nonce"), Raw: raw}, `missing mandatory field "records"`},
+	} {
+		assert.EqualError(t, tc.cel.Valid(), tc.expected)
+	}
+}
+
+func Test_CEL_JSON_SerDes_Pass(t *testing.T) {
+	records, raw := testCEL(t)
+	expected := &CEL{Nonce: []byte("nonce"), Raw: raw, Records: records}
+
+	data, err := expected.ToJSON()
+	require.NoError(t, err)
+
+	actual := &CEL{}
+	require.NoError(t, actual.FromJSON(data))
+	assert.Equal(t, expected, actual)
+}
+
+func Test_CEL_CBOR_SerDes_Pass(t *testing.T) {
+	records, raw := testCEL(t)
+	expected := &CEL{Nonce: []byte("nonce"), Raw: raw, Records: records}
+
+	data, err := expected.ToCBOR()
+	require.NoError(t, err)
+
+	actual := &CEL{}
+	require.NoError(t, actual.FromCBOR(data))
+	assert.Equal(t, expected, actual)
+}
+
+func Test_CEL_Replay(t *testing.T) {
+	records, _ := testCEL(t)
+	l := &CEL{Records: records}
+
+	expected := make([]byte, sha512.Size384)
+	for _, r := range records {
+		d := sha512.New384()
+		d.Write(expected)
+		d.Write(r.Digests[0].Digest)
+		expected = d.Sum(nil)
+	}
+
+	registers, err := l.Replay(tpm2.TPMAlgSHA384)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32][]byte{3: expected}, registers)
+
+	_, err = l.Replay(tpm2.TPMAlgSHA256)
+	assert.EqualError(t, err, "event 0: no digest for algorithm 0xb")
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Token is implemented by the token types that have equivalent JSON and CBOR
+// serializations, such as TSMReport.
+type Token interface {
+	ToJSON() ([]byte, error)
+	FromJSON(data []byte) error
+	ToCBOR() ([]byte, error)
+	FromCBOR(data []byte) error
+}
+
+// Encoding identifies the serialization used by a media type
+type Encoding int
+
+const (
+	EncodingJSON Encoding = iota
+	EncodingCBOR
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingJSON:
+		return "JSON"
+	case EncodingCBOR:
+		return "CBOR"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+// codec describes how a media type maps to a token type. Media types sharing
+// the same family carry the same token type in different encodings.
+type codec struct {
+	family   string
+	encoding Encoding
+	newToken func() Token
+}
+
+var codecs = make(map[string]codec)
+
+func init() {
+	newTSMReport := func() Token { return &TSMReport{} }
+
+	if err := RegisterCodec("tsm-report", TSMReportMediaTypeJSON, EncodingJSON, newTSMReport); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("tsm-report", TSMReportMediaTypeCBOR, EncodingCBOR, newTSMReport); err != nil {
+		panic(err)
+	}
+
+	newTPMQuote := func() Token { return &TPMQuote{} }
+
+	if err := RegisterCodec("tpm-quote", TPMQuoteMediaTypeJSON, EncodingJSON, newTPMQuote); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("tpm-quote", TPMQuoteMediaTypeCBOR, EncodingCBOR, newTPMQuote); err != nil {
+		panic(err)
+	}
+
+	newIMALog := func() Token { return &IMALog{} }
+
+	if err := RegisterCodec("ima-log", IMALogMediaTypeJSON, EncodingJSON, newIMALog); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("ima-log", IMALogMediaTypeCBOR, EncodingCBOR, newIMALog); err != nil {
+		panic(err)
+	}
+
+	newEventLog := func() Token { return &EventLog{} }
+
+	if err := RegisterCodec("event-log", EventLogMediaTypeJSON, EncodingJSON, newEventLog); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("event-log", EventLogMediaTypeCBOR, EncodingCBOR, newEventLog); err != nil {
+		panic(err)
+	}
+
+	newCEL := func() Token { return &CEL{} }
+
+	if err := RegisterCodec("cel", CELMediaTypeJSON, EncodingJSON, newCEL); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("cel", CELMediaTypeCBOR, EncodingCBOR, newCEL); err != nil {
+		panic(err)
+	}
+
+	newNvidiaGPUEvidence := func() Token { return &NvidiaGPUEvidence{} }
+
+	if err := RegisterCodec("nvidia-gpu-evidence", NvidiaGPUEvidenceMediaTypeJSON, EncodingJSON, newNvidiaGPUEvidence); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("nvidia-gpu-evidence", NvidiaGPUEvidenceMediaTypeCBOR, EncodingCBOR, newNvidiaGPUEvidence); err != nil {
+		panic(err)
+	}
+
+	newDICEChain := func() Token { return &DICEChain{} }
+
+	if err := RegisterCodec("dice-chain", DICEChainMediaTypeJSON, EncodingJSON, newDICEChain); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("dice-chain", DICEChainMediaTypeCBOR, EncodingCBOR, newDICEChain); err != nil {
+		panic(err)
+	}
+
+	newSPDMEvidence := func() Token { return &SPDMEvidence{} }
+
+	if err := RegisterCodec("spdm-evidence", SPDMEvidenceMediaTypeJSON, EncodingJSON, newSPDMEvidence); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterCodec("spdm-evidence", SPDMEvidenceMediaTypeCBOR, EncodingCBOR, newSPDMEvidence); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterCodec associates mediaType with a token family and encoding.
+// Evidence in one media type of a family can be transcoded to any other media
+// type of the same family.
+func RegisterCodec(family, mediaType string, encoding Encoding, newToken func() Token) error {
+	if family == "" {
+		return fmt.Errorf("empty codec family for %q", mediaType)
+	}
+
+	if mediaType == "" {
+		return fmt.Errorf("empty media type for codec family %q", family)
+	}
+
+	if newToken == nil {
+		return fmt.Errorf("nil token constructor for %q", mediaType)
+	}
+
+	if encoding != EncodingJSON && encoding != EncodingCBOR {
+		return fmt.Errorf("unsupported encoding %s for %q", encoding, mediaType)
+	}
+
+	if _, ok := codecs[mediaType]; ok {
+		return fmt.Errorf("codec for %q is already registered", mediaType)
+	}
+
+	codecs[mediaType] = codec{
+		family:   family,
+		encoding: encoding,
+		newToken: newToken,
+	}
+
+	return nil
+}
+
+// CanTranscode reports whether evidence in media type from can be converted
+// to media type to.
+func CanTranscode(from, to string) bool {
+	if from == to {
+		return false
+	}
+
+	src, ok := codecs[from]
+	if !ok {
+		return false
+	}
+
+	dst, ok := codecs[to]
+	if !ok {
+		return false
+	}
+
+	return src.family == dst.family
+}
+
+// EquivalentMediaTypes returns the media types that evidence in mediaType can
+// be transcoded to, in lexical order.
+func EquivalentMediaTypes(mediaType string) []string {
+	src, ok := codecs[mediaType]
+	if !ok {
+		return nil
+	}
+
+	var equivalent []string
+	for mt, c := range codecs {
+		if mt != mediaType && c.family == src.family {
+			equivalent = append(equivalent, mt)
+		}
+	}
+	sort.Strings(equivalent)
+
+	return equivalent
+}
+
+// Transcode decodes data as media type from and encodes it again as media
+// type to.
+func Transcode(data []byte, from, to string) ([]byte, error) {
+	if !CanTranscode(from, to) {
+		return nil, fmt.Errorf("cannot transcode %s to %s", from, to)
+	}
+
+	src := codecs[from]
+	dst := codecs[to]
+
+	token := src.newToken()
+	var err error
+	switch src.encoding {
+	case EncodingJSON:
+		err = token.FromJSON(data)
+	case EncodingCBOR:
+		err = token.FromCBOR(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", from, err)
+	}
+
+	var out []byte
+	switch dst.encoding {
+	case EncodingJSON:
+		out, err = token.ToJSON()
+	case EncodingCBOR:
+		out, err = token.ToCBOR()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", to, err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Transcode_TSMReport_Pass(t *testing.T) {
+	report := &TSMReport{
+		Provider: provider,
+		OutBlob:  outblob,
+		AuxBlob:  auxblob,
+	}
+
+	encodedJSON, err := report.ToJSON()
+	require.NoError(t, err)
+
+	encodedCBOR, err := Transcode(encodedJSON, TSMReportMediaTypeJSON, TSMReportMediaTypeCBOR)
+	require.NoError(t, err)
+
+	decodedReport := &TSMReport{}
+	require.NoError(t, decodedReport.FromCBOR(encodedCBOR))
+	assert.Equal(t, report, decodedReport)
+
+	roundTrip, err := Transcode(encodedCBOR, TSMReportMediaTypeCBOR, TSMReportMediaTypeJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(encodedJSON), string(roundTrip))
+}
+
+func Test_Transcode_Fail(t *testing.T) {
+	_, err := Transcode([]byte("{}"), TSMReportMediaTypeJSON, "application/json")
+	assert.EqualError(t, err,
+		"cannot transcode application/vnd.veraison.tsm-report+json to application/json")
+
+	_, err = Transcode([]byte("{}"), TSMReportMediaTypeJSON, TSMReportMediaTypeJSON)
+	assert.EqualError(t, err,
+		"cannot transcode application/vnd.veraison.tsm-report+json to application/vnd.veraison.tsm-report+json")
+
+	_, err = Transcode([]byte(`{"provider": "fake"}`), TSMReportMediaTypeJSON, TSMReportMediaTypeCBOR)
+	assert.EqualError(t, err,
+		`decoding application/vnd.veraison.tsm-report+json: JSON decoding failed: missing mandatory field "outblob"`)
+}
+
+func Test_EquivalentMediaTypes(t *testing.T) {
+	assert.Equal(t, []string{TSMReportMediaTypeCBOR}, EquivalentMediaTypes(TSMReportMediaTypeJSON))
+	assert.Equal(t, []string{TSMReportMediaTypeJSON}, EquivalentMediaTypes(TSMReportMediaTypeCBOR))
+	assert.Nil(t, EquivalentMediaTypes("application/json"))
+}
+
+func Test_RegisterCodec_Fail(t *testing.T) {
+	newTSMReport := func() Token { return &TSMReport{} }
+
+	assert.EqualError(t,
+		RegisterCodec("tsm-report", TSMReportMediaTypeJSON, EncodingJSON, newTSMReport),
+		`codec for "application/vnd.veraison.tsm-report+json" is already registered`)
+	assert.EqualError(t,
+		RegisterCodec("", "application/x-test", EncodingJSON, newTSMReport),
+		`empty codec family for "application/x-test"`)
+	assert.EqualError(t,
+		RegisterCodec("test", "application/x-test", EncodingJSON, nil),
+		`nil token constructor for "application/x-test"`)
+	assert.EqualError(t,
+		RegisterCodec("test", "application/x-test", Encoding(7), newTSMReport),
+		`unsupported encoding Encoding(7) for "application/x-test"`)
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	DICEChainMediaTypeCBOR = "application/vnd.veraison.dice-chain+cbor"
+	DICEChainMediaTypeJSON = "application/vnd.veraison.dice-chain+json"
+)
+
+// Object identifiers of the TCG DICE certificate extensions
+var (
+	OIDDICETcbInfo      = asn1.ObjectIdentifier{2, 23, 133, 5, 4, 1}
+	OIDDICEUEID         = asn1.ObjectIdentifier{2, 23, 133, 5, 4, 4}
+	OIDDICEMultiTcbInfo = asn1.ObjectIdentifier{2, 23, 133, 5, 4, 5}
+)
+
+// ErrDICEUnsigned is returned when verifying a DICE chain without signature
+var ErrDICEUnsigned = errors.New("DICE chain has no signature")
+
+// DICEChain represents the certificate chain of a DICE layered device
+// see docs/dice-chain.cddl for definition
+type DICEChain struct {
+	// Nonce is the nonce of the request. It is integrity-protected only if
+	// Signature is set.
+	Nonce BinaryString `json:"nonce"`
+	// Certificates are the DER encoded certificates of the chain, from the
+	// leaf, i.e., the certificate of the last layer, to the root
+	Certificates []BinaryString `json:"certificates"`
+	// SignatureAlg is the name of the x509.SignatureAlgorithm of Signature,
+	// e.g., "ECDSA-SHA256"
+	SignatureAlg string `json:"signature_alg,omitempty"`
+	// Signature is the signature over Nonce made with the key of the leaf
+	Signature BinaryString `json:"signature,omitempty"`
+}
+
+// DICETcbInfo is the DiceTcbInfo structure of the TcbInfo and MultiTcbInfo
+// extensions
+type DICETcbInfo struct {
+	Vendor     string         `asn1:"optional,tag:0,utf8"`
+	Model      string         `asn1:"optional,tag:1,utf8"`
+	Version    string         `asn1:"optional,tag:2,utf8"`
+	SVN        int            `asn1:"optional,tag:3"`
+	Layer      int            `asn1:"optional,tag:4"`
+	Index      int            `asn1:"optional,tag:5"`
+	FWIDs      []DICEFWID     `asn1:"optional,tag:6"`
+	Flags      asn1.BitString `asn1:"optional,tag:7"`
+	VendorInfo []byte         `asn1:"optional,tag:8"`
+	Type       []byte         `asn1:"optional,tag:9"`
+	FlagsMask  asn1.BitString `asn1:"optional,tag:10"`
+}
+
+// DICEFWID is the digest of a firmware component
+type DICEFWID struct {
+	HashAlg asn1.ObjectIdentifier
+	Digest  []byte
+}
+
+// Valid checks if the DICEChain is populated correctly
+func (t *DICEChain) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	if len(t.Certificates) == 0 {
+		return errors.New(`missing mandatory field "certificates"`)
+	}
+
+	if (t.SignatureAlg == "") != (len(t.Signature) == 0) {
+		return errors.New(`"signature" and "signature_alg" go together`)
+	}
+
+	if t.SignatureAlg != "" && diceSignatureAlgorithm(t.SignatureAlg) == x509.UnknownSignatureAlgorithm {
+		return fmt.Errorf(`unsupported "signature_alg" %q`, t.SignatureAlg)
+	}
+
+	return nil
+}
+
+// ToJSON encodes DICEChain as JSON
+func (t *DICEChain) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes DICEChain from JSON
+func (t *DICEChain) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes DICEChain as CBOR
+func (t *DICEChain) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes DICEChain from CBOR
+func (t *DICEChain) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// Chain returns the parsed certificates of the chain, leaf first
+func (t *DICEChain) Chain() ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for i, der := range t.Certificates {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", i, err)
+		}
+		chain = append(chain, c)
+	}
+
+	return chain, nil
+}
+
+// Verify checks that the chain is rooted in one of roots at currentTime, and
+// that the nonce is signed with the key of the leaf. The zero currentTime
+// means the current time. The TCG DICE extensions, which are often critical
+// even in the root certificates, are not interpreted: appraising them is left
+// to the caller.
+func (t *DICEChain) Verify(roots []*x509.Certificate, currentTime time.Time) error {
+	chain, err := t.Chain()
+	if err != nil {
+		return fmt.Errorf("DICE chain decoding failed: %w", err)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, c := range roots {
+		rootPool.AddCert(withoutDICEExtensions(c))
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(withoutDICEExtensions(c))
+	}
+
+	_, err = withoutDICEExtensions(chain[0]).Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediates,
+		CurrentTime:   currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("DICE chain validation failed: %w", err)
+	}
+
+	if len(t.Signature) == 0 {
+		return ErrDICEUnsigned
+	}
+
+	alg := diceSignatureAlgorithm(t.SignatureAlg)
+	if err := chain[0].CheckSignature(alg, t.Nonce, t.Signature); err != nil {
+		return fmt.Errorf("DICE nonce signature verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// DICETcbInfos returns the DiceTcbInfo structures of the TcbInfo and
+// MultiTcbInfo extensions of cert
+func DICETcbInfos(cert *x509.Certificate) ([]DICETcbInfo, error) {
+	var infos []DICETcbInfo
+
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(OIDDICETcbInfo):
+			var info DICETcbInfo
+			if err := unmarshalDICEExtension(ext.Value, &info); err != nil {
+				return nil, fmt.Errorf("TcbInfo: %w", err)
+			}
+			infos = append(infos, info)
+		case ext.Id.Equal(OIDDICEMultiTcbInfo):
+			var multi []DICETcbInfo
+			if err := unmarshalDICEExtension(ext.Value, &multi); err != nil {
+				return nil, fmt.Errorf("MultiTcbInfo: %w", err)
+			}
+			infos = append(infos, multi...)
+		}
+	}
+
+	return infos, nil
+}
+
+func unmarshalDICEExtension(data []byte, v any) error {
+	rest, err := asn1.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+
+	return nil
+}
+
+// withoutDICEExtensions returns a copy of c whose DICE extensions are marked
+// as handled, so that the certificate passes x509 verification
+func withoutDICEExtensions(c *x509.Certificate) *x509.Certificate {
+	handled := *c
+	handled.UnhandledCriticalExtensions = slices.DeleteFunc(
+		slices.Clone(c.UnhandledCriticalExtensions), isDICEExtension)
+	return &handled
+}
+
+func isDICEExtension(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(OIDDICETcbInfo) || oid.Equal(OIDDICEUEID) || oid.Equal(OIDDICEMultiTcbInfo)
+}
+
+// diceSignatureAlgorithms are the algorithms of the nonce signature
+var diceSignatureAlgorithms = []x509.SignatureAlgorithm{
+	x509.ECDSAWithSHA256,
+	x509.ECDSAWithSHA384,
+	x509.ECDSAWithSHA512,
+	x509.PureEd25519,
+	x509.SHA256WithRSAPSS,
+	x509.SHA384WithRSAPSS,
+	x509.SHA512WithRSAPSS,
+}
+
+// diceSignatureAlgorithm returns the signature algorithm with the given name
+func diceSignatureAlgorithm(name string) x509.SignatureAlgorithm {
+	for _, alg := range diceSignatureAlgorithms {
+		if alg.String() == name {
+			return alg
+		}
+	}
+
+	return x509.UnknownSignatureAlgorithm
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+
+// testDICELayer is a DICE layer with its certificate and key
+type testDICELayer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func testTcbInfo(layer int, firmware string) DICETcbInfo {
+	digest := sha256.Sum256([]byte(firmware))
+
+	return DICETcbInfo{
+		Vendor:  "Veraison",
+		Model:   firmware,
+		Version: "1.0",
+		SVN:     3,
+		Layer:   layer,
+		FWIDs:   []DICEFWID{{HashAlg: oidSHA256, Digest: digest[:]}},
+	}
+}
+
+// newTestDICEChain returns the layers of a DICE chain, from the DeviceID
+// layer to the Alias layer. The TcbInfo extensions are critical, as allowed
+// by the TCG DICE specification.
+func newTestDICEChain(t *testing.T) []testDICELayer {
+	t.Helper()
+
+	notBefore := time.Now().Add(-time.Hour)
+	var layers []testDICELayer
+
+	for i, firmware := range []string{"rom", "bootloader", "kernel"} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		var ext []byte
+		var oid asn1.ObjectIdentifier
+		if i == 1 {
+			oid = OIDDICEMultiTcbInfo
+			ext, err = asn1.Marshal([]DICETcbInfo{testTcbInfo(i, firmware), testTcbInfo(i, "config")})
+		} else {
+			oid = OIDDICETcbInfo
+			ext, err = asn1.Marshal(testTcbInfo(i, firmware))
+		}
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(int64(i + 1)),
+			Subject:               pkix.Name{CommonName: "layer " + firmware},
+			NotBefore:             notBefore,
+			NotAfter:              notBefore.Add(24 * time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  i < 2,
+			ExtraExtensions:       []pkix.Extension{{Id: oid, Critical: true, Value: ext}},
+		}
+
+		parent, parentKey := tmpl, key
+		if i > 0 {
+			parent, parentKey = layers[i-1].cert, layers[i-1].key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+		require.NoError(t, err)
+
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		layers = append(layers, testDICELayer{cert: cert, key: key})
+	}
+
+	return layers
+}
+
+func newTestDICEChainToken(t *testing.T, layers []testDICELayer, nonce []byte) *DICEChain {
+	t.Helper()
+
+	c := &DICEChain{Nonce: nonce}
+	for i := len(layers) - 1; i >= 0; i-- {
+		c.Certificates = append(c.Certificates, layers[i].cert.Raw)
+	}
+
+	digest := sha256.Sum256(nonce)
+	sig, err := layers[len(layers)-1].key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+
+	c.SignatureAlg = x509.ECDSAWithSHA256.String()
+	c.Signature = sig
+
+	return c
+}
+
+func testDICERoots(layers []testDICELayer) []*x509.Certificate {
+	return []*x509.Certificate{layers[0].cert}
+}
+
+func Test_DICEChain_Verify(t *testing.T) {
+	layers := newTestDICEChain(t)
+	c := newTestDICEChainToken(t, layers, []byte("nonce"))
+
+	require.NoError(t, c.Verify(testDICERoots(layers), time.Time{}))
+
+	// the chain does not need to hold the root
+	c.Certificates = c.Certificates[:2]
+	require.NoError(t, c.Verify(testDICERoots(layers), time.Time{}))
+}
+
+func Test_DICEChain_Verify_Fail(t *testing.T) {
+	layers := newTestDICEChain(t)
+	roots := testDICERoots(layers)
+
+	c := newTestDICEChainToken(t, layers, []byte("nonce"))
+	c.Nonce = []byte("other nonce")
+	assert.ErrorContains(t, c.Verify(roots, time.Time{}), "DICE nonce signature verification failed: ")
+
+	c = newTestDICEChainToken(t, layers, []byte("nonce"))
+	c.Signature, c.SignatureAlg = nil, ""
+	assert.ErrorIs(t, c.Verify(roots, time.Time{}), ErrDICEUnsigned)
+
+	c = newTestDICEChainToken(t, layers, []byte("nonce"))
+	assert.ErrorContains(t, c.Verify(testDICERoots(newTestDICEChain(t)), time.Time{}), "DICE chain validation failed: ")
+
+	c.Certificates[1] = []byte("certificate")
+	assert.ErrorContains(t, c.Verify(roots, time.Time{}), "DICE chain decoding failed: certificate 1: ")
+}
+
+func Test_DICETcbInfos(t *testing.T) {
+	layers := newTestDICEChain(t)
+
+	infos, err := DICETcbInfos(layers[0].cert)
+	require.NoError(t, err)
+	assert.Equal(t, []DICETcbInfo{testTcbInfo(0, "rom")}, infos)
+
+	infos, err = DICETcbInfos(layers[1].cert)
+	require.NoError(t, err)
+	assert.Equal(t, []DICETcbInfo{testTcbInfo(1, "bootloader"), testTcbInfo(1, "config")}, infos)
+
+	cert := *layers[2].cert
+	cert.Extensions = []pkix.Extension{{Id: OIDDICETcbInfo, Value: []byte{0x30, 0x01}}}
+	_, err = DICETcbInfos(&cert)
+	assert.ErrorContains(t, err, "TcbInfo: ")
+}
+
+func Test_DICEChain_Valid_Fail(t *testing.T) {
+	layers := newTestDICEChain(t)
+
+	tvs := []struct {
+		update   func(c *DICEChain)
+		expected string
+	}{
+		{func(c *DICEChain) { c.Nonce = nil }, `missing mandatory field "nonce"`},
+		{func(c *DICEChain) { c.Certificates = nil }, `missing mandatory field "certificates"`},
+		{func(c *DICEChain) { c.Signature = nil }, `"signature" and "signature_alg" go together`},
+		{func(c *DICEChain) { c.SignatureAlg = "MD5-RSA" }, `unsupported "signature_alg" "MD5-RSA"`},
+	}
+
+	for _, tv := range tvs {
+		c := newTestDICEChainToken(t, layers, []byte("nonce"))
+		tv.update(c)
+		assert.EqualError(t, c.Valid(), tv.expected)
+	}
+}
+
+func Test_DICEChain_JSON_SerDes_Pass(t *testing.T) {
+	c := newTestDICEChainToken(t, newTestDICEChain(t), []byte("nonce"))
+
+	encoded, err := c.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &DICEChain{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, c, decoded)
+}
+
+func Test_DICEChain_CBOR_SerDes_Pass(t *testing.T) {
+	c := newTestDICEChainToken(t, newTestDICEChain(t), []byte("nonce"))
+	c.Signature, c.SignatureAlg = nil, ""
+
+	encoded, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &DICEChain{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, c, decoded)
+}
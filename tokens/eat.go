@@ -0,0 +1,142 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+)
+
+const (
+	// EATMediaType is the media type of an EAT in CWT form (RFC 9782)
+	EATMediaType = "application/eat+cwt"
+	// MockEATProfile is the EAT profile of the evidence of the mock-eat
+	// attester
+	MockEATProfile = "tag:github.com,2026:veraison/ratsd/mock-eat"
+	// MockEATMediaType is the media type of the evidence of the mock-eat
+	// attester
+	MockEATMediaType = EATMediaType + `; eat_profile="` + MockEATProfile + `"`
+)
+
+// ErrEATUnsigned is returned when verifying an EAT without x5chain
+var ErrEATUnsigned = errors.New("EAT has no x5chain")
+
+// EATClaims are the EAT claims carried by the mock-eat attester
+type EATClaims struct {
+	Profile   string   `cbor:"265,keyasint"`
+	Nonce     []byte   `cbor:"10,keyasint"`
+	UEID      []byte   `cbor:"256,keyasint,omitempty"`
+	OEMID     int64    `cbor:"258,keyasint,omitempty"`
+	SWName    string   `cbor:"270,keyasint,omitempty"`
+	SWVersion []string `cbor:"271,keyasint,omitempty"`
+}
+
+// EAT is an EAT signed as a COSE_Sign1 message. X5Chain holds the signing
+// certificate followed by the intermediate certificates.
+type EAT struct {
+	Claims  EATClaims
+	X5Chain []*x509.Certificate
+
+	message cose.Sign1Message
+}
+
+// ParseEAT decodes an EAT. The signature is not verified.
+func ParseEAT(data []byte) (*EAT, error) {
+	t := &EAT{}
+
+	if err := t.message.UnmarshalCBOR(data); err != nil {
+		return nil, fmt.Errorf("EAT decoding failed: %w", err)
+	}
+
+	if err := cbor.Unmarshal(t.message.Payload, &t.Claims); err != nil {
+		return nil, fmt.Errorf("EAT claims decoding failed: %w", err)
+	}
+
+	chain, err := parseX5Chain(t.message.Headers.Protected[cose.HeaderLabelX5Chain])
+	if err != nil {
+		return nil, fmt.Errorf("EAT x5chain decoding failed: %w", err)
+	}
+	t.X5Chain = chain
+
+	return t, nil
+}
+
+// Verify checks that the x5chain of the EAT chains up to one of roots at
+// currentTime, and that the EAT is signed by the key of the signing
+// certificate. The zero currentTime means the current time.
+func (t *EAT) Verify(roots *x509.CertPool, currentTime time.Time) error {
+	if len(t.X5Chain) == 0 {
+		return ErrEATUnsigned
+	}
+
+	leaf := t.X5Chain[0]
+	intermediates := x509.NewCertPool()
+	for _, c := range t.X5Chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("EAT x5chain validation failed: %w", err)
+	}
+
+	alg, err := t.message.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("EAT verification failed: %w", err)
+	}
+
+	verifier, err := cose.NewVerifier(alg, leaf.PublicKey)
+	if err != nil {
+		return fmt.Errorf("EAT verification failed: %w", err)
+	}
+
+	if err := t.message.Verify(nil, verifier); err != nil {
+		return fmt.Errorf("EAT verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// parseX5Chain decodes an x5chain header, which holds either a single
+// certificate or an array of certificates
+func parseX5Chain(v any) ([]*x509.Certificate, error) {
+	var ders [][]byte
+
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		ders = [][]byte{t}
+	case []any:
+		for _, e := range t {
+			der, ok := e.([]byte)
+			if !ok {
+				return nil, fmt.Errorf("unexpected certificate type %T", e)
+			}
+			ders = append(ders, der)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected x5chain type %T", v)
+	}
+
+	var chain []*x509.Certificate
+	for _, der := range ders {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, c)
+	}
+
+	return chain, nil
+}
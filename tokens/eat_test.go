@@ -0,0 +1,147 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+var testEATClaims = EATClaims{
+	Profile:   MockEATProfile,
+	Nonce:     []byte("abcdefghijklmnopqrstuvwxyz123456"),
+	UEID:      append([]byte{0x01}, make([]byte, 32)...),
+	OEMID:     48482,
+	SWName:    "mock-eat",
+	SWVersion: []string{"1.0.0"},
+}
+
+type testEATSigner struct {
+	root *x509.Certificate
+	leaf *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestEATSigner(t *testing.T) testEATSigner {
+	t.Helper()
+
+	notBefore := time.Now().Add(-time.Hour)
+	issue := func(cn string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             notBefore,
+			NotAfter:              notBefore.Add(24 * time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+		require.NoError(t, err)
+
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		return cert, key
+	}
+
+	root, rootKey := issue("root", true, nil, nil)
+	leaf, key := issue("attester", false, root, rootKey)
+
+	return testEATSigner{root: root, leaf: leaf, key: key}
+}
+
+func (s testEATSigner) sign(t *testing.T, claims EATClaims, x5chain bool) []byte {
+	t.Helper()
+
+	payload, err := cbor.Marshal(claims)
+	require.NoError(t, err)
+
+	signer, err := cose.NewSigner(cose.AlgorithmES256, s.key)
+	require.NoError(t, err)
+
+	msg := cose.NewSign1Message()
+	msg.Headers.Protected.SetAlgorithm(cose.AlgorithmES256)
+	if x5chain {
+		msg.Headers.Protected[cose.HeaderLabelX5Chain] = [][]byte{s.leaf.Raw}
+	}
+	msg.Payload = payload
+	require.NoError(t, msg.Sign(rand.Reader, nil, signer))
+
+	data, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+
+	return data
+}
+
+func (s testEATSigner) roots() *x509.CertPool {
+	roots := x509.NewCertPool()
+	roots.AddCert(s.root)
+	return roots
+}
+
+func Test_ParseEAT_Pass(t *testing.T) {
+	s := newTestEATSigner(t)
+
+	eat, err := ParseEAT(s.sign(t, testEATClaims, true))
+	require.NoError(t, err)
+	assert.Equal(t, testEATClaims, eat.Claims)
+	require.Len(t, eat.X5Chain, 1)
+	assert.Equal(t, "attester", eat.X5Chain[0].Subject.CommonName)
+
+	assert.NoError(t, eat.Verify(s.roots(), time.Time{}))
+}
+
+func Test_ParseEAT_Fail(t *testing.T) {
+	_, err := ParseEAT([]byte{0xa0})
+	assert.ErrorContains(t, err, "EAT decoding failed")
+
+	msg := cose.NewSign1Message()
+	msg.Headers.Protected.SetAlgorithm(cose.AlgorithmES256)
+	msg.Payload = []byte{0x01}
+	msg.Signature = []byte{0x00}
+	data, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+
+	_, err = ParseEAT(data)
+	assert.ErrorContains(t, err, "EAT claims decoding failed")
+}
+
+func Test_EAT_Verify_Fail(t *testing.T) {
+	s := newTestEATSigner(t)
+
+	unsigned, err := ParseEAT(s.sign(t, testEATClaims, false))
+	require.NoError(t, err)
+	assert.ErrorIs(t, unsigned.Verify(s.roots(), time.Time{}), ErrEATUnsigned)
+
+	eat, err := ParseEAT(s.sign(t, testEATClaims, true))
+	require.NoError(t, err)
+
+	other := newTestEATSigner(t)
+	assert.ErrorContains(t, eat.Verify(other.roots(), time.Time{}),
+		"EAT x5chain validation failed")
+
+	assert.ErrorContains(t, eat.Verify(s.roots(), time.Now().Add(48*time.Hour)),
+		"EAT x5chain validation failed")
+
+	eat.message.Payload = append(eat.message.Payload, 0x00)
+	assert.ErrorContains(t, eat.Verify(s.roots(), time.Time{}), "EAT verification failed")
+}
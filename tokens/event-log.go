@@ -0,0 +1,358 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-tpm/tpm2"
+)
+
+const (
+	EventLogMediaTypeCBOR = "application/vnd.veraison.event-log+cbor"
+	EventLogMediaTypeJSON = "application/vnd.veraison.event-log+json"
+)
+
+// Sources of the measured-boot event log
+const (
+	// EventLogSourceTCG2 is the TCG2 event log of the TPM, whose events
+	// extend PCRs
+	EventLogSourceTCG2 = "tcg2"
+	// EventLogSourceCCEL is the event log of the CC Event Log ACPI table of
+	// a confidential VM, whose events extend the measurement registers of
+	// the platform, e.g., the RTMRs of TDX
+	EventLogSourceCCEL = "ccel"
+)
+
+const (
+	// EventTypeNoAction is the type of the events that are not extended
+	EventTypeNoAction = 0x3
+
+	// specIDSignature identifies the first event of crypto-agile logs
+	specIDSignature = "Spec ID Event03\x00"
+	sha1DigestSize  = 20
+	// maxEventSize bounds the size of an event, so that a corrupted log
+	// does not exhaust memory
+	maxEventSize = 1 << 20
+)
+
+var errEventLogTruncated = errors.New("event log truncated")
+
+// EventLog represents a measured-boot event log
+// see docs/event-log.cddl for definition
+type EventLog struct {
+	// Nonce is the nonce of the request. The boot events were measured before
+	// any request, so that the nonce only ties the log to the quote or report
+	// of the same request, whose PCRs or RTMRs the log is replayed against.
+	Nonce BinaryString `json:"nonce"`
+	// Source is the source of the event log, EventLogSourceTCG2 or
+	// EventLogSourceCCEL
+	Source string `json:"source"`
+	// Raw is the binary event log, up to its last event
+	Raw BinaryString `json:"raw"`
+	// Events are the events parsed from Raw
+	Events []Event `json:"events"`
+}
+
+// Event is an event of a measured-boot event log. The first event of a
+// crypto-agile log is returned as is, with a single SHA-1 digest.
+type Event struct {
+	// Index is the PCR, or the measurement register, extended by the event
+	Index     uint32        `json:"index"`
+	EventType uint32        `json:"event_type"`
+	Digests   []EventDigest `json:"digests"`
+	Data      BinaryString  `json:"data"`
+}
+
+// EventDigest is the digest of an event in one of the banks of the log
+type EventDigest struct {
+	// AlgID is the TPM algorithm ID of the bank
+	AlgID  uint16       `json:"alg_id"`
+	Digest BinaryString `json:"digest"`
+}
+
+// Valid checks if the EventLog is populated correctly
+func (t *EventLog) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	if t.Source != EventLogSourceTCG2 && t.Source != EventLogSourceCCEL {
+		return fmt.Errorf(`unsupported "source" %q`, t.Source)
+	}
+
+	if len(t.Raw) == 0 {
+		return errors.New(`missing mandatory field "raw"`)
+	}
+
+	return nil
+}
+
+// ToJSON encodes EventLog as JSON
+func (t *EventLog) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes EventLog from JSON
+func (t *EventLog) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes EventLog as CBOR
+func (t *EventLog) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes EventLog from CBOR
+func (t *EventLog) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// Replay extends the digests of the given bank into registers that start
+// zeroed, and returns the resulting values. Events of type EV_NO_ACTION are
+// skipped. The values of the registers whose initial value is not zero, such
+// as PCR 0 after a StartupLocality event, or MRTD, do not match.
+func (t *EventLog) Replay(alg tpm2.TPMAlgID) (map[uint32][]byte, error) {
+	h, err := alg.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	registers := make(map[uint32][]byte)
+	for i, e := range t.Events {
+		if e.EventType == EventTypeNoAction {
+			continue
+		}
+
+		var digest []byte
+		for _, d := range e.Digests {
+			if d.AlgID == uint16(alg) {
+				digest = d.Digest
+			}
+		}
+		if digest == nil {
+			return nil, fmt.Errorf("event %d: no digest for algorithm %#x", i, uint16(alg))
+		}
+
+		v, ok := registers[e.Index]
+		if !ok {
+			v = make([]byte, h.Size())
+		}
+
+		d := h.New()
+		d.Write(v)
+		d.Write(digest)
+		registers[e.Index] = d.Sum(nil)
+	}
+
+	return registers, nil
+}
+
+// ParseEventLog parses a TCG PC Client binary event log, either in the
+// crypto-agile format or in the legacy SHA-1 format. Parsing stops at the
+// end of data, or at the padding of the log area, made of 0x00 or 0xff bytes.
+// It returns the events and the length of the log.
+func ParseEventLog(data []byte) ([]Event, int, error) {
+	r := bytes.NewReader(data)
+
+	first, err := readSHA1Event(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("event 0: %w", err)
+	}
+	events := []Event{*first}
+
+	// digest sizes of the banks of a crypto-agile log, nil for a legacy log
+	var digestSizes map[uint16]uint16
+	if first.EventType == EventTypeNoAction && bytes.HasPrefix(first.Data, []byte(specIDSignature)) {
+		if digestSizes, err = parseSpecIDEvent(first.Data); err != nil {
+			return nil, 0, fmt.Errorf("event 0: %w", err)
+		}
+	}
+
+	for !isPadding(data[len(data)-r.Len():]) {
+		var e *Event
+		if digestSizes != nil {
+			e, err = readEvent2(r, digestSizes)
+		} else {
+			e, err = readSHA1Event(r)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("event %d: %w", len(events), err)
+		}
+		events = append(events, *e)
+	}
+
+	return events, len(data) - r.Len(), nil
+}
+
+// isPadding tells whether the rest of the log holds no further event
+func isPadding(rest []byte) bool {
+	if len(rest) < 8 {
+		return true
+	}
+
+	header := rest[:8]
+	return bytes.Equal(header, make([]byte, 8)) || bytes.Equal(header, bytes.Repeat([]byte{0xff}, 8))
+}
+
+// parseSpecIDEvent returns the digest sizes of the banks listed in the
+// TCG_EfiSpecIDEvent structure
+func parseSpecIDEvent(data []byte) (map[uint16]uint16, error) {
+	r := bytes.NewReader(data[len(specIDSignature):])
+
+	var header struct {
+		PlatformClass    uint32
+		SpecVersionMinor uint8
+		SpecVersionMajor uint8
+		SpecErrata       uint8
+		UintnSize        uint8
+		NumAlgorithms    uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("Spec ID event: %w", errEventLogTruncated)
+	}
+
+	if header.NumAlgorithms == 0 || uint64(header.NumAlgorithms)*4 > uint64(r.Len()) {
+		return nil, fmt.Errorf("Spec ID event: invalid number of algorithms %d", header.NumAlgorithms)
+	}
+
+	sizes := make(map[uint16]uint16, header.NumAlgorithms)
+	for i := uint32(0); i < header.NumAlgorithms; i++ {
+		var alg struct {
+			AlgID      uint16
+			DigestSize uint16
+		}
+		if err := binary.Read(r, binary.LittleEndian, &alg); err != nil {
+			return nil, fmt.Errorf("Spec ID event: %w", errEventLogTruncated)
+		}
+		sizes[alg.AlgID] = alg.DigestSize
+	}
+
+	return sizes, nil
+}
+
+// readSHA1Event reads a TCG_PCClientPCREvent structure
+func readSHA1Event(r *bytes.Reader) (*Event, error) {
+	e := &Event{}
+
+	if err := binary.Read(r, binary.LittleEndian, &e.Index); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &e.EventType); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	digest := make([]byte, sha1DigestSize)
+	if _, err := io.ReadFull(r, digest); err != nil {
+		return nil, errEventLogTruncated
+	}
+	e.Digests = []EventDigest{{AlgID: uint16(tpm2.TPMAlgSHA1), Digest: digest}}
+
+	var err error
+	if e.Data, err = readEventData(r); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// readEvent2 reads a TCG_PCR_EVENT2 structure
+func readEvent2(r *bytes.Reader, digestSizes map[uint16]uint16) (*Event, error) {
+	e := &Event{}
+
+	if err := binary.Read(r, binary.LittleEndian, &e.Index); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &e.EventType); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	if count > uint32(len(digestSizes)) {
+		return nil, fmt.Errorf("%d digests, the log has %d banks", count, len(digestSizes))
+	}
+
+	for i := uint32(0); i < count; i++ {
+		var algID uint16
+		if err := binary.Read(r, binary.LittleEndian, &algID); err != nil {
+			return nil, errEventLogTruncated
+		}
+
+		size, ok := digestSizes[algID]
+		if !ok {
+			return nil, fmt.Errorf("digest of unknown algorithm %#x", algID)
+		}
+
+		digest := make([]byte, size)
+		if _, err := io.ReadFull(r, digest); err != nil {
+			return nil, errEventLogTruncated
+		}
+		e.Digests = append(e.Digests, EventDigest{AlgID: algID, Digest: digest})
+	}
+
+	var err error
+	if e.Data, err = readEventData(r); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// readEventData reads the event data prefixed with its 32-bit size
+func readEventData(r *bytes.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	if size > maxEventSize {
+		return nil, fmt.Errorf("event size %d exceeds %d", size, maxEventSize)
+	}
+
+	if int64(size) > int64(r.Len()) {
+		return nil, errEventLogTruncated
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errEventLogTruncated
+	}
+
+	return data, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func le(v any) []byte {
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.LittleEndian, v)
+	return b.Bytes()
+}
+
+// testSpecIDEvent returns the first event of a crypto-agile log with SHA-1
+// and SHA-256 banks
+func testSpecIDEvent() []byte {
+	var spec bytes.Buffer
+	spec.WriteString(specIDSignature)
+	spec.Write(le(uint32(0)))
+	spec.Write([]byte{0, 2, 0, 2})
+	spec.Write(le(uint32(2)))
+	spec.Write(le([]uint16{uint16(tpm2.TPMAlgSHA1), sha1.Size, uint16(tpm2.TPMAlgSHA256), sha256.Size}))
+	spec.WriteByte(0)
+
+	var e bytes.Buffer
+	e.Write(le([]uint32{0, EventTypeNoAction}))
+	e.Write(make([]byte, sha1.Size))
+	e.Write(le(uint32(spec.Len())))
+	e.Write(spec.Bytes())
+	return e.Bytes()
+}
+
+// testEvent2 returns a TCG_PCR_EVENT2 with the SHA-1 and SHA-256 digests of
+// data
+func testEvent2(index, eventType uint32, data string) []byte {
+	d1 := sha1.Sum([]byte(data))
+	d256 := sha256.Sum256([]byte(data))
+
+	var e bytes.Buffer
+	e.Write(le([]uint32{index, eventType, 2}))
+	e.Write(le(uint16(tpm2.TPMAlgSHA1)))
+	e.Write(d1[:])
+	e.Write(le(uint16(tpm2.TPMAlgSHA256)))
+	e.Write(d256[:])
+	e.Write(le(uint32(len(data))))
+	e.WriteString(data)
+	return e.Bytes()
+}
+
+// testSHA1Event returns a TCG_PCClientPCREvent with the SHA-1 digest of data
+func testSHA1Event(index, eventType uint32, data string) []byte {
+	d := sha1.Sum([]byte(data))
+
+	var e bytes.Buffer
+	e.Write(le([]uint32{index, eventType}))
+	e.Write(d[:])
+	e.Write(le(uint32(len(data))))
+	e.WriteString(data)
+	return e.Bytes()
+}
+
+func testCryptoAgileLog() []byte {
+	return bytes.Join([][]byte{
+		testSpecIDEvent(),
+		testEvent2(0, 0x8, "firmware"),
+		testEvent2(0, 0x4, "\x00\x00\x00\x00"),
+		testEvent2(7, 0x80000001, "SecureBoot"),
+		testEvent2(7, EventTypeNoAction, "not extended"),
+	}, nil)
+}
+
+func Test_ParseEventLog_CryptoAgile(t *testing.T) {
+	raw := testCryptoAgileLog()
+
+	events, length, err := ParseEventLog(raw)
+	require.NoError(t, err)
+	assert.Equal(t, len(raw), length)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, uint32(7), events[3].Index)
+	assert.Equal(t, uint32(0x80000001), events[3].EventType)
+	assert.Equal(t, BinaryString("SecureBoot"), events[3].Data)
+	d := sha256.Sum256([]byte("SecureBoot"))
+	assert.Equal(t, EventDigest{AlgID: uint16(tpm2.TPMAlgSHA256), Digest: d[:]}, events[3].Digests[1])
+}
+
+func Test_ParseEventLog_Padding(t *testing.T) {
+	raw := testCryptoAgileLog()
+
+	for _, pad := range []byte{0x00, 0xff} {
+		events, length, err := ParseEventLog(append(bytes.Clone(raw), bytes.Repeat([]byte{pad}, 64)...))
+		require.NoError(t, err)
+		assert.Equal(t, len(raw), length)
+		assert.Len(t, events, 5)
+	}
+}
+
+func Test_ParseEventLog_SHA1(t *testing.T) {
+	raw := bytes.Join([][]byte{
+		testSHA1Event(0, 0x8, "firmware"),
+		testSHA1Event(4, 0x80000003, "bootloader"),
+	}, nil)
+
+	events, length, err := ParseEventLog(raw)
+	require.NoError(t, err)
+	assert.Equal(t, len(raw), length)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint32(4), events[1].Index)
+	assert.Equal(t, uint16(tpm2.TPMAlgSHA1), events[1].Digests[0].AlgID)
+}
+
+func Test_ParseEventLog_Fail(t *testing.T) {
+	raw := testCryptoAgileLog()
+
+	unknownAlg := testEvent2(1, 0x1, "data")
+	binary.LittleEndian.PutUint16(unknownAlg[12:], uint16(tpm2.TPMAlgSHA384))
+
+	tooManyDigests := testEvent2(1, 0x1, "data")
+	binary.LittleEndian.PutUint32(tooManyDigests[8:], 3)
+
+	tooLarge := testEvent2(1, 0x1, "data")
+	binary.LittleEndian.PutUint32(tooLarge[len(tooLarge)-8:], maxEventSize+1)
+
+	tvs := []struct {
+		raw      []byte
+		expected string
+	}{
+		{raw[:10], "event 0: event log truncated"},
+		{raw[:len(raw)-1], "event 4: event log truncated"},
+		{append(testSpecIDEvent(), unknownAlg...), "event 1: digest of unknown algorithm 0xc"},
+		{append(testSpecIDEvent(), tooManyDigests...), "event 1: 3 digests, the log has 2 banks"},
+		{append(testSpecIDEvent(), tooLarge...), "event 1: event size 1048577 exceeds 1048576"},
+	}
+
+	for _, tv := range tvs {
+		_, _, err := ParseEventLog(tv.raw)
+		assert.EqualError(t, err, tv.expected)
+	}
+}
+
+func newTestEventLog(t *testing.T) *EventLog {
+	raw := testCryptoAgileLog()
+
+	events, _, err := ParseEventLog(raw)
+	require.NoError(t, err)
+
+	return &EventLog{
+		Nonce:  []byte("nonce"),
+		Source: EventLogSourceTCG2,
+		Raw:    raw,
+		Events: events,
+	}
+}
+
+func Test_EventLog_Valid_Fail(t *testing.T) {
+	l := newTestEventLog(t)
+	l.Source = "uefi"
+	assert.EqualError(t, l.Valid(), `unsupported "source" "uefi"`)
+
+	l = newTestEventLog(t)
+	l.Raw = nil
+	assert.EqualError(t, l.Valid(), `missing mandatory field "raw"`)
+
+	l = newTestEventLog(t)
+	l.Nonce = nil
+	assert.EqualError(t, l.Valid(), `missing mandatory field "nonce"`)
+}
+
+func Test_EventLog_JSON_SerDes_Pass(t *testing.T) {
+	l := newTestEventLog(t)
+
+	encoded, err := l.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &EventLog{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, l, decoded)
+}
+
+func Test_EventLog_CBOR_SerDes_Pass(t *testing.T) {
+	l := newTestEventLog(t)
+
+	encoded, err := l.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &EventLog{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, l, decoded)
+}
+
+func Test_EventLog_Replay(t *testing.T) {
+	l := newTestEventLog(t)
+
+	extend := func(pcr []byte, data string) []byte {
+		d := sha256.Sum256([]byte(data))
+		v := sha256.Sum256(append(bytes.Clone(pcr), d[:]...))
+		return v[:]
+	}
+	zero := make([]byte, sha256.Size)
+
+	pcrs, err := l.Replay(tpm2.TPMAlgSHA256)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32][]byte{
+		0: extend(extend(zero, "firmware"), "\x00\x00\x00\x00"),
+		7: extend(zero, "SecureBoot"),
+	}, pcrs)
+
+	_, err = l.Replay(tpm2.TPMAlgSHA384)
+	assert.EqualError(t, err, "event 1: no digest for algorithm 0xc")
+}
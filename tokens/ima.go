@@ -0,0 +1,177 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	IMALogMediaTypeCBOR = "application/vnd.veraison.ima-log+cbor"
+	IMALogMediaTypeJSON = "application/vnd.veraison.ima-log+json"
+)
+
+// IMALog represents a slice of the IMA runtime measurement list
+// see docs/ima-log.cddl for definition
+type IMALog struct {
+	// Nonce is the nonce of the request. It is not measured into any entry:
+	// the log is vouched for only up to the PCR 10 value, or RTMR, quoted
+	// with the same nonce.
+	Nonce BinaryString `json:"nonce"`
+	// HashAlg is the name of the PCR bank of the template hashes
+	HashAlg string `json:"hash_alg"`
+	// Offset is the index of the first entry read from the list
+	Offset uint64 `json:"offset"`
+	// NextOffset is the number of entries in the list when it was read,
+	// i.e., the offset of the next incremental retrieval
+	NextOffset uint64 `json:"next_offset"`
+	// Entries are the entries read from the list that extend the selected
+	// PCRs
+	Entries []IMAEntry `json:"entries"`
+}
+
+// IMAEntry is an entry of the IMA runtime measurement list
+type IMAEntry struct {
+	// Index is the position of the entry in the list
+	Index uint64 `json:"index"`
+	// PCR is the PCR extended with the template hash
+	PCR uint32 `json:"pcr"`
+	// TemplateHash is the digest of TemplateData. It is zero for entries
+	// recording a measurement violation.
+	TemplateHash BinaryString `json:"template_hash"`
+	// TemplateName is the name of the IMA template, e.g., "ima-ng"
+	TemplateName string `json:"template_name"`
+	// TemplateData holds the fields of the template
+	TemplateData BinaryString `json:"template_data"`
+}
+
+// Valid checks if the IMALog is populated correctly
+func (t *IMALog) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	alg, ok := TPMHashAlgs[t.HashAlg]
+	if !ok {
+		return fmt.Errorf(`unsupported "hash_alg" %q`, t.HashAlg)
+	}
+
+	h, err := alg.Hash()
+	if err != nil {
+		return err
+	}
+
+	if t.NextOffset < t.Offset {
+		return errors.New(`"next_offset" is lower than "offset"`)
+	}
+
+	for i, e := range t.Entries {
+		if e.Index < t.Offset || e.Index >= t.NextOffset {
+			return fmt.Errorf("entry %d: index %d out of range", i, e.Index)
+		}
+
+		if i > 0 && e.Index <= t.Entries[i-1].Index {
+			return fmt.Errorf("entry %d: index %d out of order", i, e.Index)
+		}
+
+		if len(e.TemplateHash) != h.Size() {
+			return fmt.Errorf("entry %d: template hash size %d, want %d",
+				i, len(e.TemplateHash), h.Size())
+		}
+
+		if e.TemplateName == "" {
+			return fmt.Errorf(`entry %d: missing mandatory field "template_name"`, i)
+		}
+	}
+
+	return nil
+}
+
+// ToJSON encodes IMALog as JSON
+func (t *IMALog) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes IMALog from JSON
+func (t *IMALog) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes IMALog as CBOR
+func (t *IMALog) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes IMALog from CBOR
+func (t *IMALog) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ReplayPCRs extends the template hashes of the entries into PCRs that start
+// zeroed, and returns the resulting values. They match the values of a TPM
+// quote of the same bank, or the RTMRs extended by IMA in a confidential VM,
+// only if the log holds all the entries of those PCRs, i.e., if it starts at
+// offset 0 and is not filtered.
+func (t *IMALog) ReplayPCRs() (map[uint32][]byte, error) {
+	h, err := TPMHashAlgs[t.HashAlg].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("unsupported hash_alg %q", t.HashAlg)
+	}
+
+	if t.Offset != 0 {
+		return nil, fmt.Errorf("cannot replay a log starting at offset %d", t.Offset)
+	}
+
+	zero := make([]byte, h.Size())
+	// IMA extends violations with all-ones instead of their zero hash
+	violation := bytes.Repeat([]byte{0xff}, h.Size())
+
+	pcrs := make(map[uint32][]byte)
+	for _, e := range t.Entries {
+		v, ok := pcrs[e.PCR]
+		if !ok {
+			v = zero
+		}
+
+		digest := []byte(e.TemplateHash)
+		if bytes.Equal(digest, zero) {
+			digest = violation
+		}
+
+		d := h.New()
+		d.Write(v)
+		d.Write(digest)
+		pcrs[e.PCR] = d.Sum(nil)
+	}
+
+	return pcrs, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIMALog() *IMALog {
+	bash := sha256.Sum256([]byte("/usr/bin/bash"))
+	config := sha256.Sum256([]byte("/etc/ratsd/config.yaml"))
+
+	return &IMALog{
+		Nonce:      []byte("nonce"),
+		HashAlg:    "sha256",
+		NextOffset: 3,
+		Entries: []IMAEntry{
+			{Index: 0, PCR: 10, TemplateHash: bash[:], TemplateName: "ima-ng", TemplateData: []byte("bash")},
+			{Index: 1, PCR: 11, TemplateHash: config[:], TemplateName: "ima-ng", TemplateData: []byte("config")},
+			{Index: 2, PCR: 10, TemplateHash: make([]byte, sha256.Size), TemplateName: "ima-ng", TemplateData: []byte("violation")},
+		},
+	}
+}
+
+func Test_IMALog_Valid_Pass(t *testing.T) {
+	assert.NoError(t, newTestIMALog().Valid())
+
+	empty := &IMALog{Nonce: []byte("nonce"), HashAlg: "sha1", Offset: 5, NextOffset: 5}
+	assert.NoError(t, empty.Valid())
+}
+
+func Test_IMALog_Valid_Fail(t *testing.T) {
+	tvs := []struct {
+		update   func(l *IMALog)
+		expected string
+	}{
+		{func(l *IMALog) { l.Nonce = nil }, `missing mandatory field "nonce"`},
+		{func(l *IMALog) { l.HashAlg = "md5" }, `unsupported "hash_alg" "md5"`},
+		{func(l *IMALog) { l.Offset = 4 }, `"next_offset" is lower than "offset"`},
+		{func(l *IMALog) { l.Offset = 1 }, "entry 0: index 0 out of range"},
+		{func(l *IMALog) { l.NextOffset = 2 }, "entry 2: index 2 out of range"},
+		{func(l *IMALog) { l.Entries[1].Index = 0 }, "entry 1: index 0 out of order"},
+		{func(l *IMALog) { l.HashAlg = "sha1" }, "entry 0: template hash size 32, want 20"},
+		{func(l *IMALog) { l.Entries[2].TemplateName = "" }, `entry 2: missing mandatory field "template_name"`},
+	}
+
+	for _, tv := range tvs {
+		l := newTestIMALog()
+		tv.update(l)
+		assert.EqualError(t, l.Valid(), tv.expected)
+	}
+}
+
+func Test_IMALog_JSON_SerDes_Pass(t *testing.T) {
+	l := newTestIMALog()
+
+	encoded, err := l.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &IMALog{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, l, decoded)
+}
+
+func Test_IMALog_CBOR_SerDes_Pass(t *testing.T) {
+	l := newTestIMALog()
+
+	encoded, err := l.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &IMALog{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, l, decoded)
+}
+
+func Test_IMALog_ReplayPCRs(t *testing.T) {
+	l := newTestIMALog()
+
+	extend := func(pcr, digest []byte) []byte {
+		d := sha256.Sum256(append(append([]byte{}, pcr...), digest...))
+		return d[:]
+	}
+	zero := make([]byte, sha256.Size)
+
+	pcr10 := extend(zero, l.Entries[0].TemplateHash)
+	pcr10 = extend(pcr10, bytes.Repeat([]byte{0xff}, sha256.Size))
+	pcr11 := extend(zero, l.Entries[1].TemplateHash)
+
+	pcrs, err := l.ReplayPCRs()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32][]byte{10: pcr10, 11: pcr11}, pcrs)
+}
+
+func Test_IMALog_ReplayPCRs_Fail_Offset(t *testing.T) {
+	l := newTestIMALog()
+	l.Entries = l.Entries[1:]
+	l.Offset = 1
+
+	_, err := l.ReplayPCRs()
+	assert.EqualError(t, err, "cannot replay a log starting at offset 1")
+}
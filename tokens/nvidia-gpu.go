@@ -0,0 +1,215 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	NvidiaGPUEvidenceMediaTypeCBOR = "application/vnd.veraison.nvidia-gpu-evidence+cbor"
+	NvidiaGPUEvidenceMediaTypeJSON = "application/vnd.veraison.nvidia-gpu-evidence+json"
+)
+
+// Architectures of the NVIDIA GPUs supporting confidential computing
+const (
+	NvidiaGPUArchHopper    = "HOPPER"
+	NvidiaGPUArchBlackwell = "BLACKWELL"
+)
+
+// NvidiaGPUNonceSize is the size of the nonce of NVIDIA GPU evidence
+const NvidiaGPUNonceSize = 32
+
+// Base64String is standard base64 (§4 of RFC4648) with padding. Unlike
+// BinaryString, it is a text string in CBOR too.
+type Base64String []byte
+
+// MarshalJSON encodes Base64String as JSON
+func (o Base64String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(o))
+}
+
+// UnmarshalJSON decodes Base64String from JSON
+func (o *Base64String) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return o.decode(s)
+}
+
+// MarshalCBOR encodes Base64String as CBOR
+func (o Base64String) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(base64.StdEncoding.EncodeToString(o))
+}
+
+// UnmarshalCBOR decodes Base64String from CBOR
+func (o *Base64String) UnmarshalCBOR(b []byte) error {
+	var s string
+
+	if err := cbor.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return o.decode(s)
+}
+
+func (o *Base64String) decode(s string) error {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	*o = decoded
+	return nil
+}
+
+// HexString is base16 (§8 of RFC4648). It is a text string in CBOR too.
+type HexString []byte
+
+// MarshalJSON encodes HexString as JSON
+func (o HexString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(o))
+}
+
+// UnmarshalJSON decodes HexString from JSON
+func (o *HexString) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return o.decode(s)
+}
+
+// MarshalCBOR encodes HexString as CBOR
+func (o HexString) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(hex.EncodeToString(o))
+}
+
+// UnmarshalCBOR decodes HexString from CBOR
+func (o *HexString) UnmarshalCBOR(b []byte) error {
+	var s string
+
+	if err := cbor.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return o.decode(s)
+}
+
+func (o *HexString) decode(s string) error {
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	*o = decoded
+	return nil
+}
+
+// NvidiaGPUEvidence represents the evidence of the NVIDIA GPUs of a machine,
+// one entry per GPU
+// see docs/nvidia-gpu-evidence.cddl for definition
+type NvidiaGPUEvidence []NvidiaGPU
+
+// NvidiaGPU is the evidence of a single NVIDIA GPU
+type NvidiaGPU struct {
+	// Arch is the architecture of the GPU, NvidiaGPUArchHopper or
+	// NvidiaGPUArchBlackwell
+	Arch string `json:"arch"`
+	// Certificate is the certificate chain of the GPU
+	Certificate Base64String `json:"certificate"`
+	// Evidence is the attestation report of the GPU, bound to Nonce
+	Evidence Base64String `json:"evidence"`
+	Nonce    HexString    `json:"nonce"`
+}
+
+// Valid checks if the NvidiaGPUEvidence is populated correctly
+func (t *NvidiaGPUEvidence) Valid() error {
+	if len(*t) == 0 {
+		return errors.New("no GPU evidence")
+	}
+
+	for i, g := range *t {
+		if err := g.Valid(); err != nil {
+			return fmt.Errorf("GPU %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Valid checks if the NvidiaGPU is populated correctly
+func (t *NvidiaGPU) Valid() error {
+	if t.Arch != NvidiaGPUArchHopper && t.Arch != NvidiaGPUArchBlackwell {
+		return fmt.Errorf(`unsupported "arch" %q`, t.Arch)
+	}
+
+	if len(t.Certificate) == 0 {
+		return errors.New(`missing mandatory field "certificate"`)
+	}
+
+	if len(t.Evidence) == 0 {
+		return errors.New(`missing mandatory field "evidence"`)
+	}
+
+	if len(t.Nonce) != NvidiaGPUNonceSize {
+		return fmt.Errorf(`"nonce" should be %d bytes, got %d`, NvidiaGPUNonceSize, len(t.Nonce))
+	}
+
+	return nil
+}
+
+// ToJSON encodes NvidiaGPUEvidence as JSON
+func (t *NvidiaGPUEvidence) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes NvidiaGPUEvidence from JSON
+func (t *NvidiaGPUEvidence) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes NvidiaGPUEvidence as CBOR
+func (t *NvidiaGPUEvidence) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes NvidiaGPUEvidence from CBOR
+func (t *NvidiaGPUEvidence) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNvidiaGPUEvidence() *NvidiaGPUEvidence {
+	nonce := bytes.Repeat([]byte{0xab}, NvidiaGPUNonceSize)
+
+	return &NvidiaGPUEvidence{
+		{Arch: NvidiaGPUArchHopper, Certificate: []byte("cert 0"), Evidence: []byte("evidence 0"), Nonce: nonce},
+		{Arch: NvidiaGPUArchBlackwell, Certificate: []byte("cert 1"), Evidence: []byte("evidence 1"), Nonce: nonce},
+	}
+}
+
+func Test_NvidiaGPUEvidence_Valid_Fail(t *testing.T) {
+	tvs := []struct {
+		update   func(e NvidiaGPUEvidence)
+		expected string
+	}{
+		{func(e NvidiaGPUEvidence) { e[0].Arch = "AMPERE" }, `GPU 0: unsupported "arch" "AMPERE"`},
+		{func(e NvidiaGPUEvidence) { e[1].Certificate = nil }, `GPU 1: missing mandatory field "certificate"`},
+		{func(e NvidiaGPUEvidence) { e[1].Evidence = nil }, `GPU 1: missing mandatory field "evidence"`},
+		{func(e NvidiaGPUEvidence) { e[0].Nonce = e[0].Nonce[:16] }, `GPU 0: "nonce" should be 32 bytes, got 16`},
+	}
+
+	for _, tv := range tvs {
+		e := newTestNvidiaGPUEvidence()
+		tv.update(*e)
+		assert.EqualError(t, e.Valid(), tv.expected)
+	}
+
+	assert.EqualError(t, (&NvidiaGPUEvidence{}).Valid(), "no GPU evidence")
+}
+
+func Test_NvidiaGPUEvidence_JSON_SerDes_Pass(t *testing.T) {
+	e := newTestNvidiaGPUEvidence()
+
+	encoded, err := e.ToJSON()
+	require.NoError(t, err)
+
+	// the CDDL uses classic base64 and hex text strings
+	var raw []map[string]string
+	require.NoError(t, json.Unmarshal(encoded, &raw))
+	assert.Equal(t, "Y2VydCAw", raw[0]["certificate"])
+	assert.Equal(t, "ZXZpZGVuY2UgMQ==", raw[1]["evidence"])
+	assert.Equal(t, "abababababababababababababababababababababababababababababababab", raw[0]["nonce"])
+
+	decoded := &NvidiaGPUEvidence{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, e, decoded)
+}
+
+func Test_NvidiaGPUEvidence_CBOR_SerDes_Pass(t *testing.T) {
+	e := newTestNvidiaGPUEvidence()
+
+	encoded, err := e.ToCBOR()
+	require.NoError(t, err)
+
+	// text strings in CBOR too
+	var raw []map[string]string
+	require.NoError(t, cbor.Unmarshal(encoded, &raw))
+	assert.Equal(t, "Y2VydCAw", raw[0]["certificate"])
+
+	decoded := &NvidiaGPUEvidence{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, e, decoded)
+}
+
+func Test_NvidiaGPUEvidence_Decoding_Fail(t *testing.T) {
+	e := &NvidiaGPUEvidence{}
+
+	err := e.FromJSON([]byte(`[{"arch": "HOPPER", "certificate": "Y2VydCAw", "evidence": "ZXZp", "nonce": "xyz"}]`))
+	assert.ErrorContains(t, err, "JSON decoding failed: encoding/hex: invalid byte")
+
+	err = e.FromJSON([]byte(`[{"arch": "HOPPER", "certificate": "Y2VydCAw", "evidence": "ZXZ", "nonce": "ab"}]`))
+	assert.ErrorContains(t, err, "JSON decoding failed: illegal base64 data")
+
+	err = e.FromJSON([]byte(`[]`))
+	assert.EqualError(t, err, "JSON decoding failed: no GPU evidence")
+
+	data, err := cbor.Marshal([]map[string][]byte{{"nonce": []byte("nonce")}})
+	require.NoError(t, err)
+	assert.ErrorContains(t, e.FromCBOR(data), "CBOR decoding failed: ")
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+)
+
+const (
+	// PSAProfile is the EAT profile of PSA attestation tokens (RFC 9783)
+	PSAProfile = "tag:psacertified.org,2023:psa#tfm"
+	// PSATokenMediaType is the media type of a PSA attestation token
+	PSATokenMediaType = EATMediaType + `; eat_profile="` + PSAProfile + `"`
+)
+
+// PSANonceSizes are the sizes of the nonce allowed in a PSA token
+var PSANonceSizes = []int{32, 48, 64}
+
+const (
+	psaImplementationIDSize = 32
+	// psaInstanceIDType is the UEID type byte of the PSA instance ID
+	psaInstanceIDType = 0x01
+)
+
+// PSASoftwareComponent is a measured software component of the PSA platform
+type PSASoftwareComponent struct {
+	MeasurementType  string `cbor:"1,keyasint,omitempty"`
+	MeasurementValue []byte `cbor:"2,keyasint"`
+	Version          string `cbor:"4,keyasint,omitempty"`
+	SignerID         []byte `cbor:"5,keyasint"`
+	MeasurementDesc  string `cbor:"6,keyasint,omitempty"`
+}
+
+// PSAClaims are the claims of a PSA attestation token
+type PSAClaims struct {
+	Profile             string                 `cbor:"265,keyasint"`
+	ClientID            int32                  `cbor:"2394,keyasint"`
+	SecurityLifeCycle   uint16                 `cbor:"2395,keyasint"`
+	ImplementationID    []byte                 `cbor:"2396,keyasint"`
+	BootSeed            []byte                 `cbor:"2397,keyasint,omitempty"`
+	CertificationRef    string                 `cbor:"2398,keyasint,omitempty"`
+	SoftwareComponents  []PSASoftwareComponent `cbor:"2399,keyasint"`
+	VerificationService string                 `cbor:"2400,keyasint,omitempty"`
+	Nonce               []byte                 `cbor:"10,keyasint"`
+	InstanceID          []byte                 `cbor:"256,keyasint"`
+}
+
+// Valid checks if the PSAClaims are populated correctly
+func (c *PSAClaims) Valid() error {
+	if c.Profile != PSAProfile {
+		return fmt.Errorf("unsupported profile %q", c.Profile)
+	}
+
+	if !slices.Contains(PSANonceSizes, len(c.Nonce)) {
+		return fmt.Errorf("nonce size %d is not one of %v", len(c.Nonce), PSANonceSizes)
+	}
+
+	if len(c.ImplementationID) != psaImplementationIDSize {
+		return fmt.Errorf("implementation ID size %d, want %d", len(c.ImplementationID), psaImplementationIDSize)
+	}
+
+	if len(c.InstanceID) != 1+sha256.Size || c.InstanceID[0] != psaInstanceIDType {
+		return errors.New("instance ID is not a random UEID of 33 bytes")
+	}
+
+	if len(c.SoftwareComponents) == 0 {
+		return errors.New("no software component")
+	}
+
+	return nil
+}
+
+// PSAToken is a PSA attestation token, signed as a COSE_Sign1 message with
+// the initial attestation key (IAK) of the platform
+type PSAToken struct {
+	Claims PSAClaims
+
+	message cose.Sign1Message
+}
+
+// ParsePSAToken decodes a PSA attestation token and checks its claims. The
+// signature is not verified.
+func ParsePSAToken(data []byte) (*PSAToken, error) {
+	t := &PSAToken{}
+
+	if err := t.message.UnmarshalCBOR(data); err != nil {
+		return nil, fmt.Errorf("PSA token decoding failed: %w", err)
+	}
+
+	if err := cbor.Unmarshal(t.message.Payload, &t.Claims); err != nil {
+		return nil, fmt.Errorf("PSA token claims decoding failed: %w", err)
+	}
+
+	if err := t.Claims.Valid(); err != nil {
+		return nil, fmt.Errorf("PSA token claims decoding failed: %w", err)
+	}
+
+	return t, nil
+}
+
+// Verify checks that the PSA token is signed with iak, the public part of the
+// IAK of the platform identified by the instance ID of the token
+func (t *PSAToken) Verify(iak crypto.PublicKey) error {
+	alg, err := t.message.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("PSA token verification failed: %w", err)
+	}
+
+	verifier, err := cose.NewVerifier(alg, iak)
+	if err != nil {
+		return fmt.Errorf("PSA token verification failed: %w", err)
+	}
+
+	if err := t.message.Verify(nil, verifier); err != nil {
+		return fmt.Errorf("PSA token verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// PSAInstanceID returns the instance ID of the platform whose IAK is iak:
+// the random UEID type byte followed by the SHA-256 digest of the
+// uncompressed point of the key
+func PSAInstanceID(iak *ecdsa.PublicKey) ([]byte, error) {
+	k, err := iak.ECDH()
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(k.Bytes())
+	return append([]byte{psaInstanceIDType}, digest[:]...), nil
+}
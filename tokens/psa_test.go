@@ -0,0 +1,110 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+func newTestPSAClaims(t *testing.T, iak *ecdsa.PrivateKey) PSAClaims {
+	instanceID, err := PSAInstanceID(&iak.PublicKey)
+	require.NoError(t, err)
+
+	return PSAClaims{
+		Profile:           PSAProfile,
+		ClientID:          -1,
+		SecurityLifeCycle: 0x3000,
+		ImplementationID:  bytes.Repeat([]byte{0xaa}, 32),
+		SoftwareComponents: []PSASoftwareComponent{
+			{MeasurementType: "BL", MeasurementValue: make([]byte, 32), SignerID: make([]byte, 32)},
+		},
+		Nonce:      bytes.Repeat([]byte{0xbb}, 48),
+		InstanceID: instanceID,
+	}
+}
+
+func signTestPSAClaims(t *testing.T, iak *ecdsa.PrivateKey, claims PSAClaims) []byte {
+	payload, err := cbor.Marshal(claims)
+	require.NoError(t, err)
+
+	signer, err := cose.NewSigner(cose.AlgorithmES256, iak)
+	require.NoError(t, err)
+
+	msg := cose.NewSign1Message()
+	msg.Headers.Protected.SetAlgorithm(cose.AlgorithmES256)
+	msg.Payload = payload
+	require.NoError(t, msg.Sign(rand.Reader, nil, signer))
+
+	data, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+
+	return data
+}
+
+func Test_PSAToken_Parse_Verify(t *testing.T) {
+	iak, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	claims := newTestPSAClaims(t, iak)
+	token, err := ParsePSAToken(signTestPSAClaims(t, iak, claims))
+	require.NoError(t, err)
+	assert.Equal(t, claims, token.Claims)
+	assert.NoError(t, token.Verify(iak.Public()))
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.EqualError(t, token.Verify(other.Public()), "PSA token verification failed: verification error")
+}
+
+func Test_PSAToken_Parse_Fail(t *testing.T) {
+	iak, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		update   func(c *PSAClaims)
+		expected string
+	}{
+		{func(c *PSAClaims) { c.Profile = MockEATProfile }, `unsupported profile "tag:github.com,2026:veraison/ratsd/mock-eat"`},
+		{func(c *PSAClaims) { c.Nonce = c.Nonce[:16] }, "nonce size 16 is not one of [32 48 64]"},
+		{func(c *PSAClaims) { c.ImplementationID = nil }, "implementation ID size 0, want 32"},
+		{func(c *PSAClaims) { c.InstanceID[0] = 0x02 }, "instance ID is not a random UEID of 33 bytes"},
+		{func(c *PSAClaims) { c.SoftwareComponents = nil }, "no software component"},
+	}
+
+	for _, tv := range tvs {
+		claims := newTestPSAClaims(t, iak)
+		tv.update(&claims)
+
+		_, err := ParsePSAToken(signTestPSAClaims(t, iak, claims))
+		assert.EqualError(t, err, "PSA token claims decoding failed: "+tv.expected)
+	}
+
+	_, err = ParsePSAToken([]byte("token"))
+	assert.ErrorContains(t, err, "PSA token decoding failed: ")
+}
+
+func Test_PSAInstanceID(t *testing.T) {
+	iak, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// uncompressed point: 0x04 || X || Y
+	point := make([]byte, 65)
+	point[0] = 0x04
+	iak.X.FillBytes(point[1:33])
+	iak.Y.FillBytes(point[33:])
+	digest := sha256.Sum256(point)
+
+	instanceID, err := PSAInstanceID(&iak.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0x01}, digest[:]...), instanceID)
+}
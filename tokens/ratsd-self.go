@@ -0,0 +1,157 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	RatsdSelfMediaTypeJSON = "application/vnd.veraison.ratsd-self+json"
+	RatsdSelfMediaTypeCBOR = "application/vnd.veraison.ratsd-self+cbor"
+
+	RatsdSelfNonceSize = 64
+
+	// RatsdSelfCollectionType is the collection type of ratsd-self evidence
+	// returned with the TEE evidence anchoring it, under the keys "evidence"
+	// and "anchor"
+	RatsdSelfCollectionType = "tag:github.com,2026:veraison/ratsd/cmw/ratsd-self"
+)
+
+// RatsdSelfEvidence describes the ratsd daemon answering a request: its
+// executable, its configuration and the plugins it loaded, all measured when
+// it started. It is not registered as a codec, as transcoding it would break
+// its binding to the TEE evidence anchoring it, see RatsdSelfAnchorData.
+// see docs/ratsd-self.cddl for definition
+type RatsdSelfEvidence struct {
+	Nonce      BinaryString        `json:"nonce"`
+	Executable RatsdSelfComponent  `json:"executable"`
+	Config     *RatsdSelfComponent `json:"config,omitempty"`
+	Plugins    []RatsdSelfPlugin   `json:"plugins,omitempty"`
+	// StartTime is the time ratsd started, in RFC 3339 format
+	StartTime string `json:"start_time"`
+}
+
+// RatsdSelfComponent is a file measured by ratsd
+type RatsdSelfComponent struct {
+	Path string `json:"path"`
+	// Digest is the SHA-256 digest of the file
+	Digest BinaryString `json:"digest"`
+}
+
+// RatsdSelfPlugin is a sub-attester plugin loaded by ratsd
+type RatsdSelfPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	// Digest is the SHA-256 digest of the plugin executable
+	Digest BinaryString `json:"digest"`
+	// Publisher is the name of the publisher whose key verified the
+	// signature of the plugin executable, if any
+	Publisher string `json:"publisher,omitempty"`
+}
+
+// RatsdSelfAnchorData returns the data that TEE evidence anchoring the
+// encoded evidence carries, e.g., the report data of a TSM report: the
+// SHA-512 digest of the evidence
+func RatsdSelfAnchorData(evidence []byte) []byte {
+	digest := sha512.Sum512(evidence)
+	return digest[:]
+}
+
+func (c *RatsdSelfComponent) valid() error {
+	if c.Path == "" {
+		return errors.New(`missing mandatory field "path"`)
+	}
+
+	if len(c.Digest) != sha256.Size {
+		return fmt.Errorf(`"digest" should be %d bytes, got %d`, sha256.Size, len(c.Digest))
+	}
+
+	return nil
+}
+
+// Valid checks if the RatsdSelfEvidence is populated correctly
+func (t *RatsdSelfEvidence) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	if err := t.Executable.valid(); err != nil {
+		return fmt.Errorf("executable: %w", err)
+	}
+
+	if t.Config != nil {
+		if err := t.Config.valid(); err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+	}
+
+	for i, p := range t.Plugins {
+		if p.Name == "" {
+			return fmt.Errorf(`plugin %d: missing mandatory field "name"`, i)
+		}
+
+		c := RatsdSelfComponent{Path: p.Path, Digest: p.Digest}
+		if err := c.valid(); err != nil {
+			return fmt.Errorf("plugin %d: %w", i, err)
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339, t.StartTime); err != nil {
+		return fmt.Errorf(`invalid "start_time": %w`, err)
+	}
+
+	return nil
+}
+
+// ToJSON encodes RatsdSelfEvidence as JSON
+func (t *RatsdSelfEvidence) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes RatsdSelfEvidence from JSON
+func (t *RatsdSelfEvidence) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes RatsdSelfEvidence as CBOR
+func (t *RatsdSelfEvidence) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes RatsdSelfEvidence from CBOR
+func (t *RatsdSelfEvidence) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
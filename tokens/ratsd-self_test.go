@@ -0,0 +1,89 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRatsdSelfEvidence() *RatsdSelfEvidence {
+	return &RatsdSelfEvidence{
+		Nonce: bytes.Repeat([]byte{0xab}, RatsdSelfNonceSize),
+		Executable: RatsdSelfComponent{
+			Path:   "/usr/bin/ratsd",
+			Digest: bytes.Repeat([]byte{0x01}, 32),
+		},
+		Config: &RatsdSelfComponent{
+			Path:   "/etc/ratsd/config.yaml",
+			Digest: bytes.Repeat([]byte{0x02}, 32),
+		},
+		Plugins: []RatsdSelfPlugin{
+			{
+				Name:    "tsm-report",
+				Version: "1.0.0",
+				Path:    "/usr/lib/ratsd/tsm.plugin",
+				Digest:  bytes.Repeat([]byte{0x03}, 32),
+			},
+		},
+		StartTime: "2026-10-19T08:00:00Z",
+	}
+}
+
+func Test_RatsdSelfEvidence_Valid_Fail(t *testing.T) {
+	tvs := []struct {
+		update   func(e *RatsdSelfEvidence)
+		expected string
+	}{
+		{func(e *RatsdSelfEvidence) { e.Nonce = nil }, `missing mandatory field "nonce"`},
+		{func(e *RatsdSelfEvidence) { e.Executable.Path = "" }, `executable: missing mandatory field "path"`},
+		{func(e *RatsdSelfEvidence) { e.Config.Digest = e.Config.Digest[:20] }, `config: "digest" should be 32 bytes, got 20`},
+		{func(e *RatsdSelfEvidence) { e.Plugins[0].Name = "" }, `plugin 0: missing mandatory field "name"`},
+		{func(e *RatsdSelfEvidence) { e.Plugins[0].Digest = nil }, `plugin 0: "digest" should be 32 bytes, got 0`},
+		{func(e *RatsdSelfEvidence) { e.StartTime = "yesterday" }, `invalid "start_time": `},
+	}
+
+	for _, tv := range tvs {
+		e := newTestRatsdSelfEvidence()
+		tv.update(e)
+		assert.ErrorContains(t, e.Valid(), tv.expected)
+	}
+}
+
+func Test_RatsdSelfEvidence_JSON_SerDes_Pass(t *testing.T) {
+	e := newTestRatsdSelfEvidence()
+
+	encoded, err := e.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &RatsdSelfEvidence{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, e, decoded)
+
+	// the configuration and the plugins are optional
+	e.Config = nil
+	e.Plugins = nil
+	encoded, err = e.ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "config")
+}
+
+func Test_RatsdSelfEvidence_CBOR_SerDes_Pass(t *testing.T) {
+	e := newTestRatsdSelfEvidence()
+
+	encoded, err := e.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &RatsdSelfEvidence{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, e, decoded)
+}
+
+func Test_RatsdSelfAnchorData(t *testing.T) {
+	expected := sha512.Sum512([]byte("evidence"))
+	assert.Equal(t, expected[:], RatsdSelfAnchorData([]byte("evidence")))
+}
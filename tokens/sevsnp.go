@@ -0,0 +1,189 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// SEVSNPReportMediaType is the media type of a bare SEV-SNP attestation
+	// report
+	SEVSNPReportMediaType = "application/vnd.amd.sev-snp.report"
+	// PKIXCertMediaType is the media type of a DER encoded X.509 certificate
+	// (RFC 2585)
+	PKIXCertMediaType = "application/pkix-cert"
+)
+
+// Layout of the SEV-SNP ATTESTATION_REPORT structure, see Table 22 of the SEV
+// Secure Nested Paging Firmware ABI Specification.
+const (
+	sevSNPReportSize     = 0x4a0
+	sevSNPMinVersion     = 2
+	sevSNPSignatureStart = 0x2a0
+
+	sevSNPCertTableEntrySize = 24
+)
+
+// Well-known GUIDs of the SEV-SNP certificate table entries
+const (
+	SEVSNPCertGUIDVCEK = "63da758d-e664-4564-adc5-f4b93be8accd"
+	SEVSNPCertGUIDVLEK = "a8074bc2-a25a-483e-aae6-39c045a0b8a1"
+	SEVSNPCertGUIDASK  = "4ab7b379-bbac-4fe4-a02f-05aef327c782"
+	SEVSNPCertGUIDARK  = "c0b406a4-a803-4952-9743-3fb6014cd0ae"
+)
+
+var sevSNPCertKinds = map[string]string{
+	SEVSNPCertGUIDVCEK: "VCEK",
+	SEVSNPCertGUIDVLEK: "VLEK",
+	SEVSNPCertGUIDASK:  "ASK",
+	SEVSNPCertGUIDARK:  "ARK",
+}
+
+// SEVSNPTCB is a TCB_VERSION of the SEV-SNP firmware, made of the security
+// version numbers of the components of the TCB.
+type SEVSNPTCB struct {
+	BootLoader uint8
+	TEE        uint8
+	SNP        uint8
+	Microcode  uint8
+	Raw        uint64
+}
+
+func newSEVSNPTCB(v uint64) SEVSNPTCB {
+	return SEVSNPTCB{
+		BootLoader: uint8(v),
+		TEE:        uint8(v >> 8),
+		SNP:        uint8(v >> 48),
+		Microcode:  uint8(v >> 56),
+		Raw:        v,
+	}
+}
+
+// SEVSNPReport is the attestation report returned in the outblob of the
+// sev_guest TSM provider
+type SEVSNPReport struct {
+	Version         uint32
+	GuestSVN        uint32
+	Policy          uint64
+	FamilyID        []byte
+	ImageID         []byte
+	VMPL            uint32
+	SignatureAlgo   uint32
+	CurrentTCB      SEVSNPTCB
+	PlatformInfo    uint64
+	Flags           uint32
+	ReportData      []byte
+	Measurement     []byte
+	HostData        []byte
+	IDKeyDigest     []byte
+	AuthorKeyDigest []byte
+	ReportID        []byte
+	ReportIDMA      []byte
+	ReportedTCB     SEVSNPTCB
+	ChipID          []byte
+	CommittedTCB    SEVSNPTCB
+	LaunchTCB       SEVSNPTCB
+	Signature       []byte
+}
+
+// ParseSEVSNPReport decodes a SEV-SNP attestation report. The signature is
+// not verified.
+func ParseSEVSNPReport(data []byte) (*SEVSNPReport, error) {
+	if len(data) < sevSNPReportSize {
+		return nil, fmt.Errorf("SEV-SNP report too short: %d bytes, want %d", len(data), sevSNPReportSize)
+	}
+
+	le := binary.LittleEndian
+	r := &SEVSNPReport{
+		Version:         le.Uint32(data[0x00:]),
+		GuestSVN:        le.Uint32(data[0x04:]),
+		Policy:          le.Uint64(data[0x08:]),
+		FamilyID:        clone(data[0x10:0x20]),
+		ImageID:         clone(data[0x20:0x30]),
+		VMPL:            le.Uint32(data[0x30:]),
+		SignatureAlgo:   le.Uint32(data[0x34:]),
+		CurrentTCB:      newSEVSNPTCB(le.Uint64(data[0x38:])),
+		PlatformInfo:    le.Uint64(data[0x40:]),
+		Flags:           le.Uint32(data[0x48:]),
+		ReportData:      clone(data[0x50:0x90]),
+		Measurement:     clone(data[0x90:0xc0]),
+		HostData:        clone(data[0xc0:0xe0]),
+		IDKeyDigest:     clone(data[0xe0:0x110]),
+		AuthorKeyDigest: clone(data[0x110:0x140]),
+		ReportID:        clone(data[0x140:0x160]),
+		ReportIDMA:      clone(data[0x160:0x180]),
+		ReportedTCB:     newSEVSNPTCB(le.Uint64(data[0x180:])),
+		ChipID:          clone(data[0x1a0:0x1e0]),
+		CommittedTCB:    newSEVSNPTCB(le.Uint64(data[0x1e0:])),
+		LaunchTCB:       newSEVSNPTCB(le.Uint64(data[0x1f0:])),
+		Signature:       clone(data[sevSNPSignatureStart:sevSNPReportSize]),
+	}
+
+	if r.Version < sevSNPMinVersion {
+		return nil, fmt.Errorf("unsupported SEV-SNP report version %d", r.Version)
+	}
+
+	return r, nil
+}
+
+// SEVSNPCertificate is an entry of the SEV-SNP certificate table
+type SEVSNPCertificate struct {
+	GUID string
+	// Kind is the name of the certificate for well-known GUIDs, e.g.,
+	// "VCEK", and empty otherwise
+	Kind string
+	DER  []byte
+}
+
+// X509 parses the certificate
+func (c SEVSNPCertificate) X509() (*x509.Certificate, error) {
+	return x509.ParseCertificate(c.DER)
+}
+
+// ParseSEVSNPCertTable splits the certificate table returned in the auxblob
+// of the sev_guest TSM provider into individual certificates
+func ParseSEVSNPCertTable(data []byte) ([]SEVSNPCertificate, error) {
+	var (
+		certs []SEVSNPCertificate
+		zero  [16]byte
+	)
+
+	for off := 0; ; off += sevSNPCertTableEntrySize {
+		if off+sevSNPCertTableEntrySize > len(data) {
+			return nil, fmt.Errorf("SEV-SNP certificate table not terminated")
+		}
+
+		entry := data[off : off+sevSNPCertTableEntrySize]
+		if bytes.Equal(entry[:16], zero[:]) {
+			break
+		}
+
+		guid := formatGUID(entry[:16])
+		start := binary.LittleEndian.Uint32(entry[16:])
+		length := binary.LittleEndian.Uint32(entry[20:])
+
+		if uint64(start)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("SEV-SNP certificate %s out of bounds", guid)
+		}
+
+		certs = append(certs, SEVSNPCertificate{
+			GUID: guid,
+			Kind: sevSNPCertKinds[guid],
+			DER:  clone(data[start : start+length]),
+		})
+	}
+
+	return certs, nil
+}
+
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
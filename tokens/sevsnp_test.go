@@ -0,0 +1,155 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSEVSNPReport() []byte {
+	r := make([]byte, sevSNPReportSize)
+	le := binary.LittleEndian
+
+	le.PutUint32(r[0x00:], 3)
+	le.PutUint32(r[0x04:], 7)
+	le.PutUint64(r[0x08:], 0x30000)
+	le.PutUint32(r[0x30:], 1)
+	le.PutUint32(r[0x34:], 1)
+	le.PutUint64(r[0x38:], 0xd315000000000304)
+	le.PutUint64(r[0x40:], 0x3)
+	for i := 0; i < 64; i++ {
+		r[0x50+i] = byte(i)
+	}
+	for i := 0; i < 48; i++ {
+		r[0x90+i] = 0xaa
+	}
+	le.PutUint64(r[0x180:], 0xd315000000000304)
+	for i := 0; i < 64; i++ {
+		r[0x1a0+i] = 0xcc
+	}
+	le.PutUint64(r[0x1e0:], 0xd315000000000304)
+	le.PutUint64(r[0x1f0:], 0xd114000000000203)
+	r[sevSNPSignatureStart] = 0xee
+
+	return r
+}
+
+func testSEVSNPCertTable(t *testing.T, certs map[string][]byte, guids ...string) []byte {
+	t.Helper()
+
+	table := make([]byte, sevSNPCertTableEntrySize*(len(guids)+1))
+	for i, guid := range guids {
+		id, err := hex.DecodeString(strings.ReplaceAll(guid, "-", ""))
+		require.NoError(t, err)
+
+		entry := table[i*sevSNPCertTableEntrySize:]
+		copy(entry, id)
+		binary.LittleEndian.PutUint32(entry[16:], uint32(len(table)))
+		binary.LittleEndian.PutUint32(entry[20:], uint32(len(certs[guid])))
+		table = append(table, certs[guid]...)
+	}
+
+	return table
+}
+
+func testCertificateDER(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "SEV-VCEK"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return der
+}
+
+func Test_ParseSEVSNPReport_Pass(t *testing.T) {
+	r, err := ParseSEVSNPReport(testSEVSNPReport())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(3), r.Version)
+	assert.Equal(t, uint32(7), r.GuestSVN)
+	assert.Equal(t, uint64(0x30000), r.Policy)
+	assert.Equal(t, uint32(1), r.VMPL)
+	assert.Equal(t, uint64(0x3), r.PlatformInfo)
+	assert.Equal(t, SEVSNPTCB{BootLoader: 4, TEE: 3, SNP: 0x15, Microcode: 0xd3, Raw: 0xd315000000000304}, r.CurrentTCB)
+	assert.Equal(t, r.CurrentTCB, r.ReportedTCB)
+	assert.Equal(t, r.CurrentTCB, r.CommittedTCB)
+	assert.Equal(t, SEVSNPTCB{BootLoader: 3, TEE: 2, SNP: 0x14, Microcode: 0xd1, Raw: 0xd114000000000203}, r.LaunchTCB)
+	assert.Len(t, r.ReportData, 64)
+	assert.Equal(t, byte(63), r.ReportData[63])
+	assert.Len(t, r.Measurement, 48)
+	assert.Equal(t, byte(0xaa), r.Measurement[47])
+	assert.Len(t, r.ChipID, 64)
+	assert.Len(t, r.Signature, 512)
+	assert.Equal(t, byte(0xee), r.Signature[0])
+}
+
+func Test_ParseSEVSNPReport_Fail(t *testing.T) {
+	_, err := ParseSEVSNPReport([]byte{2, 0, 0, 0})
+	assert.EqualError(t, err, "SEV-SNP report too short: 4 bytes, want 1184")
+
+	report := testSEVSNPReport()
+	report[0] = 1
+	_, err = ParseSEVSNPReport(report)
+	assert.EqualError(t, err, "unsupported SEV-SNP report version 1")
+}
+
+func Test_ParseSEVSNPCertTable_Pass(t *testing.T) {
+	vcek := testCertificateDER(t)
+	ask := []byte{0x30, 0x00}
+	certs := map[string][]byte{
+		SEVSNPCertGUIDVCEK:                     vcek,
+		SEVSNPCertGUIDASK:                      ask,
+		"00000000-0000-0000-0000-000000000001": {0x01},
+	}
+
+	table := testSEVSNPCertTable(t, certs,
+		SEVSNPCertGUIDVCEK, SEVSNPCertGUIDASK, "00000000-0000-0000-0000-000000000001")
+
+	parsed, err := ParseSEVSNPCertTable(table)
+	require.NoError(t, err)
+	require.Len(t, parsed, 3)
+
+	assert.Equal(t, SEVSNPCertificate{GUID: SEVSNPCertGUIDVCEK, Kind: "VCEK", DER: vcek}, parsed[0])
+	assert.Equal(t, SEVSNPCertificate{GUID: SEVSNPCertGUIDASK, Kind: "ASK", DER: ask}, parsed[1])
+	assert.Equal(t, SEVSNPCertificate{GUID: "00000000-0000-0000-0000-000000000001", DER: []byte{0x01}}, parsed[2])
+
+	cert, err := parsed[0].X509()
+	require.NoError(t, err)
+	assert.Equal(t, "SEV-VCEK", cert.Subject.CommonName)
+}
+
+func Test_ParseSEVSNPCertTable_Fail(t *testing.T) {
+	table := testSEVSNPCertTable(t, map[string][]byte{SEVSNPCertGUIDARK: {0x30}}, SEVSNPCertGUIDARK)
+
+	unterminated := bytes.Clone(table[:sevSNPCertTableEntrySize])
+	binary.LittleEndian.PutUint32(unterminated[16:], 0)
+	_, err := ParseSEVSNPCertTable(unterminated)
+	assert.EqualError(t, err, "SEV-SNP certificate table not terminated")
+
+	binary.LittleEndian.PutUint32(table[20:], 100)
+	_, err = ParseSEVSNPCertTable(table)
+	assert.EqualError(t, err, "SEV-SNP certificate "+SEVSNPCertGUIDARK+" out of bounds")
+}
@@ -0,0 +1,572 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	SPDMEvidenceMediaTypeCBOR = "application/vnd.veraison.spdm-evidence+cbor"
+	SPDMEvidenceMediaTypeJSON = "application/vnd.veraison.spdm-evidence+json"
+)
+
+// SPDMNonceSize is the size of the nonce of SPDM GET_MEASUREMENTS requests
+const SPDMNonceSize = 32
+
+// SPDMVersion12 is the SPDMVersion field of SPDM 1.2 messages, the only
+// version of the measurement transcripts supported
+const SPDMVersion12 = 0x12
+
+// SPDM request and response codes, see DSP0274
+const (
+	SPDMGetVersion          = 0x84
+	SPDMVersion             = 0x04
+	SPDMGetCapabilities     = 0xe1
+	SPDMCapabilities        = 0x61
+	SPDMNegotiateAlgorithms = 0xe3
+	SPDMAlgorithms          = 0x63
+	SPDMGetCertificate      = 0x82
+	SPDMCertificate         = 0x02
+	SPDMGetMeasurements     = 0xe0
+	SPDMMeasurements        = 0x60
+	SPDMError               = 0x7f
+)
+
+// BaseAsymAlgo bits of the SPDM algorithms supported for signing
+// measurements
+const (
+	SPDMAsymRSASSA2048 = 1 << 0
+	SPDMAsymRSAPSS2048 = 1 << 1
+	SPDMAsymRSASSA3072 = 1 << 2
+	SPDMAsymRSAPSS3072 = 1 << 3
+	SPDMAsymECDSAP256  = 1 << 4
+	SPDMAsymRSASSA4096 = 1 << 5
+	SPDMAsymRSAPSS4096 = 1 << 6
+	SPDMAsymECDSAP384  = 1 << 7
+	SPDMAsymECDSAP521  = 1 << 8
+)
+
+// BaseHashAlgo bits of the SPDM hash algorithms supported
+const (
+	SPDMHashSHA256 = 1 << 0
+	SPDMHashSHA384 = 1 << 1
+	SPDMHashSHA512 = 1 << 2
+)
+
+// MeasurementHashAlgo bits of the digests of DMTF measurement blocks
+const (
+	SPDMMeasurementHashRaw    = 1 << 0
+	SPDMMeasurementHashSHA256 = 1 << 1
+	SPDMMeasurementHashSHA384 = 1 << 2
+	SPDMMeasurementHashSHA512 = 1 << 3
+)
+
+// SPDMMeasurementSpecDMTF is the MeasurementSpecification bit of the DMTF
+// measurement blocks, the only ones supported
+const SPDMMeasurementSpecDMTF = 1 << 0
+
+// spdmMeasurementsContext is the spdm_context of the signature of
+// MEASUREMENTS responses
+const spdmMeasurementsContext = "responder-measurements signing"
+
+var errSPDMTruncated = errors.New("SPDM message truncated")
+
+// SPDMEvidence represents the SPDM measurements of the devices attached to
+// the machine
+// see docs/spdm-evidence.cddl for definition
+type SPDMEvidence struct {
+	// Nonce is the nonce of the GET_MEASUREMENTS requests
+	Nonce   BinaryString `json:"nonce"`
+	Devices []SPDMDevice `json:"devices"`
+}
+
+// SPDMDevice represents the signed measurements of an SPDM responder
+type SPDMDevice struct {
+	// Name identifies the device on the machine, e.g., its PCI address
+	Name string `json:"name"`
+	// CertificateChain is the SPDM certificate chain of slot 0, i.e., its
+	// length, the hash of its root certificate, and its DER encoded
+	// certificates, from the root to the leaf
+	CertificateChain BinaryString `json:"certificate_chain"`
+	// Transcript is the L1/L2 transcript of SPDM 1.2 covered by Signature:
+	// the version, capabilities and algorithms negotiation messages, then
+	// the GET_MEASUREMENTS requests and the MEASUREMENTS responses, the
+	// last one without its signature
+	Transcript BinaryString `json:"transcript"`
+	// Signature is the signature of the last MEASUREMENTS response, made
+	// with the key of the leaf certificate
+	Signature BinaryString `json:"signature"`
+	// Measurements are the measurement blocks of the MEASUREMENTS responses
+	// of Transcript
+	Measurements []SPDMMeasurement `json:"measurements"`
+}
+
+// SPDMMeasurement is a DMTF measurement block
+type SPDMMeasurement struct {
+	Index uint8 `json:"index"`
+	// Type is the DMTFSpecMeasurementValueType, e.g., 0x00 for a digest of
+	// the immutable ROM, or 0x81 for the raw bit stream of the mutable
+	// firmware
+	Type  uint8        `json:"type"`
+	Value BinaryString `json:"value"`
+}
+
+// SPDMTranscript holds the values parsed from the transcript of an SPDM
+// device
+type SPDMTranscript struct {
+	// AsymAlgo is the BaseAsymSel of the ALGORITHMS response
+	AsymAlgo uint32
+	// HashAlgo is the BaseHashSel of the ALGORITHMS response
+	HashAlgo uint32
+	// MeasurementHashAlgo is the MeasurementHashAlgo of the ALGORITHMS
+	// response, i.e., the hash algorithm of the digest measurements
+	MeasurementHashAlgo uint32
+	// Nonce is the nonce of the last GET_MEASUREMENTS request
+	Nonce []byte
+	// Measurements are the measurement blocks of the MEASUREMENTS
+	// responses
+	Measurements []SPDMMeasurement
+}
+
+// Valid checks if the SPDMEvidence is populated correctly
+func (t *SPDMEvidence) Valid() error {
+	if len(t.Nonce) == 0 {
+		return errors.New(`missing mandatory field "nonce"`)
+	}
+
+	if len(t.Devices) == 0 {
+		return errors.New(`missing mandatory field "devices"`)
+	}
+
+	for i, d := range t.Devices {
+		if err := d.Valid(); err != nil {
+			return fmt.Errorf("device %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Valid checks if the SPDMDevice is populated correctly
+func (d *SPDMDevice) Valid() error {
+	if d.Name == "" {
+		return errors.New(`missing mandatory field "name"`)
+	}
+
+	if len(d.CertificateChain) == 0 {
+		return errors.New(`missing mandatory field "certificate_chain"`)
+	}
+
+	if len(d.Transcript) == 0 {
+		return errors.New(`missing mandatory field "transcript"`)
+	}
+
+	if len(d.Signature) == 0 {
+		return errors.New(`missing mandatory field "signature"`)
+	}
+
+	return nil
+}
+
+// ToJSON encodes SPDMEvidence as JSON
+func (t *SPDMEvidence) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes SPDMEvidence from JSON
+func (t *SPDMEvidence) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes SPDMEvidence as CBOR
+func (t *SPDMEvidence) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes SPDMEvidence from CBOR
+func (t *SPDMEvidence) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ParseTranscript parses the transcript of the device. The signature is not
+// verified.
+func (d *SPDMDevice) ParseTranscript() (*SPDMTranscript, error) {
+	r := &spdmReader{data: d.Transcript}
+	tr := &SPDMTranscript{}
+
+	// version, capabilities and algorithms negotiation
+	if _, err := r.message(0x10, SPDMGetVersion, 4); err != nil {
+		return nil, err
+	}
+
+	version, err := r.message(0x10, SPDMVersion, 6)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.next(2 * int(version[5])); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.message(SPDMVersion12, SPDMGetCapabilities, 20); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.message(SPDMVersion12, SPDMCapabilities, 20); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.lengthPrefixed(SPDMNegotiateAlgorithms, 32); err != nil {
+		return nil, err
+	}
+
+	algorithms, err := r.lengthPrefixed(SPDMAlgorithms, 36)
+	if err != nil {
+		return nil, err
+	}
+	tr.MeasurementHashAlgo = binary.LittleEndian.Uint32(algorithms[8:])
+	tr.AsymAlgo = binary.LittleEndian.Uint32(algorithms[12:])
+	tr.HashAlgo = binary.LittleEndian.Uint32(algorithms[16:])
+
+	// measurements
+	signed := false
+	for r.len() > 0 {
+		if signed {
+			return nil, errors.New("messages follow the signed MEASUREMENTS response")
+		}
+
+		req, err := r.message(SPDMVersion12, SPDMGetMeasurements, 4)
+		if err != nil {
+			return nil, err
+		}
+
+		if signed = req[2]&1 != 0; signed {
+			nonce, err := r.next(SPDMNonceSize + 1)
+			if err != nil {
+				return nil, err
+			}
+			tr.Nonce = nonce[:SPDMNonceSize]
+		}
+
+		resp, err := r.message(SPDMVersion12, SPDMMeasurements, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := r.next(int(resp[5]) | int(resp[6])<<8 | int(resp[7])<<16)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks, err := parseSPDMMeasurementRecord(record, int(resp[4]))
+		if err != nil {
+			return nil, err
+		}
+		tr.Measurements = append(tr.Measurements, blocks...)
+
+		// responder nonce and opaque data
+		opaque, err := r.next(SPDMNonceSize + 2)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.next(int(binary.LittleEndian.Uint16(opaque[SPDMNonceSize:]))); err != nil {
+			return nil, err
+		}
+	}
+
+	if !signed {
+		return nil, errors.New("no signed MEASUREMENTS response")
+	}
+
+	return tr, nil
+}
+
+// Certificates returns the parsed certificates of the certificate chain,
+// root first. hashAlgo is the BaseHashAlgo of the root hash, as in
+// SPDMTranscript.HashAlgo.
+func (d *SPDMDevice) Certificates(hashAlgo uint32) ([]*x509.Certificate, error) {
+	h, err := spdmHash(hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := d.CertificateChain
+	if len(chain) < 4+h.Size() {
+		return nil, errors.New("certificate chain truncated")
+	}
+
+	if l := int(binary.LittleEndian.Uint16(chain)); l != len(chain) {
+		return nil, fmt.Errorf("certificate chain length %d, expected %d", l, len(chain))
+	}
+
+	certs, err := x509.ParseCertificates(chain[4+h.Size():])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate in chain")
+	}
+
+	rootHash := h.New()
+	rootHash.Write(certs[0].Raw)
+	if !bytes.Equal(rootHash.Sum(nil), chain[4:4+h.Size()]) {
+		return nil, errors.New("root hash does not match the root certificate")
+	}
+
+	return certs, nil
+}
+
+// Verify checks that the certificate chain of the device is rooted in one of
+// roots at currentTime, that its transcript is signed with the key of the
+// leaf certificate, and that the measurements are those of the transcript.
+// The zero currentTime means the current time. The nonce of the transcript is
+// left to the caller to check.
+func (d *SPDMDevice) Verify(roots []*x509.Certificate, currentTime time.Time) error {
+	tr, err := d.ParseTranscript()
+	if err != nil {
+		return fmt.Errorf("SPDM transcript decoding failed: %w", err)
+	}
+
+	certs, err := d.Certificates(tr.HashAlgo)
+	if err != nil {
+		return fmt.Errorf("SPDM certificate chain decoding failed: %w", err)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, c := range roots {
+		rootPool.AddCert(c)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs {
+		intermediates.AddCert(c)
+	}
+
+	leaf := certs[len(certs)-1]
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediates,
+		CurrentTime:   currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("SPDM certificate chain validation failed: %w", err)
+	}
+
+	if err := verifySPDMSignature(leaf.PublicKey, tr, d.Transcript, d.Signature); err != nil {
+		return fmt.Errorf("SPDM signature verification failed: %w", err)
+	}
+
+	if !slices.EqualFunc(d.Measurements, tr.Measurements, func(a, b SPDMMeasurement) bool {
+		return a.Index == b.Index && a.Type == b.Type && bytes.Equal(a.Value, b.Value)
+	}) {
+		return errors.New("SPDM measurements differ from those of the transcript")
+	}
+
+	return nil
+}
+
+// SPDMMeasurementsDigest returns the digest signed by an SPDM 1.2 responder
+// in its MEASUREMENTS response, i.e., the hash of the combined SPDM prefix
+// and of the hash of transcript
+func SPDMMeasurementsDigest(h crypto.Hash, transcript []byte) []byte {
+	prefix := bytes.Repeat([]byte("dmtf-spdm-v1.2.*"), 4)
+	prefix = append(prefix, make([]byte, 36-len(spdmMeasurementsContext))...)
+	prefix = append(prefix, spdmMeasurementsContext...)
+
+	th := h.New()
+	th.Write(transcript)
+
+	mh := h.New()
+	mh.Write(prefix)
+	mh.Write(th.Sum(nil))
+
+	return mh.Sum(nil)
+}
+
+func verifySPDMSignature(pub crypto.PublicKey, tr *SPDMTranscript, transcript, sig []byte) error {
+	h, err := spdmHash(tr.HashAlgo)
+	if err != nil {
+		return err
+	}
+	digest := SPDMMeasurementsDigest(h, transcript)
+
+	switch tr.AsymAlgo {
+	case SPDMAsymECDSAP256, SPDMAsymECDSAP384, SPDMAsymECDSAP521:
+		curve := map[uint32]elliptic.Curve{
+			SPDMAsymECDSAP256: elliptic.P256(),
+			SPDMAsymECDSAP384: elliptic.P384(),
+			SPDMAsymECDSAP521: elliptic.P521(),
+		}[tr.AsymAlgo]
+
+		k, ok := pub.(*ecdsa.PublicKey)
+		if !ok || k.Curve != curve {
+			return fmt.Errorf("leaf key is not an ECDSA %s key", curve.Params().Name)
+		}
+
+		size := (curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return fmt.Errorf("signature size %d, expected %d", len(sig), 2*size)
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+	case SPDMAsymRSASSA2048, SPDMAsymRSASSA3072, SPDMAsymRSASSA4096:
+		k, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("leaf key is not an RSA key")
+		}
+		return rsa.VerifyPKCS1v15(k, h, digest, sig)
+	case SPDMAsymRSAPSS2048, SPDMAsymRSAPSS3072, SPDMAsymRSAPSS4096:
+		k, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("leaf key is not an RSA key")
+		}
+		return rsa.VerifyPSS(k, h, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	default:
+		return fmt.Errorf("unsupported BaseAsymAlgo %#x", tr.AsymAlgo)
+	}
+
+	return nil
+}
+
+func spdmHash(hashAlgo uint32) (crypto.Hash, error) {
+	switch hashAlgo {
+	case SPDMHashSHA256:
+		return crypto.SHA256, nil
+	case SPDMHashSHA384:
+		return crypto.SHA384, nil
+	case SPDMHashSHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported BaseHashAlgo %#x", hashAlgo)
+	}
+}
+
+// parseSPDMMeasurementRecord parses the n DMTF measurement blocks of record
+func parseSPDMMeasurementRecord(record []byte, n int) ([]SPDMMeasurement, error) {
+	r := &spdmReader{data: record}
+	blocks := make([]SPDMMeasurement, 0, n)
+
+	for i := 0; i < n; i++ {
+		header, err := r.next(4)
+		if err != nil {
+			return nil, fmt.Errorf("measurement block %d: %w", i, err)
+		}
+
+		if header[1] != SPDMMeasurementSpecDMTF {
+			return nil, fmt.Errorf("measurement block %d: unsupported MeasurementSpecification %#x", i, header[1])
+		}
+
+		m, err := r.next(int(binary.LittleEndian.Uint16(header[2:])))
+		if err != nil {
+			return nil, fmt.Errorf("measurement block %d: %w", i, err)
+		}
+
+		if len(m) < 3 || int(binary.LittleEndian.Uint16(m[1:])) != len(m)-3 {
+			return nil, fmt.Errorf("measurement block %d: invalid DMTF measurement", i)
+		}
+
+		blocks = append(blocks, SPDMMeasurement{Index: header[0], Type: m[0], Value: m[3:]})
+	}
+
+	if r.len() > 0 {
+		return nil, errors.New("trailing data after the measurement blocks")
+	}
+
+	return blocks, nil
+}
+
+// spdmReader reads consecutive SPDM messages
+type spdmReader struct {
+	data []byte
+}
+
+func (r *spdmReader) len() int {
+	return len(r.data)
+}
+
+func (r *spdmReader) next(n int) ([]byte, error) {
+	if n > len(r.data) {
+		return nil, errSPDMTruncated
+	}
+
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+// message reads the first size bytes of a message, checking its version and
+// code
+func (r *spdmReader) message(version, code byte, size int) ([]byte, error) {
+	if len(r.data) >= 2 && r.data[1] != code {
+		return nil, fmt.Errorf("unexpected SPDM message %#x, expected %#x", r.data[1], code)
+	}
+
+	m, err := r.next(size)
+	if err != nil {
+		return nil, err
+	}
+
+	if m[0] != version {
+		return nil, fmt.Errorf("unexpected SPDM version %#x in message %#x", m[0], code)
+	}
+
+	return m, nil
+}
+
+// lengthPrefixed reads a message carrying its length after its header
+func (r *spdmReader) lengthPrefixed(code byte, minSize int) ([]byte, error) {
+	if len(r.data) < 6 {
+		return nil, errSPDMTruncated
+	}
+
+	size := int(binary.LittleEndian.Uint16(r.data[4:]))
+	if size < minSize {
+		return nil, fmt.Errorf("SPDM message %#x too short", code)
+	}
+
+	return r.message(SPDMVersion12, code, size)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSPDMNonce = bytes.Repeat([]byte{0xab}, SPDMNonceSize)
+
+var testSPDMMeasurements = []SPDMMeasurement{
+	{Index: 1, Type: 0x00, Value: bytes.Repeat([]byte{0x01}, 32)},
+	{Index: 2, Type: 0x86, Value: []byte("1.0.0")},
+}
+
+// newTestSPDMTranscript returns an SPDM 1.2 transcript negotiating asym and
+// SHA-256, and measuring testSPDMMeasurements over testSPDMNonce
+func newTestSPDMTranscript(asym uint32) []byte {
+	tr := []byte{0x10, SPDMGetVersion, 0, 0}
+	tr = append(tr, 0x10, SPDMVersion, 0, 0, 0, 1, 0x00, SPDMVersion12)
+	tr = append(tr, SPDMVersion12, SPDMGetCapabilities, 0, 0)
+	tr = append(tr, make([]byte, 16)...)
+	tr = append(tr, SPDMVersion12, SPDMCapabilities, 0, 0)
+	tr = append(tr, make([]byte, 16)...)
+
+	tr = append(tr, SPDMVersion12, SPDMNegotiateAlgorithms, 0, 0, 32, 0, SPDMMeasurementSpecDMTF, 0)
+	tr = binary.LittleEndian.AppendUint32(tr, asym)
+	tr = binary.LittleEndian.AppendUint32(tr, SPDMHashSHA256)
+	tr = append(tr, make([]byte, 16)...)
+	tr = append(tr, SPDMVersion12, SPDMAlgorithms, 0, 0, 36, 0, SPDMMeasurementSpecDMTF, 0)
+	tr = binary.LittleEndian.AppendUint32(tr, SPDMMeasurementHashSHA256)
+	tr = binary.LittleEndian.AppendUint32(tr, asym)
+	tr = binary.LittleEndian.AppendUint32(tr, SPDMHashSHA256)
+	tr = append(tr, make([]byte, 16)...)
+
+	var record []byte
+	for _, m := range testSPDMMeasurements {
+		record = append(record, m.Index, SPDMMeasurementSpecDMTF)
+		record = binary.LittleEndian.AppendUint16(record, uint16(3+len(m.Value)))
+		record = append(record, m.Type)
+		record = binary.LittleEndian.AppendUint16(record, uint16(len(m.Value)))
+		record = append(record, m.Value...)
+	}
+
+	tr = append(tr, SPDMVersion12, SPDMGetMeasurements, 1, 0xff)
+	tr = append(tr, testSPDMNonce...)
+	tr = append(tr, 0)
+	tr = append(tr, SPDMVersion12, SPDMMeasurements, 0, 0, byte(len(testSPDMMeasurements)), byte(len(record)), 0, 0)
+	tr = append(tr, record...)
+	tr = append(tr, bytes.Repeat([]byte{0xcd}, SPDMNonceSize)...)
+	// opaque data
+	tr = append(tr, 2, 0, 0xca, 0xfe)
+
+	return tr
+}
+
+// newTestSPDMDevice returns a device whose self-signed certificate is that
+// of key
+func newTestSPDMDevice(t *testing.T, key crypto.Signer, asym uint32) (*SPDMDevice, *x509.Certificate) {
+	t.Helper()
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "SPDM device"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	rootHash := sha256.Sum256(der)
+	chain := binary.LittleEndian.AppendUint16(nil, uint16(4+len(rootHash)+len(der)))
+	chain = append(chain, 0, 0)
+	chain = append(chain, rootHash[:]...)
+	chain = append(chain, der...)
+
+	transcript := newTestSPDMTranscript(asym)
+	digest := SPDMMeasurementsDigest(crypto.SHA256, transcript)
+
+	var sig []byte
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		require.NoError(t, err)
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest,
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		require.NoError(t, err)
+	}
+
+	return &SPDMDevice{
+		Name:             "nic0",
+		CertificateChain: chain,
+		Transcript:       transcript,
+		Signature:        sig,
+		Measurements:     testSPDMMeasurements,
+	}, cert
+}
+
+func newTestECDSASPDMDevice(t *testing.T) (*SPDMDevice, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return newTestSPDMDevice(t, key, SPDMAsymECDSAP256)
+}
+
+func Test_SPDMDevice_ParseTranscript(t *testing.T) {
+	d, _ := newTestECDSASPDMDevice(t)
+
+	tr, err := d.ParseTranscript()
+	require.NoError(t, err)
+	assert.Equal(t, &SPDMTranscript{
+		AsymAlgo:            SPDMAsymECDSAP256,
+		HashAlgo:            SPDMHashSHA256,
+		MeasurementHashAlgo: SPDMMeasurementHashSHA256,
+		Nonce:               testSPDMNonce,
+		Measurements:        testSPDMMeasurements,
+	}, tr)
+}
+
+func Test_SPDMDevice_ParseTranscript_Fail(t *testing.T) {
+	transcript := newTestSPDMTranscript(SPDMAsymECDSAP256)
+
+	// the negotiation takes 120 bytes, followed by the signed request
+	unsigned := slices.Concat(transcript[:120],
+		[]byte{SPDMVersion12, SPDMGetMeasurements, 0, 0xff}, transcript[120+4+SPDMNonceSize+1:])
+
+	tvs := []struct {
+		transcript []byte
+		expected   string
+	}{
+		{transcript[:len(transcript)-1], "SPDM message truncated"},
+		{append(slices.Clone(transcript), 0), "messages follow the signed MEASUREMENTS response"},
+		{transcript[4:], "unexpected SPDM message 0x4, expected 0x84"},
+		{unsigned, "no signed MEASUREMENTS response"},
+	}
+
+	for _, tv := range tvs {
+		d := &SPDMDevice{Transcript: tv.transcript}
+		_, err := d.ParseTranscript()
+		assert.EqualError(t, err, tv.expected)
+	}
+}
+
+func Test_SPDMDevice_Verify(t *testing.T) {
+	d, cert := newTestECDSASPDMDevice(t)
+	require.NoError(t, d.Verify([]*x509.Certificate{cert}, time.Time{}))
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	d, cert = newTestSPDMDevice(t, key, SPDMAsymRSAPSS2048)
+	require.NoError(t, d.Verify([]*x509.Certificate{cert}, time.Time{}))
+}
+
+func Test_SPDMDevice_Verify_Fail(t *testing.T) {
+	d, cert := newTestECDSASPDMDevice(t)
+	roots := []*x509.Certificate{cert}
+
+	_, other := newTestECDSASPDMDevice(t)
+	assert.ErrorContains(t, d.Verify([]*x509.Certificate{other}, time.Time{}),
+		"SPDM certificate chain validation failed: ")
+
+	d.Signature[0] ^= 0xff
+	assert.EqualError(t, d.Verify(roots, time.Time{}),
+		"SPDM signature verification failed: invalid signature")
+	d.Signature[0] ^= 0xff
+
+	d.Measurements = d.Measurements[:1]
+	assert.EqualError(t, d.Verify(roots, time.Time{}),
+		"SPDM measurements differ from those of the transcript")
+
+	d.CertificateChain[4] ^= 0xff
+	assert.EqualError(t, d.Verify(roots, time.Time{}),
+		"SPDM certificate chain decoding failed: root hash does not match the root certificate")
+
+	d.Transcript = d.Transcript[:20]
+	assert.ErrorContains(t, d.Verify(roots, time.Time{}), "SPDM transcript decoding failed: ")
+}
+
+func Test_SPDMEvidence_Valid_Fail(t *testing.T) {
+	d, _ := newTestECDSASPDMDevice(t)
+
+	tvs := []struct {
+		update   func(e *SPDMEvidence)
+		expected string
+	}{
+		{func(e *SPDMEvidence) { e.Nonce = nil }, `missing mandatory field "nonce"`},
+		{func(e *SPDMEvidence) { e.Devices = nil }, `missing mandatory field "devices"`},
+		{func(e *SPDMEvidence) { e.Devices[0].Name = "" }, `device 0: missing mandatory field "name"`},
+		{func(e *SPDMEvidence) { e.Devices[0].CertificateChain = nil }, `device 0: missing mandatory field "certificate_chain"`},
+		{func(e *SPDMEvidence) { e.Devices[0].Transcript = nil }, `device 0: missing mandatory field "transcript"`},
+		{func(e *SPDMEvidence) { e.Devices[0].Signature = nil }, `device 0: missing mandatory field "signature"`},
+	}
+
+	for _, tv := range tvs {
+		e := &SPDMEvidence{Nonce: testSPDMNonce, Devices: []SPDMDevice{*d}}
+		tv.update(e)
+		assert.EqualError(t, e.Valid(), tv.expected)
+	}
+}
+
+func Test_SPDMEvidence_JSON_SerDes_Pass(t *testing.T) {
+	d, _ := newTestECDSASPDMDevice(t)
+	e := &SPDMEvidence{Nonce: testSPDMNonce, Devices: []SPDMDevice{*d}}
+
+	encoded, err := e.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &SPDMEvidence{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, e, decoded)
+}
+
+func Test_SPDMEvidence_CBOR_SerDes_Pass(t *testing.T) {
+	d, _ := newTestECDSASPDMDevice(t)
+	e := &SPDMEvidence{Nonce: testSPDMNonce, Devices: []SPDMDevice{*d}}
+
+	encoded, err := e.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &SPDMEvidence{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, e, decoded)
+}
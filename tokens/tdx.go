@@ -0,0 +1,146 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// TDXQuoteMediaType is the media type of a bare TDX quote
+const TDXQuoteMediaType = "application/vnd.intel.tdx.quote"
+
+// Layout of the TDX quote, see the Intel TDX DCAP Quoting Library API
+const (
+	tdxQuoteHeaderSize = 48
+	tdxTEEType         = 0x81
+
+	tdxBodyTypeTDX10    = 2
+	tdxBodyTypeTDX15    = 3
+	tdxReportBody10Size = 584
+	tdxReportBody15Size = 648
+)
+
+// TDXReportBody is the TD report body of a TDX quote. TEETCBSVN2 and
+// MRServiceTD are only set for TDX 1.5 report bodies.
+type TDXReportBody struct {
+	TEETCBSVN      []byte
+	MRSEAM         []byte
+	MRSignerSEAM   []byte
+	SEAMAttributes []byte
+	TDAttributes   []byte
+	XFAM           []byte
+	MRTD           []byte
+	MRConfigID     []byte
+	MROwner        []byte
+	MROwnerConfig  []byte
+	RTMR           [][]byte
+	ReportData     []byte
+	TEETCBSVN2     []byte
+	MRServiceTD    []byte
+}
+
+// TDXQuote is the quote returned in the outblob of the tdx_guest TSM provider
+type TDXQuote struct {
+	Version            uint16
+	AttestationKeyType uint16
+	TEEType            uint32
+	QESVN              uint16
+	PCESVN             uint16
+	QEVendorID         []byte
+	UserData           []byte
+	Body               TDXReportBody
+	// SignatureData is the quote signature data, including the QE
+	// certification data
+	SignatureData []byte
+}
+
+// ParseTDXQuote decodes a version 4 or 5 TDX quote. The signature is not
+// verified.
+func ParseTDXQuote(data []byte) (*TDXQuote, error) {
+	if len(data) < tdxQuoteHeaderSize {
+		return nil, fmt.Errorf("TDX quote too short: %d bytes", len(data))
+	}
+
+	le := binary.LittleEndian
+	q := &TDXQuote{
+		Version:            le.Uint16(data[0:]),
+		AttestationKeyType: le.Uint16(data[2:]),
+		TEEType:            le.Uint32(data[4:]),
+		QESVN:              le.Uint16(data[8:]),
+		PCESVN:             le.Uint16(data[10:]),
+		QEVendorID:         clone(data[12:28]),
+		UserData:           clone(data[28:48]),
+	}
+
+	if q.TEEType != tdxTEEType {
+		return nil, fmt.Errorf("unexpected TDX quote TEE type %#x", q.TEEType)
+	}
+
+	off := tdxQuoteHeaderSize
+	bodyType := uint16(tdxBodyTypeTDX10)
+
+	switch q.Version {
+	case 4:
+	case 5:
+		if len(data) < off+6 {
+			return nil, fmt.Errorf("TDX quote too short: %d bytes", len(data))
+		}
+		bodyType = le.Uint16(data[off:])
+		off += 6
+	default:
+		return nil, fmt.Errorf("unsupported TDX quote version %d", q.Version)
+	}
+
+	bodySize := tdxReportBody10Size
+	switch bodyType {
+	case tdxBodyTypeTDX10:
+	case tdxBodyTypeTDX15:
+		bodySize = tdxReportBody15Size
+	default:
+		return nil, fmt.Errorf("unsupported TDX report body type %d", bodyType)
+	}
+
+	if len(data) < off+bodySize+4 {
+		return nil, fmt.Errorf("TDX quote too short: %d bytes", len(data))
+	}
+
+	q.Body = parseTDXReportBody(data[off : off+bodySize])
+	off += bodySize
+
+	sigLen := le.Uint32(data[off:])
+	off += 4
+	if uint64(off)+uint64(sigLen) > uint64(len(data)) {
+		return nil, fmt.Errorf("TDX quote signature data out of bounds")
+	}
+	q.SignatureData = clone(data[off : off+int(sigLen)])
+
+	return q, nil
+}
+
+func parseTDXReportBody(b []byte) TDXReportBody {
+	body := TDXReportBody{
+		TEETCBSVN:      clone(b[0:16]),
+		MRSEAM:         clone(b[16:64]),
+		MRSignerSEAM:   clone(b[64:112]),
+		SEAMAttributes: clone(b[112:120]),
+		TDAttributes:   clone(b[120:128]),
+		XFAM:           clone(b[128:136]),
+		MRTD:           clone(b[136:184]),
+		MRConfigID:     clone(b[184:232]),
+		MROwner:        clone(b[232:280]),
+		MROwnerConfig:  clone(b[280:328]),
+		ReportData:     clone(b[520:584]),
+	}
+
+	for i := 0; i < 4; i++ {
+		body.RTMR = append(body.RTMR, clone(b[328+48*i:376+48*i]))
+	}
+
+	if len(b) == tdxReportBody15Size {
+		body.TEETCBSVN2 = clone(b[584:600])
+		body.MRServiceTD = clone(b[600:648])
+	}
+
+	return body
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTDXReportBody(size int) []byte {
+	body := make([]byte, size)
+	for i := 0; i < size/8; i++ {
+		binary.LittleEndian.PutUint64(body[8*i:], uint64(i))
+	}
+	return body
+}
+
+func testTDXQuote(version uint16, bodyType uint16, body []byte, signature []byte) []byte {
+	le := binary.LittleEndian
+	header := make([]byte, tdxQuoteHeaderSize)
+	le.PutUint16(header[0:], version)
+	le.PutUint16(header[2:], 2)
+	le.PutUint32(header[4:], tdxTEEType)
+	copy(header[12:], bytes.Repeat([]byte{0x93}, 16))
+
+	q := header
+	if version == 5 {
+		q = le.AppendUint16(q, bodyType)
+		q = le.AppendUint32(q, uint32(len(body)))
+	}
+	q = append(q, body...)
+	q = le.AppendUint32(q, uint32(len(signature)))
+	return append(q, signature...)
+}
+
+func Test_ParseTDXQuote_V4_Pass(t *testing.T) {
+	body := testTDXReportBody(tdxReportBody10Size)
+
+	q, err := ParseTDXQuote(testTDXQuote(4, 0, body, []byte{0xee, 0xff}))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(4), q.Version)
+	assert.Equal(t, uint16(2), q.AttestationKeyType)
+	assert.Equal(t, uint32(tdxTEEType), q.TEEType)
+	assert.Equal(t, bytes.Repeat([]byte{0x93}, 16), q.QEVendorID)
+	assert.Equal(t, body[0:16], q.Body.TEETCBSVN)
+	assert.Equal(t, body[136:184], q.Body.MRTD)
+	require.Len(t, q.Body.RTMR, 4)
+	assert.Equal(t, body[472:520], q.Body.RTMR[3])
+	assert.Equal(t, body[520:584], q.Body.ReportData)
+	assert.Nil(t, q.Body.MRServiceTD)
+	assert.Equal(t, []byte{0xee, 0xff}, q.SignatureData)
+}
+
+func Test_ParseTDXQuote_V5_Pass(t *testing.T) {
+	body := testTDXReportBody(tdxReportBody15Size)
+
+	q, err := ParseTDXQuote(testTDXQuote(5, tdxBodyTypeTDX15, body, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(5), q.Version)
+	assert.Equal(t, body[520:584], q.Body.ReportData)
+	assert.Equal(t, body[584:600], q.Body.TEETCBSVN2)
+	assert.Equal(t, body[600:648], q.Body.MRServiceTD)
+	assert.Empty(t, q.SignatureData)
+}
+
+func Test_ParseTDXQuote_Fail(t *testing.T) {
+	body := testTDXReportBody(tdxReportBody10Size)
+	valid := testTDXQuote(4, 0, body, []byte{0xee})
+
+	wrongTEE := bytes.Clone(valid)
+	wrongTEE[4] = 0
+
+	wrongVersion := bytes.Clone(valid)
+	wrongVersion[0] = 3
+
+	truncatedSignature := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(truncatedSignature[tdxQuoteHeaderSize+tdxReportBody10Size:], 2)
+
+	tests := []struct {
+		name     string
+		quote    []byte
+		expected string
+	}{
+		{"short header", valid[:10], "TDX quote too short: 10 bytes"},
+		{"short body", valid[:100], "TDX quote too short: 100 bytes"},
+		{"wrong TEE type", wrongTEE, "unexpected TDX quote TEE type 0x0"},
+		{"unsupported version", wrongVersion, "unsupported TDX quote version 3"},
+		{"unsupported body type", testTDXQuote(5, 1, body, nil), "unsupported TDX report body type 1"},
+		{"truncated signature", truncatedSignature, "TDX quote signature data out of bounds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTDXQuote(tt.quote)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
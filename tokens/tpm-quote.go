@@ -0,0 +1,353 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-tpm/tpm2"
+)
+
+const (
+	TPMQuoteMediaTypeCBOR = "application/vnd.veraison.tpm-quote+cbor"
+	TPMQuoteMediaTypeJSON = "application/vnd.veraison.tpm-quote+json"
+)
+
+// TPMHashAlgs maps the names of the PCR banks to their TPM algorithm IDs
+var TPMHashAlgs = map[string]tpm2.TPMAlgID{
+	"sha1":   tpm2.TPMAlgSHA1,
+	"sha256": tpm2.TPMAlgSHA256,
+	"sha384": tpm2.TPMAlgSHA384,
+	"sha512": tpm2.TPMAlgSHA512,
+}
+
+// TPMQuote represents a TPM 2.0 quote with the PCR values it covers
+// see docs/tpm-quote.cddl for definition
+type TPMQuote struct {
+	// Quote is the TPMS_ATTEST structure signed by the AK
+	Quote BinaryString `json:"quote"`
+	// Signature is the TPMT_SIGNATURE over Quote
+	Signature BinaryString `json:"signature"`
+	// HashAlg is the name of the quoted PCR bank
+	HashAlg string `json:"hash_alg"`
+	// PCRs are the values of the quoted PCRs, indexed by PCR number
+	PCRs map[uint32]BinaryString `json:"pcrs"`
+	// AKPublic is the TPMT_PUBLIC area of the AK
+	AKPublic BinaryString `json:"ak_public"`
+	// AKCert is the DER-encoded certificate of the AK
+	AKCert BinaryString `json:"ak_cert,omitempty"`
+	// EventLog is the TCG measured-boot event log
+	EventLog BinaryString `json:"event_log,omitempty"`
+}
+
+// Valid checks if the TPMQuote is populated correctly
+func (t *TPMQuote) Valid() error {
+	if len(t.Quote) == 0 {
+		return errors.New(`missing mandatory field "quote"`)
+	}
+
+	if len(t.Signature) == 0 {
+		return errors.New(`missing mandatory field "signature"`)
+	}
+
+	if t.HashAlg == "" {
+		return errors.New(`missing mandatory field "hash_alg"`)
+	}
+
+	if _, ok := TPMHashAlgs[t.HashAlg]; !ok {
+		return fmt.Errorf(`unsupported "hash_alg" %q`, t.HashAlg)
+	}
+
+	if len(t.PCRs) == 0 {
+		return errors.New(`missing mandatory field "pcrs"`)
+	}
+
+	if len(t.AKPublic) == 0 {
+		return errors.New(`missing mandatory field "ak_public"`)
+	}
+
+	return nil
+}
+
+// ToJSON encodes TPMQuote as JSON
+func (t *TPMQuote) ToJSON() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("JSON encoding failed: %w", err)
+	}
+
+	return json.Marshal(t)
+}
+
+// FromJSON decodes TPMQuote from JSON
+func (t *TPMQuote) FromJSON(data []byte) error {
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("JSON decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ToCBOR encodes TPMQuote as CBOR
+func (t *TPMQuote) ToCBOR() ([]byte, error) {
+	if err := t.Valid(); err != nil {
+		return nil, fmt.Errorf("CBOR encoding failed: %w", err)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// FromCBOR decodes TPMQuote from CBOR
+func (t *TPMQuote) FromCBOR(data []byte) error {
+	if err := cbor.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	if err := t.Valid(); err != nil {
+		return fmt.Errorf("CBOR decoding failed: %w", err)
+	}
+
+	return nil
+}
+
+// ExtraData returns the qualifying data bound to the quote
+func (t *TPMQuote) ExtraData() ([]byte, error) {
+	attest, err := tpm2.Unmarshal[tpm2.TPMSAttest](t.Quote)
+	if err != nil {
+		return nil, fmt.Errorf("TPMS_ATTEST decoding failed: %w", err)
+	}
+
+	return attest.ExtraData.Buffer, nil
+}
+
+// Verify checks that the quote is signed by the AK, that the AK certificate,
+// if any, certifies the AK, and that the quoted PCR digest matches the PCR
+// values. The AK certificate is not validated against any trust anchor.
+func (t *TPMQuote) Verify() error {
+	akPublic, err := tpm2.Unmarshal[tpm2.TPMTPublic](t.AKPublic)
+	if err != nil {
+		return fmt.Errorf("AK public area decoding failed: %w", err)
+	}
+
+	ak, err := tpm2.Pub(*akPublic)
+	if err != nil {
+		return fmt.Errorf("AK public area decoding failed: %w", err)
+	}
+
+	if len(t.AKCert) > 0 {
+		cert, err := x509.ParseCertificate(t.AKCert)
+		if err != nil {
+			return fmt.Errorf("AK certificate decoding failed: %w", err)
+		}
+
+		certified, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !certified.Equal(ak) {
+			return errors.New("AK certificate does not certify the AK")
+		}
+	}
+
+	sig, err := tpm2.Unmarshal[tpm2.TPMTSignature](t.Signature)
+	if err != nil {
+		return fmt.Errorf("TPMT_SIGNATURE decoding failed: %w", err)
+	}
+
+	sigHash, err := verifyTPMSignature(ak, sig, t.Quote)
+	if err != nil {
+		return fmt.Errorf("quote verification failed: %w", err)
+	}
+
+	return t.checkPCRDigest(sigHash)
+}
+
+// CheckPCRDigest checks that the quoted PCR digest matches the PCR values,
+// using the hash of the signature scheme of the quote. Unlike Verify, it does
+// not check the signature, so that it can tell whether PCRs were extended
+// between their reading and the quote.
+func (t *TPMQuote) CheckPCRDigest() error {
+	sig, err := tpm2.Unmarshal[tpm2.TPMTSignature](t.Signature)
+	if err != nil {
+		return fmt.Errorf("TPMT_SIGNATURE decoding failed: %w", err)
+	}
+
+	sigHash, err := signatureHash(sig)
+	if err != nil {
+		return err
+	}
+
+	return t.checkPCRDigest(sigHash)
+}
+
+// checkPCRDigest compares the quoted PCR digest with the digest of the PCR
+// values computed with h
+func (t *TPMQuote) checkPCRDigest(h crypto.Hash) error {
+	attest, err := tpm2.Unmarshal[tpm2.TPMSAttest](t.Quote)
+	if err != nil {
+		return fmt.Errorf("TPMS_ATTEST decoding failed: %w", err)
+	}
+
+	if attest.Type != tpm2.TPMSTAttestQuote {
+		return fmt.Errorf("unexpected TPMS_ATTEST type %#x", attest.Type)
+	}
+
+	info, err := attest.Attested.Quote()
+	if err != nil {
+		return fmt.Errorf("TPMS_QUOTE_INFO decoding failed: %w", err)
+	}
+
+	digest, err := t.pcrDigest(info.PCRSelect, h)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest, info.PCRDigest.Buffer) {
+		return errors.New("PCR values do not match the quoted PCR digest")
+	}
+
+	return nil
+}
+
+// pcrDigest computes the digest of the PCR values in the order of the PCR
+// selection, which must select exactly the PCRs of the PCR bank of t
+func (t *TPMQuote) pcrDigest(sel tpm2.TPMLPCRSelection, h crypto.Hash) ([]byte, error) {
+	if len(sel.PCRSelections) != 1 || sel.PCRSelections[0].Hash != TPMHashAlgs[t.HashAlg] {
+		return nil, fmt.Errorf("quoted PCR selection is not limited to the %s bank", t.HashAlg)
+	}
+
+	pcrs := PCRIndices(sel.PCRSelections[0].PCRSelect)
+	if len(pcrs) != len(t.PCRs) {
+		return nil, errors.New("PCR values do not match the quoted PCR selection")
+	}
+
+	d := h.New()
+	for _, pcr := range pcrs {
+		v, ok := t.PCRs[pcr]
+		if !ok {
+			return nil, fmt.Errorf("missing value of quoted PCR %d", pcr)
+		}
+		d.Write(v)
+	}
+
+	return d.Sum(nil), nil
+}
+
+// PCRIndices returns the PCRs selected by a PCR selection bitmap, in
+// ascending order
+func PCRIndices(bitmap []byte) []uint32 {
+	var pcrs []uint32
+	for i, b := range bitmap {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				pcrs = append(pcrs, uint32(i*8+bit))
+			}
+		}
+	}
+	return pcrs
+}
+
+// verifyTPMSignature checks sig over msg using key, and returns the hash of
+// the signature scheme
+func verifyTPMSignature(key crypto.PublicKey, sig *tpm2.TPMTSignature, msg []byte) (crypto.Hash, error) {
+	switch sig.SigAlg {
+	case tpm2.TPMAlgECDSA:
+		s, err := sig.Signature.ECDSA()
+		if err != nil {
+			return 0, err
+		}
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return 0, errors.New("ECDSA signature from a non-ECC AK")
+		}
+		h, digest, err := tpmDigest(s.Hash, msg)
+		if err != nil {
+			return 0, err
+		}
+		r := new(big.Int).SetBytes(s.SignatureR.Buffer)
+		ss := new(big.Int).SetBytes(s.SignatureS.Buffer)
+		if !ecdsa.Verify(pub, digest, r, ss) {
+			return 0, errors.New("invalid ECDSA signature")
+		}
+		return h, nil
+	case tpm2.TPMAlgRSASSA, tpm2.TPMAlgRSAPSS:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return 0, errors.New("RSA signature from a non-RSA AK")
+		}
+		var s *tpm2.TPMSSignatureRSA
+		var err error
+		if sig.SigAlg == tpm2.TPMAlgRSASSA {
+			s, err = sig.Signature.RSASSA()
+		} else {
+			s, err = sig.Signature.RSAPSS()
+		}
+		if err != nil {
+			return 0, err
+		}
+		h, digest, err := tpmDigest(s.Hash, msg)
+		if err != nil {
+			return 0, err
+		}
+		if sig.SigAlg == tpm2.TPMAlgRSASSA {
+			err = rsa.VerifyPKCS1v15(pub, h, digest, s.Sig.Buffer)
+		} else {
+			err = rsa.VerifyPSS(pub, h, digest, s.Sig.Buffer, nil)
+		}
+		if err != nil {
+			return 0, err
+		}
+		return h, nil
+	default:
+		return 0, fmt.Errorf("unsupported signature algorithm %#x", sig.SigAlg)
+	}
+}
+
+// signatureHash returns the hash of the signature scheme of sig
+func signatureHash(sig *tpm2.TPMTSignature) (crypto.Hash, error) {
+	var alg tpm2.TPMIAlgHash
+
+	switch sig.SigAlg {
+	case tpm2.TPMAlgECDSA:
+		s, err := sig.Signature.ECDSA()
+		if err != nil {
+			return 0, err
+		}
+		alg = s.Hash
+	case tpm2.TPMAlgRSASSA:
+		s, err := sig.Signature.RSASSA()
+		if err != nil {
+			return 0, err
+		}
+		alg = s.Hash
+	case tpm2.TPMAlgRSAPSS:
+		s, err := sig.Signature.RSAPSS()
+		if err != nil {
+			return 0, err
+		}
+		alg = s.Hash
+	default:
+		return 0, fmt.Errorf("unsupported signature algorithm %#x", sig.SigAlg)
+	}
+
+	return alg.Hash()
+}
+
+func tpmDigest(alg tpm2.TPMIAlgHash, msg []byte) (crypto.Hash, []byte, error) {
+	h, err := alg.Hash()
+	if err != nil {
+		return 0, nil, err
+	}
+	d := h.New()
+	d.Write(msg)
+	return h, d.Sum(nil), nil
+}
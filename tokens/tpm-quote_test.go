@@ -0,0 +1,218 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package tokens
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tpmNonce = []byte("qualifying data of the TPM quote")
+
+// newTestTPMQuote returns a quote of PCRs 0 and 7 of the SHA-256 bank, signed
+// in software the way a TPM would with an ECC P-256 AK
+func newTestTPMQuote(t *testing.T) (*TPMQuote, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pcr0 := sha256.Sum256([]byte("pcr0"))
+	pcr7 := sha256.Sum256([]byte("pcr7"))
+	pcrs := map[uint32]BinaryString{0: pcr0[:], 7: pcr7[:]}
+	pcrDigest := sha256.Sum256(append(append([]byte{}, pcrs[0]...), pcrs[7]...))
+
+	attest := tpm2.TPMSAttest{
+		Magic:     tpm2.TPMGeneratedValue,
+		Type:      tpm2.TPMSTAttestQuote,
+		ExtraData: tpm2.TPM2BData{Buffer: tpmNonce},
+		Attested: tpm2.NewTPMUAttest(tpm2.TPMSTAttestQuote, &tpm2.TPMSQuoteInfo{
+			PCRSelect: tpm2.TPMLPCRSelection{
+				PCRSelections: []tpm2.TPMSPCRSelection{
+					{Hash: tpm2.TPMAlgSHA256, PCRSelect: []byte{0x81, 0x00, 0x00}},
+				},
+			},
+			PCRDigest: tpm2.TPM2BDigest{Buffer: pcrDigest[:]},
+		}),
+	}
+	quote := tpm2.Marshal(attest)
+
+	digest := sha256.Sum256(quote)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	sig := tpm2.TPMTSignature{
+		SigAlg: tpm2.TPMAlgECDSA,
+		Signature: tpm2.NewTPMUSignature(tpm2.TPMAlgECDSA, &tpm2.TPMSSignatureECC{
+			Hash:       tpm2.TPMAlgSHA256,
+			SignatureR: tpm2.TPM2BECCParameter{Buffer: r.Bytes()},
+			SignatureS: tpm2.TPM2BECCParameter{Buffer: s.Bytes()},
+		}),
+	}
+
+	public := tpm2.TPMTPublic{
+		Type:    tpm2.TPMAlgECC,
+		NameAlg: tpm2.TPMAlgSHA256,
+		ObjectAttributes: tpm2.TPMAObject{
+			Restricted:  true,
+			SignEncrypt: true,
+		},
+		Parameters: tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+			Symmetric: tpm2.TPMTSymDefObject{Algorithm: tpm2.TPMAlgNull},
+			Scheme: tpm2.TPMTECCScheme{
+				Scheme: tpm2.TPMAlgECDSA,
+				Details: tpm2.NewTPMUAsymScheme(tpm2.TPMAlgECDSA, &tpm2.TPMSSigSchemeECDSA{
+					HashAlg: tpm2.TPMAlgSHA256,
+				}),
+			},
+			CurveID: tpm2.TPMECCNistP256,
+			KDF:     tpm2.TPMTKDFScheme{Scheme: tpm2.TPMAlgNull},
+		}),
+		Unique: tpm2.NewTPMUPublicID(tpm2.TPMAlgECC, &tpm2.TPMSECCPoint{
+			X: tpm2.TPM2BECCParameter{Buffer: key.X.FillBytes(make([]byte, 32))},
+			Y: tpm2.TPM2BECCParameter{Buffer: key.Y.FillBytes(make([]byte, 32))},
+		}),
+	}
+
+	return &TPMQuote{
+		Quote:     quote,
+		Signature: tpm2.Marshal(sig),
+		HashAlg:   "sha256",
+		PCRs:      pcrs,
+		AKPublic:  tpm2.Marshal(public),
+	}, key
+}
+
+func newTestAKCert(t *testing.T, ak *ecdsa.PublicKey) []byte {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test AK"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, ak, caKey)
+	require.NoError(t, err)
+
+	return der
+}
+
+func Test_TPMQuote_Valid_Fail_MandatoryField(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.AKPublic = nil
+
+	assert.EqualError(t, q.Valid(), `missing mandatory field "ak_public"`)
+}
+
+func Test_TPMQuote_Valid_Fail_HashAlg(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.HashAlg = "md5"
+
+	assert.EqualError(t, q.Valid(), `unsupported "hash_alg" "md5"`)
+}
+
+func Test_TPMQuote_JSON_SerDes_Pass(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.EventLog = []byte("event log")
+
+	encoded, err := q.ToJSON()
+	require.NoError(t, err)
+
+	decoded := &TPMQuote{}
+	require.NoError(t, decoded.FromJSON(encoded))
+	assert.Equal(t, q, decoded)
+}
+
+func Test_TPMQuote_CBOR_SerDes_Pass(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+
+	encoded, err := q.ToCBOR()
+	require.NoError(t, err)
+
+	decoded := &TPMQuote{}
+	require.NoError(t, decoded.FromCBOR(encoded))
+	assert.Equal(t, q, decoded)
+}
+
+func Test_TPMQuote_ExtraData(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+
+	extraData, err := q.ExtraData()
+	require.NoError(t, err)
+	assert.Equal(t, tpmNonce, extraData)
+}
+
+func Test_TPMQuote_Verify_Pass(t *testing.T) {
+	q, key := newTestTPMQuote(t)
+	q.AKCert = newTestAKCert(t, &key.PublicKey)
+
+	assert.NoError(t, q.Verify())
+}
+
+func Test_TPMQuote_Verify_Fail_PCRValue(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.PCRs[7] = make([]byte, sha256.Size)
+
+	assert.EqualError(t, q.Verify(), "PCR values do not match the quoted PCR digest")
+}
+
+func Test_TPMQuote_Verify_Fail_MissingPCR(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	delete(q.PCRs, 7)
+	q.PCRs[1] = make([]byte, sha256.Size)
+
+	assert.EqualError(t, q.Verify(), "missing value of quoted PCR 7")
+}
+
+func Test_TPMQuote_Verify_Fail_Bank(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.HashAlg = "sha1"
+
+	assert.EqualError(t, q.Verify(), "quoted PCR selection is not limited to the sha1 bank")
+}
+
+func Test_TPMQuote_Verify_Fail_Signature(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	q.Quote[len(q.Quote)-1] ^= 0xff
+
+	assert.EqualError(t, q.Verify(), "quote verification failed: invalid ECDSA signature")
+}
+
+func Test_TPMQuote_Verify_Fail_AKCert(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	q.AKCert = newTestAKCert(t, &other.PublicKey)
+
+	assert.EqualError(t, q.Verify(), "AK certificate does not certify the AK")
+}
+
+func Test_TPMQuote_CheckPCRDigest(t *testing.T) {
+	q, _ := newTestTPMQuote(t)
+	assert.NoError(t, q.CheckPCRDigest())
+
+	// the signature is not checked
+	q.Signature[len(q.Signature)-1] ^= 0xff
+	assert.NoError(t, q.CheckPCRDigest())
+	assert.Error(t, q.Verify())
+
+	q.PCRs[7] = make([]byte, sha256.Size)
+	assert.EqualError(t, q.CheckPCRDigest(), "PCR values do not match the quoted PCR digest")
+}
+
+func Test_PCRIndices(t *testing.T) {
+	assert.Equal(t, []uint32{0, 7, 8, 23}, PCRIndices([]byte{0x81, 0x01, 0x80}))
+	assert.Empty(t, PCRIndices([]byte{0, 0, 0}))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fxamacker/cbor/v2"
 )
@@ -14,6 +15,13 @@ const (
 	TSMReportMediaTypeJSON = "application/vnd.veraison.tsm-report+json"
 )
 
+// Names of the configfs-TSM providers with a typed outblob decoder
+const (
+	TSMProviderSEVSNP = "sev_guest"
+	TSMProviderTDX    = "tdx_guest"
+	TSMProviderCCA    = "arm_cca_guest"
+)
+
 // BinaryString is base64url (§5 of RFC4648) without padding.
 type BinaryString []byte
 
@@ -111,3 +119,36 @@ func (t *TSMReport) FromCBOR(data []byte) error {
 
 	return nil
 }
+
+// ProviderName returns the provider without the trailing newline reported by
+// configfs-TSM
+func (t *TSMReport) ProviderName() string {
+	return strings.TrimSpace(t.Provider)
+}
+
+// ReportData returns the inblob bound to the outblob, i.e., the REPORT_DATA of
+// SEV-SNP reports and TDX quotes, or the realm challenge of CCA tokens
+func (t *TSMReport) ReportData() ([]byte, error) {
+	switch t.ProviderName() {
+	case TSMProviderSEVSNP:
+		r, err := ParseSEVSNPReport(t.OutBlob)
+		if err != nil {
+			return nil, err
+		}
+		return r.ReportData, nil
+	case TSMProviderTDX:
+		q, err := ParseTDXQuote(t.OutBlob)
+		if err != nil {
+			return nil, err
+		}
+		return q.Body.ReportData, nil
+	case TSMProviderCCA:
+		c, err := ParseCCAToken(t.OutBlob)
+		if err != nil {
+			return nil, err
+		}
+		return c.Realm.Challenge, nil
+	default:
+		return nil, fmt.Errorf("unsupported TSM provider %q", t.ProviderName())
+	}
+}
@@ -4,6 +4,7 @@ package tokens
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
 )
@@ -77,3 +78,42 @@ func Test_TSMReport_CBOR_SerDes_Pass(t *testing.T) {
 
 	assert.True(t, reflect.DeepEqual(report, decodedReport))
 }
+
+func Test_TSMReport_ReportData_Pass(t *testing.T) {
+	sevSNP := testSEVSNPReport()
+	tdx := testTDXQuote(4, 0, testTDXReportBody(tdxReportBody10Size), nil)
+	cca := testCCAReport(t)
+
+	tests := []struct {
+		name     string
+		report   *TSMReport
+		expected []byte
+	}{
+		{"SEV-SNP", &TSMReport{Provider: "sev_guest\n", OutBlob: sevSNP}, sevSNP[0x50:0x90]},
+		{"TDX", &TSMReport{Provider: "tdx_guest\n", OutBlob: tdx}, tdx[48+520 : 48+584]},
+		{"CCA", cca, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportData, err := tt.report.ReportData()
+			require.NoError(t, err)
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, reportData)
+			} else {
+				assert.Len(t, reportData, 64)
+			}
+		})
+	}
+}
+
+func Test_TSMReport_ReportData_Fail(t *testing.T) {
+	report := &TSMReport{Provider: "fake\n", OutBlob: outblob}
+
+	_, err := report.ReportData()
+	assert.EqualError(t, err, `unsupported TSM provider "fake"`)
+
+	report.Provider = "tdx_guest"
+	_, err = report.ReportData()
+	assert.EqualError(t, err, "TDX quote too short: 12 bytes")
+}
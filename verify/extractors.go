@@ -0,0 +1,450 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/veraison/ratsd/tokens"
+)
+
+// NonceExtractor returns the nonces bound to a piece of evidence. Evidence
+// formats that carry a single nonce return a slice with one element.
+// Extractors only parse the evidence: they do not verify its signatures, and
+// the nonces echoed in logs, e.g., IMA logs, event logs or CELs, are not
+// integrity-protected, so that such logs are only as fresh as the evidence
+// they are appraised against.
+type NonceExtractor func(evidence []byte) ([][]byte, error)
+
+var extractors = make(map[string]NonceExtractor)
+
+func init() {
+	if err := RegisterNonceExtractor(tokens.TSMReportMediaTypeJSON, tsmReportJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.TSMReportMediaTypeCBOR, tsmReportCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.MockEATMediaType, eatNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.PSATokenMediaType, psaNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.TPMQuoteMediaTypeJSON, tpmQuoteJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.TPMQuoteMediaTypeCBOR, tpmQuoteCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.IMALogMediaTypeJSON, imaLogJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.IMALogMediaTypeCBOR, imaLogCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.EventLogMediaTypeJSON, eventLogJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.EventLogMediaTypeCBOR, eventLogCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.NvidiaGPUEvidenceMediaTypeJSON, nvidiaGPUJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.NvidiaGPUEvidenceMediaTypeCBOR, nvidiaGPUCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.DICEChainMediaTypeJSON, diceChainJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.DICEChainMediaTypeCBOR, diceChainCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.SPDMEvidenceMediaTypeJSON, spdmJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.SPDMEvidenceMediaTypeCBOR, spdmCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.RatsdSelfMediaTypeJSON, ratsdSelfJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.RatsdSelfMediaTypeCBOR, ratsdSelfCBORNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.CELMediaTypeJSON, celJSONNonces); err != nil {
+		panic(err)
+	}
+
+	if err := RegisterNonceExtractor(tokens.CELMediaTypeCBOR, celCBORNonces); err != nil {
+		panic(err)
+	}
+
+	// native formats of the TSM providers
+	nativeExtractors := map[string]string{
+		tokens.SEVSNPReportMediaType: tokens.TSMProviderSEVSNP,
+		tokens.TDXQuoteMediaType:     tokens.TSMProviderTDX,
+		tokens.CCATokenMediaType:     tokens.TSMProviderCCA,
+	}
+	for mediaType, provider := range nativeExtractors {
+		if err := RegisterNonceExtractor(mediaType, outblobNonces(provider)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNonceExtractor makes extractor the default nonce extractor for
+// evidence of the given media type.
+func RegisterNonceExtractor(mediaType string, extractor NonceExtractor) error {
+	if mediaType == "" {
+		return fmt.Errorf("empty media type for nonce extractor")
+	}
+
+	if extractor == nil {
+		return fmt.Errorf("nil nonce extractor for %q", mediaType)
+	}
+
+	if _, ok := extractors[mediaType]; ok {
+		return fmt.Errorf("nonce extractor for %q already registered", mediaType)
+	}
+
+	extractors[mediaType] = extractor
+	return nil
+}
+
+func tsmReportJSONNonces(evidence []byte) ([][]byte, error) {
+	report := &tokens.TSMReport{}
+	if err := report.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return tsmReportNonces(report)
+}
+
+func tsmReportCBORNonces(evidence []byte) ([][]byte, error) {
+	report := &tokens.TSMReport{}
+	if err := report.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return tsmReportNonces(report)
+}
+
+// tsmReportNonces returns the inblob that was written to configfs-TSM when
+// the report was generated, as recorded by the TSM provider in the outblob.
+func tsmReportNonces(report *tokens.TSMReport) ([][]byte, error) {
+	reportData, err := report.ReportData()
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]byte{reportData}, nil
+}
+
+// outblobNonces returns an extractor for the bare outblob of the given TSM
+// provider
+func outblobNonces(provider string) NonceExtractor {
+	return func(evidence []byte) ([][]byte, error) {
+		return tsmReportNonces(&tokens.TSMReport{Provider: provider, OutBlob: evidence})
+	}
+}
+
+// eatNonces returns the nonce claim of an EAT
+func eatNonces(evidence []byte) ([][]byte, error) {
+	t, err := tokens.ParseEAT(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t.Claims.Nonce) == 0 {
+		return nil, fmt.Errorf("no nonce in EAT")
+	}
+
+	return [][]byte{t.Claims.Nonce}, nil
+}
+
+func psaNonces(evidence []byte) ([][]byte, error) {
+	t, err := tokens.ParsePSAToken(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]byte{t.Claims.Nonce}, nil
+}
+
+func tpmQuoteJSONNonces(evidence []byte) ([][]byte, error) {
+	q := &tokens.TPMQuote{}
+	if err := q.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return tpmQuoteNonces(q)
+}
+
+func tpmQuoteCBORNonces(evidence []byte) ([][]byte, error) {
+	q := &tokens.TPMQuote{}
+	if err := q.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return tpmQuoteNonces(q)
+}
+
+// tpmQuoteNonces returns the qualifying data of a TPM quote
+func tpmQuoteNonces(q *tokens.TPMQuote) ([][]byte, error) {
+	extraData, err := q.ExtraData()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extraData) == 0 {
+		return nil, fmt.Errorf("no qualifying data in TPM quote")
+	}
+
+	return [][]byte{extraData}, nil
+}
+
+// imaLogJSONNonces returns the nonce echoed in an IMA log
+func imaLogJSONNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.IMALog{}
+	if err := l.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
+
+// imaLogCBORNonces is the CBOR counterpart of imaLogJSONNonces
+func imaLogCBORNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.IMALog{}
+	if err := l.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
+
+// eventLogJSONNonces returns the nonce echoed in a measured-boot event log
+func eventLogJSONNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.EventLog{}
+	if err := l.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
+
+// eventLogCBORNonces is the CBOR counterpart of eventLogJSONNonces
+func eventLogCBORNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.EventLog{}
+	if err := l.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
+
+// nvidiaGPUJSONNonces returns the nonce shared by all the GPUs
+func nvidiaGPUJSONNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.NvidiaGPUEvidence{}
+	if err := e.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return nvidiaGPUNonces(e)
+}
+
+// nvidiaGPUCBORNonces is the CBOR counterpart of nvidiaGPUJSONNonces
+func nvidiaGPUCBORNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.NvidiaGPUEvidence{}
+	if err := e.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return nvidiaGPUNonces(e)
+}
+
+func nvidiaGPUNonces(e *tokens.NvidiaGPUEvidence) ([][]byte, error) {
+	nonce := (*e)[0].Nonce
+	for i, g := range (*e)[1:] {
+		if !bytes.Equal(g.Nonce, nonce) {
+			return nil, fmt.Errorf("nonce of GPU %d differs from that of GPU 0", i+1)
+		}
+	}
+
+	return [][]byte{nonce}, nil
+}
+
+// diceChainJSONNonces returns the nonce of a DICE chain
+func diceChainJSONNonces(evidence []byte) ([][]byte, error) {
+	c := &tokens.DICEChain{}
+	if err := c.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{c.Nonce}, nil
+}
+
+// diceChainCBORNonces is the CBOR counterpart of diceChainJSONNonces
+func diceChainCBORNonces(evidence []byte) ([][]byte, error) {
+	c := &tokens.DICEChain{}
+	if err := c.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{c.Nonce}, nil
+}
+
+// spdmJSONNonces returns the nonce shared by the devices of SPDM evidence
+func spdmJSONNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.SPDMEvidence{}
+	if err := e.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return spdmNonces(e)
+}
+
+// spdmCBORNonces is the CBOR counterpart of spdmJSONNonces
+func spdmCBORNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.SPDMEvidence{}
+	if err := e.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return spdmNonces(e)
+}
+
+func spdmNonces(e *tokens.SPDMEvidence) ([][]byte, error) {
+	for _, d := range e.Devices {
+		tr, err := d.ParseTranscript()
+		if err != nil {
+			return nil, fmt.Errorf("SPDM device %s: %w", d.Name, err)
+		}
+
+		if !bytes.Equal(tr.Nonce, e.Nonce) {
+			return nil, fmt.Errorf("nonce of SPDM device %s differs from that of the evidence", d.Name)
+		}
+	}
+
+	return [][]byte{e.Nonce}, nil
+}
+
+// FakeTSMReportExtractors returns nonce extractors for TSM reports of the
+// configfs-TSM fake provider, such as those of the mock-tsm attester. Fake
+// reports are not backed by any TEE, so that they are only accepted by callers
+// that pass these extractors in Config.NonceExtractors, e.g., for testing.
+func FakeTSMReportExtractors() map[string]NonceExtractor {
+	return map[string]NonceExtractor{
+		tokens.TSMReportMediaTypeJSON: func(evidence []byte) ([][]byte, error) {
+			report := &tokens.TSMReport{}
+			if err := report.FromJSON(evidence); err != nil {
+				return nil, err
+			}
+
+			return fakeTSMReportNonces(report)
+		},
+		tokens.TSMReportMediaTypeCBOR: func(evidence []byte) ([][]byte, error) {
+			report := &tokens.TSMReport{}
+			if err := report.FromCBOR(evidence); err != nil {
+				return nil, err
+			}
+
+			return fakeTSMReportNonces(report)
+		},
+	}
+}
+
+// fakeTSMReportNonces returns the nonces of fake reports, and those of real
+// ones as tsmReportNonces does
+func fakeTSMReportNonces(report *tokens.TSMReport) ([][]byte, error) {
+	if report.ProviderName() != "fake" {
+		return tsmReportNonces(report)
+	}
+
+	return fakeReportNonces(report.OutBlob)
+}
+
+// fakeReportNonces parses the outblob of the configfs-TSM fake provider, which
+// records the inblob as a hex-encoded "inblob:" line.
+func fakeReportNonces(outblob []byte) ([][]byte, error) {
+	s := bufio.NewScanner(bytes.NewReader(outblob))
+	for s.Scan() {
+		v, ok := strings.CutPrefix(s.Text(), "inblob: ")
+		if !ok {
+			continue
+		}
+
+		nonce, err := hex.DecodeString(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid inblob: %w", err)
+		}
+
+		return [][]byte{nonce}, nil
+	}
+
+	return nil, fmt.Errorf("no inblob in fake TSM report")
+}
+
+// ratsdSelfJSONNonces returns the nonce of ratsd-self evidence
+func ratsdSelfJSONNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.RatsdSelfEvidence{}
+	if err := e.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{e.Nonce}, nil
+}
+
+// ratsdSelfCBORNonces is the CBOR counterpart of ratsdSelfJSONNonces
+func ratsdSelfCBORNonces(evidence []byte) ([][]byte, error) {
+	e := &tokens.RatsdSelfEvidence{}
+	if err := e.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{e.Nonce}, nil
+}
+
+// celJSONNonces returns the nonce echoed in the CEL of the plugin measurements
+func celJSONNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.CEL{}
+	if err := l.FromJSON(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
+
+// celCBORNonces is the CBOR counterpart of celJSONNonces
+func celCBORNonces(evidence []byte) ([][]byte, error) {
+	l := &tokens.CEL{}
+	if err := l.FromCBOR(evidence); err != nil {
+		return nil, err
+	}
+
+	return [][]byte{l.Nonce}, nil
+}
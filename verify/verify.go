@@ -0,0 +1,362 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verify implements the relying-party checks for RATSD tokens: the
+// signature and x5chain of v2 tokens, the EAT profile, and the binding of the
+// relying party's nonce to the evidence of each sub-attester.
+package verify
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/veraison/cmw"
+	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
+	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var (
+	// ErrUnsigned is returned for a v2 token without x5chain, unless
+	// unsigned tokens are allowed.
+	ErrUnsigned = errors.New("token is not signed")
+	// ErrNonceMismatch is returned when a token or a piece of evidence is
+	// not bound to the expected nonce.
+	ErrNonceMismatch = errors.New("nonce mismatch")
+	// ErrNoNonceExtractor is returned when no nonce extractor is available
+	// for any record of a sub-attester.
+	ErrNoNonceExtractor = errors.New("no nonce extractor")
+)
+
+// Config holds the trust anchors and policy used to verify a token.
+type Config struct {
+	// Roots are the trust anchors for the x5chain of v2 tokens.
+	Roots *x509.CertPool
+	// CurrentTime is the time at which certificates must be valid. The zero
+	// value means the current time.
+	CurrentTime time.Time
+	// AllowUnsigned accepts v2 tokens without x5chain, such as those
+	// produced by ratsd when token signing is not configured. Their
+	// signature is not checked, but the nonce binding still is.
+	AllowUnsigned bool
+	// NonceExtractors maps media types to nonce extractors. They take
+	// precedence over the extractors registered with
+	// RegisterNonceExtractor.
+	NonceExtractors map[string]NonceExtractor
+}
+
+func (c Config) extractor(mediaType string) NonceExtractor {
+	if e, ok := c.NonceExtractors[mediaType]; ok {
+		return e
+	}
+
+	return extractors[mediaType]
+}
+
+// Result describes a successfully verified token.
+type Result struct {
+	// Profile is the EAT profile of the token.
+	Profile string
+	// Chain is the verified certificate chain of a signed v2 token, from
+	// the signing certificate to the trust anchor.
+	Chain []*x509.Certificate
+	// Nonces maps each sub-attester to the adjusted nonce found in its
+	// evidence.
+	Nonces map[string][]byte
+	// Unbound lists the sub-attesters whose output is not bound to the
+	// nonce, e.g., static endorsements or reference values. It must not be
+	// taken as fresh evidence.
+	Unbound []string
+}
+
+// Legacy verifies a legacy RATSD token against the nonce sent to ratsd.
+// Legacy tokens are not signed.
+func Legacy(token, nonce []byte, cfg Config) (*Result, error) {
+	e := ratsdtoken.NewEvidence()
+	if err := e.UnmarshalJSON(token); err != nil {
+		return nil, fmt.Errorf("decoding legacy token: %w", err)
+	}
+
+	claims, err := e.GetClaims()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := claims.GetEatProfile().Get()
+	if err != nil || profile != ratsdtoken.LegacyProfile {
+		return nil, fmt.Errorf("unexpected eat_profile %q, want %q", profile, ratsdtoken.LegacyProfile)
+	}
+
+	found := false
+	eatNonce := claims.GetEatNonce()
+	for i := 0; i < eatNonce.Len(); i++ {
+		if bytes.Equal(eatNonce.GetI(i), nonce) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("eat_nonce: %w", ErrNonceMismatch)
+	}
+
+	nonces, unbound, err := checkBindings(*claims.GetCMW(), nonce,
+		claims.GetNonceAdjustFn(), claims.GetNonceAdjustMap(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Profile: profile, Nonces: nonces, Unbound: unbound}, nil
+}
+
+// V2 verifies a v2 RATSD token against the nonce sent to ratsd. The
+// signature is verified with the key of the signing certificate, whose chain
+// must lead to one of the configured roots.
+func V2(token, nonce []byte, cfg Config) (*Result, error) {
+	e := ratsdtokenv2.NewEvidence()
+	if err := e.FromCBOR(token); err != nil {
+		return nil, fmt.Errorf("decoding v2 token: %w", err)
+	}
+
+	result := &Result{}
+
+	if e.SigningCert == nil {
+		if !cfg.AllowUnsigned {
+			return nil, ErrUnsigned
+		}
+	} else {
+		chain, err := e.VerifyWithRoots(cfg.Roots, ratsdtokenv2.VerifyOptions{
+			CurrentTime: cfg.CurrentTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Chain = chain
+	}
+
+	claims, err := e.GetClaims()
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.GetEatProfile() != ratsdtokenv2.Profile {
+		return nil, fmt.Errorf("unexpected eat_profile %q, want %q",
+			claims.GetEatProfile(), ratsdtokenv2.Profile)
+	}
+	result.Profile = claims.GetEatProfile()
+
+	if !bytes.Equal(claims.GetEatNonce(), nonce) {
+		return nil, fmt.Errorf("eat_nonce: %w", ErrNonceMismatch)
+	}
+
+	collection, err := e.GetCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	result.Nonces, result.Unbound, err = checkBindings(collection, nonce,
+		claims.GetNonceAdjustFn(), claims.GetNonceAdjustMap(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkBindings confirms that the evidence of each sub-attester in collection
+// is bound to the nonce derived from nonce using function and the size in
+// sizes. Without a nonce adjustment function, the evidence must be bound to
+// nonce itself. Sub-attesters left out of sizes whose records are all marked
+// as something other than evidence are not bound to the nonce, and are
+// returned separately.
+func checkBindings(
+	collection cmw.CMW, nonce []byte, function string, sizes map[string]uint, cfg Config,
+) (map[string][]byte, []string, error) {
+	meta, err := collection.GetCollectionMeta()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonces := make(map[string][]byte, len(meta))
+	var unbound []string
+
+	for _, m := range meta {
+		key, ok := m.Key.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected collection key %v", m.Key)
+		}
+
+		item, err := collection.GetCollectionItem(key)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		size, ok := sizes[key]
+		if !ok && isUnbound(item) {
+			unbound = append(unbound, key)
+			continue
+		}
+
+		expected := nonce
+		if function != "" {
+			if !ok {
+				return nil, nil, fmt.Errorf("sub-attester %q missing from nonce_adjust_map", key)
+			}
+
+			expected, err = ratsdtoken.AdjustNonce(nonce, uint32(size), function)
+			if err != nil {
+				return nil, nil, fmt.Errorf("sub-attester %q: %w", key, err)
+			}
+		}
+
+		if err := containsNonce(item, expected, cfg); err != nil {
+			return nil, nil, fmt.Errorf("sub-attester %q: %w", key, err)
+		}
+
+		nonces[key] = expected
+	}
+
+	for key := range sizes {
+		if _, ok := nonces[key]; !ok {
+			return nil, nil, fmt.Errorf("nonce_adjust_map entry %q has no evidence", key)
+		}
+	}
+
+	sort.Strings(unbound)
+
+	return nonces, unbound, nil
+}
+
+// isUnbound reports whether all the records in c carry a CMW indicator that
+// excludes evidence, such as the endorsements and reference values returned
+// by the static attester.
+func isUnbound(c *cmw.CMW) bool {
+	switch c.GetKind() {
+	case cmw.KindMonad:
+		ind := c.GetMonadIndicator()
+		return !ind.Empty() && !ind.Has(cmw.Evidence)
+	case cmw.KindCollection:
+		meta, err := c.GetCollectionMeta()
+		if err != nil || len(meta) == 0 {
+			return false
+		}
+		for _, m := range meta {
+			item, err := c.GetCollectionItem(m.Key)
+			if err != nil || !isUnbound(item) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// containsNonce confirms that expected is bound to every record in c that
+// has a nonce extractor. Records without one, and records that are not
+// evidence, e.g., endorsements accompanying the evidence, are skipped. The
+// anchor of ratsd-self evidence is bound to the evidence rather than to
+// expected, see checkRatsdSelfAnchor.
+func containsNonce(c *cmw.CMW, expected []byte, cfg Config) error {
+	var (
+		records []*cmw.CMW
+		unknown []string
+		checked bool
+		collect func(c *cmw.CMW) error
+	)
+
+	collect = func(c *cmw.CMW) error {
+		switch c.GetKind() {
+		case cmw.KindMonad:
+			records = append(records, c)
+		case cmw.KindCollection:
+			if ct, _ := c.GetCollectionType(); ct == tokens.RatsdSelfCollectionType {
+				evidence, err := checkRatsdSelfAnchor(c, cfg)
+				if err != nil {
+					return err
+				}
+				records = append(records, evidence)
+				return nil
+			}
+
+			meta, err := c.GetCollectionMeta()
+			if err != nil {
+				return err
+			}
+			for _, m := range meta {
+				item, err := c.GetCollectionItem(m.Key)
+				if err != nil {
+					return err
+				}
+				if err := collect(item); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported CMW kind %s", c.GetKind())
+		}
+		return nil
+	}
+
+	if err := collect(c); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if ind := r.GetMonadIndicator(); ind != 0 && !ind.Has(cmw.Evidence) {
+			continue
+		}
+
+		extract := cfg.extractor(r.GetMonadType())
+		if extract == nil {
+			unknown = append(unknown, r.GetMonadType())
+			continue
+		}
+		checked = true
+
+		nonces, err := extract(r.GetMonadValue())
+		if err != nil {
+			return fmt.Errorf("extracting nonce from %s: %w", r.GetMonadType(), err)
+		}
+
+		if !slices.ContainsFunc(nonces, func(n []byte) bool { return bytes.Equal(n, expected) }) {
+			return fmt.Errorf("%s: %w", r.GetMonadType(), ErrNonceMismatch)
+		}
+	}
+
+	if !checked {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w for %s", ErrNoNonceExtractor, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// checkRatsdSelfAnchor confirms that the anchor of ratsd-self evidence is
+// bound to the evidence record, whose tokens.RatsdSelfAnchorData must be the
+// report data of the anchor. It returns the evidence record.
+func checkRatsdSelfAnchor(c *cmw.CMW, cfg Config) (*cmw.CMW, error) {
+	evidence, err := c.GetCollectionItem("evidence")
+	if err != nil {
+		return nil, fmt.Errorf("ratsd-self evidence: %w", err)
+	}
+	if evidence.GetKind() != cmw.KindMonad {
+		return nil, fmt.Errorf("ratsd-self evidence is not a record")
+	}
+
+	anchor, err := c.GetCollectionItem("anchor")
+	if err != nil {
+		return nil, fmt.Errorf("ratsd-self anchor: %w", err)
+	}
+
+	if err := containsNonce(anchor, tokens.RatsdSelfAnchorData(evidence.GetMonadValue()), cfg); err != nil {
+		return nil, fmt.Errorf("ratsd-self anchor: %w", err)
+	}
+
+	return evidence, nil
+}
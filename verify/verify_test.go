@@ -0,0 +1,834 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package verify
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/cmw"
+	"github.com/veraison/go-cose"
+	"github.com/veraison/ratsd/attesters/mockeat"
+	"github.com/veraison/ratsd/attesters/mocktsm"
+	"github.com/veraison/ratsd/attesters/psa"
+	"github.com/veraison/ratsd/attesters/spdm"
+	"github.com/veraison/ratsd/proto/compositor"
+	ratsdtoken "github.com/veraison/ratsd/ratsd-token"
+	ratsdtokenv2 "github.com/veraison/ratsd/ratsd-token-v2"
+	"github.com/veraison/ratsd/self"
+	"github.com/veraison/ratsd/tokens"
+)
+
+var testNonce = []byte("MIDBNH28iioisjPyxxxxxxxxxxxxxxxxMIDBNH28iioisjPyxxxxxxxxxxxxxxxx")
+
+type testPKI struct {
+	roots        *x509.CertPool
+	leaf         []byte
+	intermediate []byte
+	key          crypto.Signer
+}
+
+func newTestCert(
+	t *testing.T, template, parent *x509.Certificate, pub crypto.PublicKey, priv crypto.Signer,
+) *x509.Certificate {
+	t.Helper()
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	root := newTestCert(t, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+
+	intKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	intTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	intermediate := newTestCert(t, intTemplate, root, intKey.Public(), rootKey)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "ratsd"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	leaf := newTestCert(t, leafTemplate, intermediate, leafKey.Public(), intKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	return testPKI{
+		roots:        roots,
+		leaf:         leaf.Raw,
+		intermediate: intermediate.Raw,
+		key:          leafKey,
+	}
+}
+
+func fakeTSMReport(t *testing.T, inblob []byte) []byte {
+	t.Helper()
+
+	report := &tokens.TSMReport{
+		Provider: "fake\n",
+		OutBlob:  []byte("privlevel: 0\ninblob: " + hex.EncodeToString(inblob)),
+	}
+
+	data, err := report.ToJSON()
+	require.NoError(t, err)
+
+	return data
+}
+
+func adjustedTestNonce(t *testing.T) []byte {
+	t.Helper()
+
+	adjusted, err := ratsdtoken.AdjustNonce(testNonce, 64, ratsdtoken.NonceAdjustFunctionShake256)
+	require.NoError(t, err)
+
+	return adjusted
+}
+
+func newTestV2Evidence(t *testing.T, report []byte) *ratsdtokenv2.Evidence {
+	t.Helper()
+
+	e := ratsdtokenv2.NewEvidence()
+	require.NoError(t, e.Claims.SetNonce(testNonce))
+	require.NoError(t, e.Claims.SetNonceAdjustFn(ratsdtokenv2.NonceAdjustFunctionShake256))
+	require.NoError(t, e.Claims.SetKeyandNonceSz("mock-tsm", 64))
+	require.NoError(t, e.SetToken("mock-tsm", tokens.TSMReportMediaTypeJSON, report, cmw.Evidence))
+
+	return e
+}
+
+func signTestV2Evidence(t *testing.T, e *ratsdtokenv2.Evidence, pki testPKI) []byte {
+	t.Helper()
+
+	require.NoError(t, e.AddSigningCert(pki.leaf))
+	require.NoError(t, e.AddIntermediateCerts(pki.intermediate))
+
+	signer, err := cose.NewSigner(cose.AlgorithmES256, pki.key)
+	require.NoError(t, err)
+
+	token, err := e.Sign(signer)
+	require.NoError(t, err)
+
+	return token
+}
+
+func newTestLegacyToken(t *testing.T, report []byte) []byte {
+	t.Helper()
+
+	e := ratsdtoken.NewEvidence()
+	require.NoError(t, e.Claims.SetNonce(testNonce))
+	require.NoError(t, e.Claims.SetNonceAdjustFn(ratsdtoken.NonceAdjustFunctionShake256))
+	require.NoError(t, e.Claims.SetKeyandNonceSz("mock-tsm", 64))
+
+	collection := cmw.NewCollection("tag:github.com,2025:veraison/ratsd/cmw")
+	require.NoError(t, collection.AddCollectionItem("mock-tsm",
+		cmw.NewMonad(tokens.TSMReportMediaTypeJSON, report)))
+	require.NoError(t, e.Claims.SetCMW(collection))
+
+	token, err := e.MarshalJSON()
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestV2(t *testing.T) {
+	pki := newTestPKI(t)
+	e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+	token := signTestV2Evidence(t, e, pki)
+
+	result, err := V2(token, testNonce, Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Equal(t, ratsdtokenv2.Profile, result.Profile)
+	require.Len(t, result.Chain, 3)
+	assert.Equal(t, "ratsd", result.Chain[0].Subject.CommonName)
+	assert.Equal(t, "root", result.Chain[2].Subject.CommonName)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustedTestNonce(t)}, result.Nonces)
+}
+
+func unsignedTestV2Token(t *testing.T, e *ratsdtokenv2.Evidence) []byte {
+	t.Helper()
+
+	// mirrors the placeholder signature of ratsd when signing is not configured
+	require.NoError(t, e.SetSignature([]byte{0}))
+
+	token, err := e.ToCBOR()
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestV2MockEAT(t *testing.T) {
+	adjusted, err := ratsdtoken.AdjustNonce(testNonce, 32, ratsdtoken.NonceAdjustFunctionShake256)
+	require.NoError(t, err)
+
+	out := mockeat.GetPlugin().GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.MockEATMediaType,
+		Nonce:       adjusted,
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	e := ratsdtokenv2.NewEvidence()
+	require.NoError(t, e.Claims.SetNonce(testNonce))
+	require.NoError(t, e.Claims.SetNonceAdjustFn(ratsdtokenv2.NonceAdjustFunctionShake256))
+	require.NoError(t, e.Claims.SetKeyandNonceSz("mock-eat", 32))
+	require.NoError(t, e.SetToken("mock-eat", tokens.MockEATMediaType, out.Evidence, cmw.Evidence))
+
+	pki := newTestPKI(t)
+	result, err := V2(signTestV2Evidence(t, e, pki), testNonce, Config{Roots: pki.roots})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mock-eat": adjusted}, result.Nonces)
+
+	eat, err := tokens.ParseEAT(out.Evidence)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(mockeat.TrustAnchor())
+	assert.NoError(t, eat.Verify(roots, time.Time{}))
+}
+
+func TestV2Unsigned(t *testing.T) {
+	token := unsignedTestV2Token(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))))
+
+	_, err := V2(token, testNonce, Config{})
+	assert.ErrorIs(t, err, ErrUnsigned)
+
+	result, err := V2(token, testNonce, Config{AllowUnsigned: true, NonceExtractors: FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Nil(t, result.Chain)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustedTestNonce(t)}, result.Nonces)
+}
+
+func TestV2Unbound(t *testing.T) {
+	e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+
+	artifacts := cmw.NewCollection("tag:github.com,2026:veraison/ratsd/cmw/static-artifacts")
+	require.NoError(t, artifacts.AddCollectionItem("corim",
+		cmw.NewMonad("application/rim+cbor", []byte("corim"), cmw.ReferenceValues|cmw.Endorsements)))
+	require.NoError(t, artifacts.AddCollectionItem("root",
+		cmw.NewMonad("application/pkix-cert", []byte("root"), cmw.TrustAnchors)))
+	require.NoError(t, e.SetCollectionItem("static", *artifacts))
+
+	result, err := V2(unsignedTestV2Token(t, e), testNonce,
+		Config{AllowUnsigned: true, NonceExtractors: FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustedTestNonce(t)}, result.Nonces)
+	assert.Equal(t, []string{"static"}, result.Unbound)
+}
+
+func TestV2NonEvidenceRecords(t *testing.T) {
+	e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+	records := cmw.NewCollection("tag:github.com,2026:veraison/ratsd/cmw/records")
+	require.NoError(t, records.AddCollectionItem("evidence",
+		cmw.NewMonad(tokens.TSMReportMediaTypeJSON, fakeTSMReport(t, adjustedTestNonce(t)), cmw.Evidence)))
+	// a report endorsing the evidence is not bound to the nonce
+	require.NoError(t, records.AddCollectionItem("endorsement",
+		cmw.NewMonad(tokens.TSMReportMediaTypeJSON, fakeTSMReport(t, []byte("stale")), cmw.Endorsements)))
+	require.NoError(t, e.SetCollectionItem("mock-tsm", *records))
+
+	result, err := V2(unsignedTestV2Token(t, e), testNonce,
+		Config{AllowUnsigned: true, NonceExtractors: FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustedTestNonce(t)}, result.Nonces)
+}
+
+func TestV2Fail(t *testing.T) {
+	pki := newTestPKI(t)
+	otherPKI := newTestPKI(t)
+
+	tests := []struct {
+		name     string
+		token    func(t *testing.T) []byte
+		nonce    []byte
+		cfg      Config
+		expected string
+		prefix   bool
+	}{
+		{
+			"untrusted root",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))), pki)
+			},
+			testNonce,
+			Config{Roots: otherPKI.roots},
+			"x5chain validation failed: certificate chain not trusted: x509: certificate signed by unknown authority",
+			true,
+		},
+		{
+			"expired certificate",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))), pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, CurrentTime: time.Now().Add(2 * time.Hour)},
+			"x5chain validation failed: certificate expired or not yet valid: x509: certificate has expired or is not yet valid",
+			true,
+		},
+		{
+			"no trust anchors",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))), pki)
+			},
+			testNonce,
+			Config{},
+			"no trust anchors configured",
+			false,
+		},
+		{
+			"wrong nonce",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))), pki)
+			},
+			[]byte("another nonce"),
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			"eat_nonce: nonce mismatch",
+			false,
+		},
+		{
+			"evidence bound to the unadjusted nonce",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, testNonce)), pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			`sub-attester "mock-tsm": application/vnd.veraison.tsm-report+json: nonce mismatch`,
+			false,
+		},
+		{
+			"fake report without opt-in",
+			func(t *testing.T) []byte {
+				return signTestV2Evidence(t, newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t))), pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots},
+			`sub-attester "mock-tsm": extracting nonce from application/vnd.veraison.tsm-report+json: unsupported TSM provider "fake"`,
+			false,
+		},
+		{
+			"one of several records bound to another nonce",
+			func(t *testing.T) []byte {
+				e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+				records := cmw.NewCollection("tag:github.com,2026:veraison/ratsd/cmw/records")
+				require.NoError(t, records.AddCollectionItem("bound",
+					cmw.NewMonad(tokens.TSMReportMediaTypeJSON, fakeTSMReport(t, adjustedTestNonce(t)), cmw.Evidence)))
+				require.NoError(t, records.AddCollectionItem("stale",
+					cmw.NewMonad(tokens.TSMReportMediaTypeJSON, fakeTSMReport(t, []byte("stale")), cmw.Evidence)))
+				require.NoError(t, e.SetCollectionItem("mock-tsm", *records))
+				return signTestV2Evidence(t, e, pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			`sub-attester "mock-tsm": application/vnd.veraison.tsm-report+json: nonce mismatch`,
+			false,
+		},
+		{
+			"missing nonce_adjust_map entry",
+			func(t *testing.T) []byte {
+				e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+				e.Claims.NonceAdjustMap = map[string]uint{"other": 64}
+				return signTestV2Evidence(t, e, pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			`sub-attester "mock-tsm" missing from nonce_adjust_map`,
+			false,
+		},
+		{
+			"unbound evidence",
+			func(t *testing.T) []byte {
+				e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+				require.NoError(t, e.SetToken("static", "application/rim+cbor", []byte("corim"),
+					cmw.Endorsements|cmw.Evidence))
+				return signTestV2Evidence(t, e, pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			`sub-attester "static" missing from nonce_adjust_map`,
+			false,
+		},
+		{
+			"no nonce extractor",
+			func(t *testing.T) []byte {
+				e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+				require.NoError(t, e.SetToken("mock-tsm", "application/vnd.example.evidence",
+					[]byte("evidence"), cmw.Evidence))
+				return signTestV2Evidence(t, e, pki)
+			},
+			testNonce,
+			Config{Roots: pki.roots, NonceExtractors: FakeTSMReportExtractors()},
+			`sub-attester "mock-tsm": no nonce extractor for application/vnd.example.evidence`,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := V2(tt.token(t), tt.nonce, tt.cfg)
+			if tt.prefix {
+				assert.ErrorContains(t, err, tt.expected)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestV2CustomNonceExtractor(t *testing.T) {
+	mediaType := "application/vnd.example.evidence"
+	e := newTestV2Evidence(t, fakeTSMReport(t, adjustedTestNonce(t)))
+	require.NoError(t, e.SetToken("mock-tsm", mediaType, adjustedTestNonce(t), cmw.Evidence))
+	token := unsignedTestV2Token(t, e)
+
+	cfg := Config{
+		AllowUnsigned: true,
+		NonceExtractors: map[string]NonceExtractor{
+			mediaType: func(evidence []byte) ([][]byte, error) {
+				return [][]byte{evidence}, nil
+			},
+		},
+	}
+
+	_, err := V2(token, testNonce, cfg)
+	assert.NoError(t, err)
+}
+
+func TestLegacy(t *testing.T) {
+	token := newTestLegacyToken(t, fakeTSMReport(t, adjustedTestNonce(t)))
+
+	result, err := Legacy(token, testNonce, Config{NonceExtractors: FakeTSMReportExtractors()})
+	require.NoError(t, err)
+	assert.Equal(t, ratsdtoken.LegacyProfile, result.Profile)
+	assert.Nil(t, result.Chain)
+	assert.Equal(t, map[string][]byte{"mock-tsm": adjustedTestNonce(t)}, result.Nonces)
+}
+
+func TestLegacyFail(t *testing.T) {
+	token := newTestLegacyToken(t, fakeTSMReport(t, adjustedTestNonce(t)))
+
+	cfg := Config{NonceExtractors: FakeTSMReportExtractors()}
+
+	_, err := Legacy(token, []byte("another nonce"), cfg)
+	assert.ErrorIs(t, err, ErrNonceMismatch)
+
+	_, err = Legacy(token, testNonce, Config{})
+	assert.EqualError(t, err, `sub-attester "mock-tsm": extracting nonce from `+
+		`application/vnd.veraison.tsm-report+json: unsupported TSM provider "fake"`)
+
+	token = newTestLegacyToken(t, fakeTSMReport(t, []byte("stale")))
+
+	_, err = Legacy(token, testNonce, cfg)
+	assert.EqualError(t, err, `sub-attester "mock-tsm": application/vnd.veraison.tsm-report+json: nonce mismatch`)
+
+	_, err = Legacy([]byte("{}"), testNonce, Config{})
+	assert.ErrorContains(t, err, "decoding legacy token")
+}
+
+func TestTSMReportNonces(t *testing.T) {
+	reportData := make([]byte, 64)
+	for i := range reportData {
+		reportData[i] = byte(i)
+	}
+
+	// version 2 SEV-SNP report
+	sevSNP := make([]byte, 0x4a0)
+	sevSNP[0] = 2
+	copy(sevSNP[0x50:], reportData)
+
+	// version 4 TDX quote with an empty signature
+	tdx := make([]byte, 48+584+4)
+	tdx[0], tdx[4] = 4, 0x81
+	copy(tdx[48+520:], reportData)
+
+	tests := []struct {
+		provider string
+		outblob  []byte
+		expected []byte
+		err      string
+	}{
+		{"sev_guest\n", sevSNP, reportData, ""},
+		{"tdx_guest\n", tdx, reportData, ""},
+		{"fake\n", []byte("privlevel: 0\ninblob: 0001"), []byte{0, 1}, ""},
+		{"sev_guest\n", []byte("short"), nil, "SEV-SNP report too short: 5 bytes, want 1184"},
+		{"fake\n", []byte("privlevel: 0\n"), nil, "no inblob in fake TSM report"},
+		{"arm-cca\n", []byte("token"), nil, `unsupported TSM provider "arm-cca"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			report := &tokens.TSMReport{Provider: tt.provider, OutBlob: tt.outblob}
+
+			nonces, err := fakeTSMReportNonces(report)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{tt.expected}, nonces)
+		})
+	}
+
+	// fake reports are only accepted with FakeTSMReportExtractors
+	_, err := Config{}.extractor(tokens.TSMReportMediaTypeJSON)(fakeTSMReport(t, reportData))
+	assert.EqualError(t, err, `unsupported TSM provider "fake"`)
+}
+
+func TestNativeTSMNonces(t *testing.T) {
+	reportData := bytes.Repeat([]byte{0xab}, 64)
+
+	sevSNP := make([]byte, 0x4a0)
+	sevSNP[0] = 2
+	copy(sevSNP[0x50:], reportData)
+
+	tdx := make([]byte, 48+584+4)
+	tdx[0], tdx[4] = 4, 0x81
+	copy(tdx[48+520:], reportData)
+
+	tests := []struct {
+		mediaType string
+		evidence  []byte
+	}{
+		{tokens.SEVSNPReportMediaType, sevSNP},
+		{tokens.TDXQuoteMediaType, tdx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			extract := Config{}.extractor(tt.mediaType)
+			require.NotNil(t, extract)
+
+			nonces, err := extract(tt.evidence)
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{reportData}, nonces)
+		})
+	}
+
+	_, err := Config{}.extractor(tokens.CCATokenMediaType)([]byte{0xa0})
+	assert.ErrorContains(t, err, "CCA token decoding failed")
+}
+
+func TestTPMQuoteNonces(t *testing.T) {
+	extraData := bytes.Repeat([]byte{0xcd}, 32)
+
+	attest := func(extraData []byte) []byte {
+		return tpm2.Marshal(tpm2.TPMSAttest{
+			Magic:     tpm2.TPMGeneratedValue,
+			Type:      tpm2.TPMSTAttestQuote,
+			ExtraData: tpm2.TPM2BData{Buffer: extraData},
+			Attested:  tpm2.NewTPMUAttest(tpm2.TPMSTAttestQuote, &tpm2.TPMSQuoteInfo{}),
+		})
+	}
+
+	// the signature is not verified when extracting the nonce
+	q := &tokens.TPMQuote{
+		Quote:     attest(extraData),
+		Signature: []byte{0},
+		HashAlg:   "sha256",
+		PCRs:      map[uint32]tokens.BinaryString{0: make([]byte, 32)},
+		AKPublic:  []byte{0},
+	}
+
+	jsonQuote, err := q.ToJSON()
+	require.NoError(t, err)
+	cborQuote, err := q.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.TPMQuoteMediaTypeJSON: jsonQuote,
+		tokens.TPMQuoteMediaTypeCBOR: cborQuote,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{extraData}, nonces, mediaType)
+	}
+
+	q.Quote = attest(nil)
+	_, err = tpmQuoteNonces(q)
+	assert.EqualError(t, err, "no qualifying data in TPM quote")
+
+	q.Quote = []byte("quote")
+	_, err = tpmQuoteNonces(q)
+	assert.ErrorContains(t, err, "TPMS_ATTEST decoding failed")
+}
+
+func TestIMALogNonces(t *testing.T) {
+	l := &tokens.IMALog{Nonce: []byte("nonce"), HashAlg: "sha1"}
+
+	jsonLog, err := l.ToJSON()
+	require.NoError(t, err)
+	cborLog, err := l.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.IMALogMediaTypeJSON: jsonLog,
+		tokens.IMALogMediaTypeCBOR: cborLog,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{[]byte("nonce")}, nonces, mediaType)
+	}
+}
+
+func TestEventLogNonces(t *testing.T) {
+	l := &tokens.EventLog{
+		Nonce:  []byte("nonce"),
+		Source: tokens.EventLogSourceTCG2,
+		Raw:    []byte("raw"),
+	}
+
+	jsonLog, err := l.ToJSON()
+	require.NoError(t, err)
+	cborLog, err := l.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.EventLogMediaTypeJSON: jsonLog,
+		tokens.EventLogMediaTypeCBOR: cborLog,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{[]byte("nonce")}, nonces, mediaType)
+	}
+}
+
+func TestNvidiaGPUNonces(t *testing.T) {
+	nonce := bytes.Repeat([]byte{0xab}, tokens.NvidiaGPUNonceSize)
+	e := &tokens.NvidiaGPUEvidence{
+		{Arch: tokens.NvidiaGPUArchHopper, Certificate: []byte("cert"), Evidence: []byte("evidence"), Nonce: nonce},
+		{Arch: tokens.NvidiaGPUArchHopper, Certificate: []byte("cert"), Evidence: []byte("evidence"), Nonce: nonce},
+	}
+
+	jsonEvidence, err := e.ToJSON()
+	require.NoError(t, err)
+	cborEvidence, err := e.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.NvidiaGPUEvidenceMediaTypeJSON: jsonEvidence,
+		tokens.NvidiaGPUEvidenceMediaTypeCBOR: cborEvidence,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{nonce}, nonces, mediaType)
+	}
+
+	(*e)[1].Nonce = bytes.Repeat([]byte{0xcd}, tokens.NvidiaGPUNonceSize)
+	_, err = nvidiaGPUNonces(e)
+	assert.EqualError(t, err, "nonce of GPU 1 differs from that of GPU 0")
+}
+
+func TestPSANonces(t *testing.T) {
+	b, err := psa.NewSoftwareBackend(filepath.Join("..", "attesters", "psa", "testdata", "iak.pem"))
+	require.NoError(t, err)
+
+	nonce := bytes.Repeat([]byte{0xab}, 48)
+	token, err := b.Token(nonce)
+	require.NoError(t, err)
+
+	nonces, err := Config{}.extractor(tokens.PSATokenMediaType)(token)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{nonce}, nonces)
+
+	_, err = psaNonces([]byte("token"))
+	assert.ErrorContains(t, err, "PSA token decoding failed")
+}
+
+func TestDICEChainNonces(t *testing.T) {
+	nonce := bytes.Repeat([]byte{0xab}, 32)
+	c := &tokens.DICEChain{Nonce: nonce, Certificates: []tokens.BinaryString{[]byte("cert")}}
+
+	jsonEvidence, err := c.ToJSON()
+	require.NoError(t, err)
+	cborEvidence, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.DICEChainMediaTypeJSON: jsonEvidence,
+		tokens.DICEChainMediaTypeCBOR: cborEvidence,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{nonce}, nonces, mediaType)
+	}
+}
+
+func TestSPDMNonces(t *testing.T) {
+	b, err := spdm.NewEmulatorBackend([]string{"nic0", "nic1"})
+	require.NoError(t, err)
+	p := spdm.NewPlugin(b)
+
+	nonce := bytes.Repeat([]byte{0xab}, tokens.SPDMNonceSize)
+	for _, mediaType := range []string{tokens.SPDMEvidenceMediaTypeJSON, tokens.SPDMEvidenceMediaTypeCBOR} {
+		out := p.GetEvidence(&compositor.EvidenceIn{ContentType: mediaType, Nonce: nonce})
+		require.True(t, out.Status.Result, out.Status.Error)
+
+		nonces, err := Config{}.extractor(mediaType)(out.Evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{nonce}, nonces, mediaType)
+	}
+
+	out := p.GetEvidence(&compositor.EvidenceIn{ContentType: tokens.SPDMEvidenceMediaTypeJSON, Nonce: nonce})
+	require.True(t, out.Status.Result, out.Status.Error)
+
+	e := &tokens.SPDMEvidence{}
+	require.NoError(t, e.FromJSON(out.Evidence))
+	e.Nonce = bytes.Repeat([]byte{0xcd}, tokens.SPDMNonceSize)
+	_, err = spdmNonces(e)
+	assert.EqualError(t, err, "nonce of SPDM device nic0 differs from that of the evidence")
+}
+
+func TestRatsdSelfNonces(t *testing.T) {
+	a, err := self.New(self.Config{Executable: "verify.go", StartTime: time.Now()})
+	require.NoError(t, err)
+
+	nonce := bytes.Repeat([]byte{0xab}, tokens.RatsdSelfNonceSize)
+	for _, mediaType := range []string{tokens.RatsdSelfMediaTypeJSON, tokens.RatsdSelfMediaTypeCBOR} {
+		out := a.GetEvidence(&compositor.EvidenceIn{ContentType: mediaType, Nonce: nonce})
+		require.True(t, out.Status.Result, out.Status.Error)
+
+		nonces, err := Config{}.extractor(mediaType)(out.Evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{nonce}, nonces, mediaType)
+	}
+
+	_, err = ratsdSelfJSONNonces([]byte("{}"))
+	assert.ErrorContains(t, err, "JSON decoding failed")
+}
+
+// newTestRatsdSelfToken returns a legacy token carrying ratsd-self evidence
+// anchored in a mock TSM report, with the anchor replaced by tamper, if any
+func newTestRatsdSelfToken(t *testing.T, tamper func(anchor []byte) []byte) []byte {
+	t.Helper()
+
+	a, err := self.New(self.Config{
+		Executable: "verify.go",
+		StartTime:  time.Now(),
+		Anchor:     mocktsm.GetPlugin(),
+	})
+	require.NoError(t, err)
+
+	out := a.GetEvidence(&compositor.EvidenceIn{
+		ContentType: tokens.RatsdSelfMediaTypeJSON,
+		Nonce:       adjustedTestNonce(t),
+	})
+	require.True(t, out.Status.Result, out.Status.Error)
+	require.Equal(t, tokens.RatsdSelfCollectionType, out.CollectionType)
+
+	item := cmw.NewCollection(tokens.RatsdSelfCollectionType)
+	for _, r := range out.Records {
+		value := r.Value
+		if r.Key == "anchor" && tamper != nil {
+			value = tamper(value)
+		}
+		require.NoError(t, item.AddCollectionItem(r.Key,
+			cmw.NewMonad(r.ContentType, value, cmw.Indicator(r.Indicator))))
+	}
+
+	e := ratsdtoken.NewEvidence()
+	require.NoError(t, e.Claims.SetNonce(testNonce))
+	require.NoError(t, e.Claims.SetNonceAdjustFn(ratsdtoken.NonceAdjustFunctionShake256))
+	require.NoError(t, e.Claims.SetKeyandNonceSz(self.Name, 64))
+
+	collection := cmw.NewCollection("tag:github.com,2025:veraison/ratsd/cmw")
+	require.NoError(t, collection.AddCollectionItem(self.Name, item))
+	require.NoError(t, e.Claims.SetCMW(collection))
+
+	token, err := e.MarshalJSON()
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestRatsdSelfAnchor(t *testing.T) {
+	cfg := Config{NonceExtractors: FakeTSMReportExtractors()}
+
+	result, err := Legacy(newTestRatsdSelfToken(t, nil), testNonce, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{self.Name: adjustedTestNonce(t)}, result.Nonces)
+
+	// an anchor of other evidence, e.g., replayed from another request
+	token := newTestRatsdSelfToken(t, func([]byte) []byte {
+		return fakeTSMReport(t, tokens.RatsdSelfAnchorData([]byte("other evidence")))
+	})
+	_, err = Legacy(token, testNonce, cfg)
+	assert.EqualError(t, err, `sub-attester "ratsd-self": ratsd-self anchor: application/vnd.veraison.tsm-report+json: nonce mismatch`)
+
+	// an anchor bound to the nonce rather than to the evidence
+	token = newTestRatsdSelfToken(t, func([]byte) []byte {
+		return fakeTSMReport(t, adjustedTestNonce(t))
+	})
+	_, err = Legacy(token, testNonce, cfg)
+	assert.ErrorIs(t, err, ErrNonceMismatch)
+}
+
+func TestRegisterNonceExtractorFail(t *testing.T) {
+	assert.EqualError(t, RegisterNonceExtractor("", tsmReportJSONNonces),
+		"empty media type for nonce extractor")
+	assert.EqualError(t, RegisterNonceExtractor("application/vnd.example", nil),
+		`nil nonce extractor for "application/vnd.example"`)
+	assert.EqualError(t, RegisterNonceExtractor(tokens.TSMReportMediaTypeJSON, tsmReportJSONNonces),
+		`nonce extractor for "application/vnd.veraison.tsm-report+json" already registered`)
+}
+
+func TestCELNonces(t *testing.T) {
+	l := &tokens.CEL{Nonce: []byte("nonce")}
+
+	jsonLog, err := l.ToJSON()
+	require.NoError(t, err)
+	cborLog, err := l.ToCBOR()
+	require.NoError(t, err)
+
+	for mediaType, evidence := range map[string][]byte{
+		tokens.CELMediaTypeJSON: jsonLog,
+		tokens.CELMediaTypeCBOR: cborLog,
+	} {
+		nonces, err := Config{}.extractor(mediaType)(evidence)
+		require.NoError(t, err, mediaType)
+		assert.Equal(t, [][]byte{[]byte("nonce")}, nonces, mediaType)
+	}
+}